@@ -31,17 +31,21 @@ type SippyDaemonFlags struct {
 	DBFlags          *flags.PostgresFlags
 	GoogleCloudFlags *flags.GoogleCloudFlags
 
-	GithubCommenterFlags *flags.GithubCommenterFlags
-	MetricsAddr          string
+	GithubCommenterFlags    *flags.GithubCommenterFlags
+	AnnotationCampaignFlags *flags.AnnotationCampaignFlags
+	ChatRetentionFlags      *flags.ChatRetentionFlags
+	MetricsAddr             string
 }
 
 func NewSippyDaemonFlags() *SippyDaemonFlags {
 	return &SippyDaemonFlags{
-		DBFlags:              flags.NewPostgresDatabaseFlags(),
-		BigQueryFlags:        flags.NewBigQueryFlags(),
-		CacheFlags:           flags.NewCacheFlags(),
-		GithubCommenterFlags: flags.NewGithubCommenterFlags(),
-		GoogleCloudFlags:     flags.NewGoogleCloudFlags(),
+		DBFlags:                 flags.NewPostgresDatabaseFlags(),
+		BigQueryFlags:           flags.NewBigQueryFlags(),
+		CacheFlags:              flags.NewCacheFlags(),
+		GithubCommenterFlags:    flags.NewGithubCommenterFlags(),
+		AnnotationCampaignFlags: flags.NewAnnotationCampaignFlags(),
+		ChatRetentionFlags:      flags.NewChatRetentionFlags(),
+		GoogleCloudFlags:        flags.NewGoogleCloudFlags(),
 	}
 }
 
@@ -50,6 +54,8 @@ func (f *SippyDaemonFlags) BindFlags(fs *pflag.FlagSet) {
 	f.CacheFlags.BindFlags(fs)
 	f.DBFlags.BindFlags(fs)
 	f.GithubCommenterFlags.BindFlags(fs)
+	f.AnnotationCampaignFlags.BindFlags(fs)
+	f.ChatRetentionFlags.BindFlags(fs)
 	f.GoogleCloudFlags.BindFlags(fs)
 
 	fs.StringVar(&f.MetricsAddr, "listen-metrics", f.MetricsAddr, "The address to serve prometheus metrics on (default :2112)")
@@ -65,7 +71,7 @@ func NewSippyDaemonCommand() *cobra.Command {
 	// rootCmd represents the base command when called without any subcommands
 	cmd := &cobra.Command{
 		Use:   "sippy-daemon",
-		Short: "Sippy daemon is used for on-going tasks like monitoring git repos for reporting risk analysis.",
+		Short: "Sippy daemon is used for on-going tasks like monitoring git repos for reporting risk analysis and running job run annotation campaigns.",
 		PersistentPreRun: func(c *cobra.Command, args []string) {
 			fmt.Fprintf(os.Stdout, "sippy built from %s\n", version.Get().GitCommit)
 		},
@@ -122,6 +128,22 @@ func NewSippyDaemonCommand() *cobra.Command {
 				processes = append(processes, sippyserver.NewWorkProcessor(dbc, bigQueryClient, gcsClient.Bucket(f.GoogleCloudFlags.StorageBucket), cacheClient, ghCommenter, 10, 5*time.Minute, 5*time.Second, f.GithubCommenterFlags.CommentProcessingDryRun))
 			}
 
+			if f.AnnotationCampaignFlags.AnnotationCampaigns {
+				process, err := f.newAnnotationCampaignProcessor()
+				if err != nil {
+					return errors.WithMessage(err, "couldn't set up annotation campaigns")
+				}
+				processes = append(processes, process)
+			}
+
+			if f.ChatRetentionFlags.Retention > 0 {
+				dbc, err := f.DBFlags.GetDBClient()
+				if err != nil {
+					return err
+				}
+				processes = append(processes, sippyserver.NewChatRetentionProcessor(dbc, f.ChatRetentionFlags.Retention, f.ChatRetentionFlags.Interval))
+			}
+
 			daemonServer := sippyserver.NewDaemonServer(processes)
 
 			// Serve our metrics endpoint for prometheus to scrape
@@ -146,6 +168,39 @@ func NewSippyDaemonCommand() *cobra.Command {
 	return cmd
 }
 
+func (f *SippyDaemonFlags) newAnnotationCampaignProcessor() (*sippyserver.AnnotationCampaignProcessor, error) {
+	dbc, err := f.DBFlags.GetDBClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheClient, err := f.CacheFlags.GetCacheClient()
+	if err != nil {
+		return nil, errors.WithMessage(err, "couldn't get cache client")
+	}
+
+	opCtx := bqlabel.OperationalContext{
+		App:         bqlabel.AppSippy,
+		Command:     "sippy-daemon",
+		Environment: bqlabel.EnvDaemon,
+	}
+	bigQueryClient, err := f.BigQueryFlags.GetBigQueryClient(context.Background(), opCtx, cacheClient, f.GoogleCloudFlags.ServiceAccountCredentialFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "couldn't get bigquery client")
+	}
+
+	gcsClient, err := gcs.NewGCSClient(context.TODO(),
+		f.GoogleCloudFlags.ServiceAccountCredentialFile,
+		f.GoogleCloudFlags.OAuthClientCredentialFile,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "couldn't get gcs client")
+	}
+
+	return sippyserver.NewAnnotationCampaignProcessor(dbc, bigQueryClient, gcsClient, cacheClient,
+		f.AnnotationCampaignFlags.Interval, f.AnnotationCampaignFlags.SettleTime, f.AnnotationCampaignFlags.AnnotationCampaignsDryRun), nil
+}
+
 func main() {
 	// Set log level
 	level, err := log.ParseLevel(logLevel)
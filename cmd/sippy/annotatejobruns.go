@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/openshift/sippy/pkg/api/componentreadiness"
@@ -50,6 +49,7 @@ type AnnotateJobRunsFlags struct {
 	JobRunIDs               *[]int64
 	Comment                 string
 	User                    string
+	CampaignID              uint
 }
 
 func NewAnnotateJobRunsFlags() *AnnotateJobRunsFlags {
@@ -85,29 +85,19 @@ func (f *AnnotateJobRunsFlags) BindFlags(fs *pflag.FlagSet) {
 	f.JobRunIDs = fs.Int64Slice("job-run-id", []int64{}, "A list of job runs to apply the label. Can be used if you already know the job IDs you want to apply the label. This list can be further filtered by other arguments")
 	fs.StringVar(&f.Comment, "comment", f.Comment, "Comment you want to add with the label. This can serve as breadcrumbs to show where the label is from.")
 	fs.StringVar(&f.User, "user", os.Getenv("USER"), "User who is applying the label.")
+	fs.UintVar(&f.CampaignID, "campaign-id", f.CampaignID, "Run the stored annotation campaign with this ID over the job runs started since it last ran, instead of a search given by flags. Progress is only recorded with --execute.")
 }
 
 func (f *AnnotateJobRunsFlags) Validate(allVariants crtest.JobVariants) error {
-	for _, variantStr := range f.VariantStr {
-		vt := strings.Split(variantStr, ":")
-		if len(vt) != 2 {
-			return fmt.Errorf("--variant %s is in wrong format", variantStr)
-		}
-		vs, ok := allVariants.Variants[vt[0]]
-		if !ok {
-			return fmt.Errorf("--variant %s has wrong variant name %s", variantStr, vt[0])
-		}
-		found := false
-		for _, v := range vs {
-			if v == vt[1] {
-				found = true
-			}
-		}
-		if !found {
-			return fmt.Errorf("--variant %s has wrong variant value %s", variantStr, vt[1])
-		}
-		f.Variants = append(f.Variants, crstatus.Variant{Key: vt[0], Value: vt[1]})
+	if f.CampaignID != 0 {
+		// the search is stored with the campaign
+		return f.GoogleCloudFlags.Validate()
+	}
+	variants, err := jobrunannotator.ParseVariants(f.VariantStr, allVariants)
+	if err != nil {
+		return errors.WithMessage(err, "--variant")
 	}
+	f.Variants = variants
 	if len(f.Label) == 0 {
 		return fmt.Errorf("--label is required")
 	}
@@ -135,6 +125,7 @@ func NewAnnotateJobRunsCommand() *cobra.Command {
 		Use:   "annotate-job-runs",
 		Short: "Annotate job runs",
 		Long: `Find all job runs that match the passed criteria and annotate them with desired label.
+A stored annotation campaign can be run instead with --campaign-id, which scans the job runs started since the campaign last ran.
 Example run: sippy annotate-job-runs  --google-service-account-credential-file=file.json --database-dsn="$DSN_PROD" --label=test --start-time="2025-05-21T00:00:00Z" --duration=48h --release=4.19 --min-failures=2 --variant=Platform:vsphere --path-glob="build-log.txt" --text-regex='\[error\]|"error"|level=error' --job-run-id=1925488012808949760 --job-run-id=1925488012808949761 --flake-as-failure=true --comment "ken test"  --build-cluster="build01" --build-cluster="vsphere02" --user=ken`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Hour*1)
@@ -189,6 +180,28 @@ Example run: sippy annotate-job-runs  --google-service-account-credential-file=f
 				return errors.WithMessage(err, "error validating options")
 			}
 
+			if f.CampaignID != 0 {
+				campaign, err := jobrunannotator.GetCampaign(dbc.DB, f.CampaignID)
+				if err != nil {
+					return err
+				}
+				if campaign == nil {
+					return fmt.Errorf("annotation campaign %d not found", f.CampaignID)
+				}
+				runner := jobrunannotator.NewCampaignRunner(bigQueryClient, cacheOpts, gcsClient, dbc, cacheClient,
+					f.Execute, jobrunannotator.DefaultCampaignSettleTime)
+				result, err := runner.Run(ctx, *campaign, allVariants)
+				if err != nil {
+					return errors.WithMessagef(err, "error running annotation campaign %d", f.CampaignID)
+				}
+				log.WithFields(log.Fields{
+					"scanned": result.Scanned,
+					"matched": result.Matched,
+					"labeled": result.Labeled,
+				}).Infof("ran annotation campaign %d", f.CampaignID)
+				return nil
+			}
+
 			jobRunannotator, err := jobrunannotator.NewJobRunAnnotator(
 				bigQueryClient,
 				cacheOpts,
@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -42,6 +45,10 @@ type AutomateJiraFlags struct {
 	ColumnThresholds    map[jiraautomator.Variant]int
 	JiraAccount         string
 	DryRun              bool
+	// PlanFile and ApplyFile are where a plan of the jira changes is saved to instead of applying
+	// them, and the saved plan to apply.
+	PlanFile  string
+	ApplyFile string
 }
 
 func NewAutomateJiraFlags() *AutomateJiraFlags {
@@ -71,6 +78,19 @@ func (f *AutomateJiraFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.JiraAccount, "jira-account", f.JiraAccount, "The jira account used to automate jira")
 	fs.BoolVar(&f.DryRun, "dry-run", f.DryRun, "Print the tasks of automating jiras without real interaction with jira.")
 	fs.StringVar(&f.DataProvider, "data-provider", "default", "Data provider: default, bigquery, or postgres")
+	fs.StringVar(&f.PlanFile, "plan", f.PlanFile, "Write the jira changes to this JSON file, and a markdown summary next to it, instead of making them.")
+	fs.StringVar(&f.ApplyFile, "apply", f.ApplyFile, "Make the jira changes of a plan previously written with --plan, skipping components whose issues have changed since.")
+}
+
+// ValidateMode checks the flags that choose between running, planning and applying a plan.
+func (f *AutomateJiraFlags) ValidateMode() error {
+	if len(f.PlanFile) > 0 && len(f.ApplyFile) > 0 {
+		return fmt.Errorf("--plan and --apply cannot be used together")
+	}
+	if len(f.PlanFile) > 0 && filepath.Ext(f.PlanFile) == ".md" {
+		return fmt.Errorf("--plan %s must not be a markdown file, the summary is written next to it", f.PlanFile)
+	}
+	return nil
 }
 
 func (f *AutomateJiraFlags) Validate(allVariants crtest.JobVariants) error {
@@ -128,6 +148,13 @@ func NewAutomateJiraCommand() *cobra.Command {
 		Short: "Automate jira with component readiness regressions",
 		Long:  "Check the component report for each view with automate jira enabled. Maintains jira cards for current regressions automatically.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.ValidateMode(); err != nil {
+				return errors.WithMessage(err, "error validating options")
+			}
+			if len(f.ApplyFile) > 0 {
+				return f.applyPlan()
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), time.Hour*1)
 			defer cancel()
 
@@ -194,6 +221,13 @@ func NewAutomateJiraCommand() *cobra.Command {
 			if err != nil {
 				panic(err)
 			}
+			if len(f.PlanFile) > 0 {
+				plan, err := j.Plan()
+				if err != nil {
+					return err
+				}
+				return writeJiraPlan(f.PlanFile, plan)
+			}
 			return j.Run()
 		},
 	}
@@ -202,3 +236,61 @@ func NewAutomateJiraCommand() *cobra.Command {
 
 	return cmd
 }
+
+// writeJiraPlan writes the plan as JSON to path, and its markdown summary to path with a .md extension.
+func writeJiraPlan(path string, plan jiraautomator.Plan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.WithMessage(err, "couldn't write jira plan")
+	}
+
+	summaryPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".md"
+	summary, err := os.Create(summaryPath)
+	if err != nil {
+		return errors.WithMessage(err, "couldn't write jira plan summary")
+	}
+	defer summary.Close()
+	if err := plan.WriteMarkdown(summary); err != nil {
+		return errors.WithMessage(err, "couldn't write jira plan summary")
+	}
+	log.Infof("Wrote jira plan to %s and its summary to %s", path, summaryPath)
+	return nil
+}
+
+// applyPlan applies the plan saved in ApplyFile, which only needs a jira client.
+func (f *AutomateJiraFlags) applyPlan() error {
+	data, err := os.ReadFile(f.ApplyFile)
+	if err != nil {
+		return errors.WithMessage(err, "couldn't read jira plan")
+	}
+	var plan jiraautomator.Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return errors.WithMessage(err, "couldn't parse jira plan")
+	}
+	if len(f.JiraAccount) > 0 && f.JiraAccount != plan.JiraAccount {
+		return fmt.Errorf("--jira-account %s does not match the account %s of the plan", f.JiraAccount, plan.JiraAccount)
+	}
+
+	jiraClient, err := f.JiraFlags.GetJiraClient()
+	if err != nil {
+		return errors.WithMessage(err, "couldn't get jira client")
+	}
+	if jiraClient == nil {
+		return fmt.Errorf("couldn't get jira client: jira auth is not configured")
+	}
+
+	results := jiraautomator.NewJiraPlanApplier(jiraClient, f.DryRun).Apply(plan)
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to apply jira plan for %d of %d components", failed, len(results))
+	}
+	return nil
+}
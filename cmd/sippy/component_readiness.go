@@ -188,6 +188,16 @@ func (f *ComponentReadinessFlags) runServerMode() error {
 		log.WithError(err).Warn("unable to initialize Jira client, bug filing will be disabled")
 	}
 
+	authorization, err := f.APIFlags.GetAuthorizationConfig()
+	if err != nil {
+		log.WithError(err).Fatal("unable to load authorization config")
+	}
+
+	rateLimits, err := f.APIFlags.GetRateLimits()
+	if err != nil {
+		log.WithError(err).Fatal("invalid rate limits")
+	}
+
 	crDataProvider, err := flags.NewDataProvider(f.DataProvider, bigQueryClient, dbc, cacheClient)
 	if err != nil {
 		return err
@@ -215,6 +225,8 @@ func (f *ComponentReadinessFlags) runServerMode() error {
 		f.APIFlags.EnableWriteEndpoints,
 		"", // No chat API in Component Readiness
 		jiraClient,
+		authorization,
+		rateLimits,
 	)
 
 	if f.APIFlags.MetricsAddr != "" {
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/api/featuregatepromotion"
+	"github.com/openshift/sippy/pkg/flags"
+)
+
+func NewFeatureGatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feature-gates",
+		Short: "Subcommands available for working with feature gates",
+	}
+
+	cmd.AddCommand(NewFeatureGatesReportCommand())
+	return cmd
+}
+
+type FeatureGatesReportFlags struct {
+	DBFlags    *flags.PostgresFlags
+	CacheFlags *flags.CacheFlags
+	Releases   []string
+	Output     string
+	CompareTo  string
+	Store      bool
+}
+
+func NewFeatureGatesReportFlags() *FeatureGatesReportFlags {
+	return &FeatureGatesReportFlags{
+		DBFlags:    flags.NewPostgresDatabaseFlags(),
+		CacheFlags: flags.NewCacheFlags(),
+		Store:      true,
+	}
+}
+
+func (f *FeatureGatesReportFlags) BindFlags(fs *pflag.FlagSet) {
+	f.DBFlags.BindFlags(fs)
+	f.CacheFlags.BindFlags(fs)
+	fs.StringArrayVar(&f.Releases, "release", f.Releases, "Which releases to report on (one per arg instance)")
+	fs.StringVar(&f.Output, "output", f.Output, "Write the JSON report to this file, and a markdown summary next to it. With several releases, the release is added to the file names. Without it the markdown summary is printed.")
+	fs.StringVar(&f.CompareTo, "compare-to", f.CompareTo, "Compare with the stored report of this day (YYYY-MM-DD), or the latest before it. Defaults to a week before.")
+	fs.BoolVar(&f.Store, "store", f.Store, "Store the report in the database as today's report, for later trend comparison")
+}
+
+func (f *FeatureGatesReportFlags) Validate() error {
+	if len(f.Releases) == 0 {
+		return fmt.Errorf("--release is required")
+	}
+	if f.CompareTo != "" {
+		if _, err := time.Parse(featuregatepromotion.ReportDateFormat, f.CompareTo); err != nil {
+			return fmt.Errorf("--compare-to %s is not a YYYY-MM-DD date", f.CompareTo)
+		}
+	}
+	if filepath.Ext(f.Output) == ".md" {
+		return fmt.Errorf("--output %s must not be a markdown file, the summary is written next to it", f.Output)
+	}
+	return nil
+}
+
+func NewFeatureGatesReportCommand() *cobra.Command {
+	f := NewFeatureGatesReportFlags()
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report the promotion readiness of every TechPreview and DevPreview feature gate of a release",
+		Long: "Runs the promotion analysis of /api/feature_gates/{feature_gate} for every feature gate of a release that is " +
+			"not yet promoted to Default, storing the day's results so that they can be compared week over week.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.Validate(); err != nil {
+				return errors.WithMessage(err, "error validating options")
+			}
+			dbc, err := f.DBFlags.GetDBClient()
+			if err != nil {
+				return err
+			}
+			cacheClient, err := f.CacheFlags.GetCacheClient()
+			if err != nil {
+				log.WithError(err).Warn("failed to get cache client, test results will not be cached")
+				cacheClient = nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour*1)
+			defer cancel()
+
+			for _, release := range f.Releases {
+				report, err := featuregatepromotion.GenerateReleaseReport(ctx, dbc, cacheClient, release, time.Now())
+				if err != nil {
+					return errors.WithMessagef(err, "couldn't generate feature gate promotion report for %s", release)
+				}
+				if f.Store {
+					if err := featuregatepromotion.SaveReleaseReport(dbc.DB, *report); err != nil {
+						return err
+					}
+				}
+				if err := featuregatepromotion.AddTrend(dbc.DB, report, f.CompareTo); err != nil {
+					return errors.WithMessagef(err, "couldn't compare feature gate promotion report for %s", release)
+				}
+				if err := f.writeReport(*report); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	f.BindFlags(cmd.Flags())
+
+	return cmd
+}
+
+// writeReport writes the report as JSON to Output, and its markdown summary to Output with a .md
+// extension, or prints the summary without an Output.
+func (f *FeatureGatesReportFlags) writeReport(report featuregatepromotion.ReleaseReport) error {
+	if f.Output == "" {
+		return report.WriteMarkdown(os.Stdout)
+	}
+
+	base := strings.TrimSuffix(f.Output, filepath.Ext(f.Output))
+	path := f.Output
+	if len(f.Releases) > 1 {
+		base += "-" + report.Release
+		path = base + filepath.Ext(f.Output)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.WithMessage(err, "couldn't write feature gate promotion report")
+	}
+
+	summaryPath := base + ".md"
+	summary, err := os.Create(summaryPath)
+	if err != nil {
+		return errors.WithMessage(err, "couldn't write feature gate promotion report summary")
+	}
+	defer summary.Close()
+	if err := report.WriteMarkdown(summary); err != nil {
+		return errors.WithMessage(err, "couldn't write feature gate promotion report summary")
+	}
+	log.Infof("Wrote feature gate promotion report for %s to %s and its summary to %s", report.Release, path, summaryPath)
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"cloud.google.com/go/bigquery"
@@ -37,7 +39,6 @@ import (
 	"github.com/openshift/sippy/pkg/dataloader/loaderwithmetrics"
 	"github.com/openshift/sippy/pkg/dataloader/prmergesyncloader"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader"
-	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/github"
 	releasedefloader "github.com/openshift/sippy/pkg/dataloader/releasedefloader"
 	"github.com/openshift/sippy/pkg/dataloader/releaseloader"
@@ -64,11 +65,17 @@ type LoadFlags struct {
 	CacheFlags              *flags.CacheFlags
 	ComponentReadinessFlags *flags.ComponentReadinessFlags
 	JiraFlags               *flags.JiraFlags
+	NotificationFlags       *flags.NotificationFlags
+	ArtifactSourceFlags     *flags.ArtifactSourceFlags
 	JobVariantsInputFile    string
 	LogLevel                string
 	ProwLoadSince           string
 	SkipMatviewRefresh      bool
 	ForceGARefresh          bool
+	// LoaderTimeouts override the default timeout for individual loaders, as name=duration.
+	LoaderTimeouts     []string
+	DefaultTimeout     time.Duration
+	MaxParallelLoaders int
 	// DataProvider selects the component readiness data backend used by the
 	// regression cache loader: "default" (auto-select from the configured
 	// clients), "bigquery", or "postgres" (PostgreSQL-only, requires no BigQuery
@@ -83,16 +90,19 @@ var loadMetricGauge = promauto.NewGauge(prometheus.GaugeOpts{
 })
 
 func NewLoadFlags() *LoadFlags {
+	googleCloudFlags := flags.NewGoogleCloudFlags()
 	return &LoadFlags{
 		BigQueryFlags:           flags.NewBigQueryFlags(),
 		ConfigFlags:             configflags.NewConfigFlags(),
 		DBFlags:                 flags.NewPostgresDatabaseFlags(),
 		GithubCommenterFlags:    flags.NewGithubCommenterFlags(),
-		GoogleCloudFlags:        flags.NewGoogleCloudFlags(),
+		GoogleCloudFlags:        googleCloudFlags,
 		ModeFlags:               flags.NewModeFlags(),
 		CacheFlags:              flags.NewCacheFlags(),
 		ComponentReadinessFlags: flags.NewComponentReadinessFlags(),
 		JiraFlags:               flags.NewJiraFlags(),
+		NotificationFlags:       flags.NewNotificationFlags(),
+		ArtifactSourceFlags:     flags.NewArtifactSourceFlags(googleCloudFlags),
 	}
 }
 
@@ -106,6 +116,8 @@ func (f *LoadFlags) BindFlags(fs *pflag.FlagSet) {
 	f.CacheFlags.BindFlags(fs)
 	f.ComponentReadinessFlags.BindFlags(fs)
 	f.JiraFlags.BindFlags(fs)
+	f.NotificationFlags.BindFlags(fs)
+	f.ArtifactSourceFlags.BindFlags(fs)
 
 	fs.BoolVar(&f.InitDatabase, "init-database", false, "Migrate the DB before loading")
 	fs.StringArrayVar(&f.Loaders, "loader", []string{"release-definitions", "pr-merge-sync", "prow", "releases", "jira", "github", "bugs", "test-mapping", "feature-gates", "ga-test-status"}, "Which data sources to use for data loading")
@@ -116,6 +128,9 @@ func (f *LoadFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.ProwLoadSince, "prow-load-since", "", "Override how far back to load prow jobs (e.g. 2024-01-15T00:00:00Z or 72h for 72 hours ago)")
 	fs.BoolVar(&f.SkipMatviewRefresh, "skip-matview-refresh", false, "Skip refreshing materialized views after loading")
 	fs.BoolVar(&f.ForceGARefresh, "force-ga-refresh", false, "Force re-population of GA test status data from BigQuery")
+	fs.StringArrayVar(&f.LoaderTimeouts, "loader-timeout", f.LoaderTimeouts, "Timeout for a single loader as name=duration, e.g. prow=3h (one per arg instance)")
+	fs.DurationVar(&f.DefaultTimeout, "default-loader-timeout", loaderwithmetrics.DefaultTimeout, "Timeout for loaders without a --loader-timeout")
+	fs.IntVar(&f.MaxParallelLoaders, "max-parallel-loaders", 0, "Maximum number of loaders to run at once, 0 for no limit")
 	fs.StringVar(&f.DataProvider, "data-provider", "default", "Data provider for component readiness regression cache loading: default (auto-select from the configured clients), bigquery, or postgres (PostgreSQL-only, requires no BigQuery credentials)")
 }
 
@@ -133,11 +148,15 @@ func NewLoadCommand() *cobra.Command {
 			}
 			log.SetLevel(level)
 
-			loaders := make([]dataloader.DataLoader, 0)
 			allErrs := []error{}
 
-			// Cancel syncing after 4 hours
-			ctx, cancel := context.WithTimeout(context.Background(), time.Hour*4)
+			timeouts, err := f.parseLoaderTimeouts()
+			if err != nil {
+				return err
+			}
+
+			// Each loader has its own timeout, this only releases their contexts once loading is done
+			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
 			start := time.Now()
@@ -152,6 +171,8 @@ func NewLoadCommand() *cobra.Command {
 				t := f.DBFlags.GetPinnedTime()
 				if err := dbc.UpdateSchema(t); err != nil {
 					dbErr = errors.WithMessage(err, "could not migrate db")
+				} else if err := seedRegressionAllowances(ctx, dbc); err != nil {
+					dbErr = err
 				}
 			}
 
@@ -220,6 +241,14 @@ func NewLoadCommand() *cobra.Command {
 				promPusher.Collector(loadMetricGauge)
 			}
 
+			// Loaders run concurrently once the loaders they depend on are done, each with a context
+			// from the runner that enforces its timeout.
+			runner := loaderwithmetrics.New(ctx, loaderwithmetrics.Options{
+				MaxParallel: f.MaxParallelLoaders,
+				Timeout:     f.DefaultTimeout,
+				Timeouts:    timeouts,
+			})
+
 			var regressionCacheAdded bool
 			for _, l := range f.Loaders {
 				if l == "release-definitions" {
@@ -229,8 +258,8 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return errors.Wrap(dbErr, "CRITICAL error getting postgres client which prevents release-definitions loading")
 					}
-					rdl := releasedefloader.NewReleaseDefinitionLoader(ctx, dbc, bqc)
-					loaders = append(loaders, rdl)
+					rdl := releasedefloader.NewReleaseDefinitionLoader(runner.Context(l), dbc, bqc)
+					runner.Add(rdl)
 				}
 
 				// TODO: remove "component-readiness-cache" and "regression-tracker" once the cronjob
@@ -275,7 +304,11 @@ func NewLoadCommand() *cobra.Command {
 					if jErr != nil {
 						return errors.Wrap(jErr, "CRITICAL error getting jira client which prevents regression tracking")
 					}
-					regressionStore := componentreadiness.NewPostgresRegressionStore(dbc, jiraClient)
+					notifier, err := f.NotificationFlags.GetNotifier()
+					if err != nil {
+						return errors.Wrap(err, "error loading notifications config")
+					}
+					regressionStore := componentreadiness.NewPostgresRegressionStore(dbc, jiraClient, notifier)
 
 					crDataProvider, err := flags.NewDataProvider(f.DataProvider, bqClient, dbc, cacheClient)
 					if err != nil {
@@ -283,7 +316,7 @@ func NewLoadCommand() *cobra.Command {
 					}
 
 					rcl, err := regressioncacheloader.New(
-						dbc, crDataProvider, config, views.ComponentReadiness, releaseConfigs,
+						runner.Context(l), dbc, crDataProvider, config, views.ComponentReadiness, releaseConfigs,
 						f.ComponentReadinessFlags.CRTimeRoundingFactor,
 						f.ComponentReadinessFlags.CRTimeRoundingOffset,
 						regressionStore,
@@ -291,22 +324,23 @@ func NewLoadCommand() *cobra.Command {
 					if err != nil {
 						return errors.Wrap(err, "error creating regression cache loader")
 					}
-					loaders = append(loaders, rcl)
+					runner.Add(rcl)
 				}
 
 				if l == "releases" {
 					if dbErr != nil {
 						return dbErr
 					}
-					loaders = append(loaders, releaseloader.New(ctx, dbc, bqc, f.Releases, f.Architectures, releaseConfigs))
+					runner.Add(releaseloader.New(runner.Context(l), dbc, bqc, f.Releases, f.Architectures, releaseConfigs))
 				}
 
 				if l == "pr-merge-sync" {
 					if dbErr != nil {
 						return dbErr
 					}
-					ghClient := github.New(ctx, github.OpenshiftOrg)
-					loaders = append(loaders, prmergesyncloader.New(ctx, dbc, ghClient))
+					loaderCtx := runner.Context(l)
+					ghClient := github.New(loaderCtx, github.OpenshiftOrg)
+					runner.Add(prmergesyncloader.New(loaderCtx, dbc, ghClient))
 				}
 
 				// Prow Loader
@@ -315,12 +349,12 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return dbErr
 					}
-					prowLoader, err := f.prowLoader(ctx, dbc, config, releaseConfigs, promPusher)
+					prowLoader, err := f.prowLoader(runner.Context(l), dbc, config, releaseConfigs, promPusher)
 					if err != nil {
 						return err
 					}
 
-					loaders = append(loaders, prowLoader)
+					runner.Add(prowLoader)
 				}
 
 				// JIRA Loader
@@ -328,7 +362,7 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return dbErr
 					}
-					loaders = append(loaders, jiraloader.New(dbc))
+					runner.Add(jiraloader.New(runner.Context(l), dbc))
 				}
 
 				// Load mapping for jira components to tests
@@ -337,7 +371,7 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return dbErr
 					}
-					cl, err := testownershiploader.New(ctx,
+					cl, err := testownershiploader.New(runner.Context(l),
 						dbc,
 						f.GoogleCloudFlags.ServiceAccountCredentialFile,
 						f.GoogleCloudFlags.OAuthClientCredentialFile)
@@ -345,7 +379,7 @@ func NewLoadCommand() *cobra.Command {
 						return errors.WithMessage(err, "failed to create component loader")
 					}
 
-					loaders = append(loaders, cl)
+					runner.Add(cl)
 				}
 
 				// Bug Loader
@@ -356,16 +390,16 @@ func NewLoadCommand() *cobra.Command {
 					if bigqueryErr != nil {
 						return errors.WithMessage(bigqueryErr, "could not get bigquery client")
 					}
-					loaders = append(loaders, bugloader.New(ctx, dbc, bqc))
+					runner.Add(bugloader.New(runner.Context(l), dbc, bqc))
 				}
 
 				// Load Job Variants into BigQuery
 				if l == "job-variants" {
-					variantsLoader, err := f.jobVariantsLoader(ctx)
+					variantsLoader, err := f.jobVariantsLoader(runner.Context(l))
 					if err != nil {
 						return err
 					}
-					loaders = append(loaders, variantsLoader)
+					runner.Add(variantsLoader)
 				}
 
 				// Sync postgres variants from BigQuery -- directly updates all jobs immediately
@@ -375,11 +409,11 @@ func NewLoadCommand() *cobra.Command {
 					if bigqueryErr != nil {
 						return errors.WithMessage(bigqueryErr, "could not get bigquery client")
 					}
-					vs, err := variantsyncer.New(dbc, bqc)
+					vs, err := variantsyncer.New(runner.Context(l), dbc, bqc)
 					if err != nil {
 						return err
 					}
-					loaders = append(loaders, vs)
+					runner.Add(vs)
 				}
 
 				// Feature gates
@@ -388,9 +422,10 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return dbErr
 					}
-					ghc := github.New(ctx, github.OpenshiftOrg)
-					fgLoader := featuregateloader.New(ctx, dbc, ghc.APIClient(), releaseConfigs)
-					loaders = append(loaders, fgLoader)
+					loaderCtx := runner.Context(l)
+					ghc := github.New(loaderCtx, github.OpenshiftOrg)
+					fgLoader := featuregateloader.New(loaderCtx, dbc, ghc.APIClient(), releaseConfigs)
+					runner.Add(fgLoader)
 				}
 
 				if l == "ga-test-status" {
@@ -401,17 +436,16 @@ func NewLoadCommand() *cobra.Command {
 					if dbErr != nil {
 						return errors.Wrap(dbErr, "CRITICAL error getting postgres client which prevents ga-test-status loading")
 					}
-					loaders = append(loaders, gateststatus.New(ctx, dbc, bqc, f.ForceGARefresh, f.Releases))
+					runner.Add(gateststatus.New(runner.Context(l), dbc, bqc, f.ForceGARefresh, f.Releases))
 				}
 
 			}
 
-			// Run loaders with the metrics wrapper
-			l := loaderwithmetrics.New(loaders)
-			l.Load()
-			if len(l.Errors()) > 0 {
-				allErrs = append(allErrs, l.Errors()...)
+			runner.Load()
+			if len(runner.Errors()) > 0 {
+				allErrs = append(allErrs, runner.Errors()...)
 			}
+			printLoaderSummary(runner.Results())
 
 			elapsed := time.Since(start)
 			log.WithField("elapsed", elapsed).Info("database load complete")
@@ -453,6 +487,41 @@ func NewLoadCommand() *cobra.Command {
 	return cmd
 }
 
+// parseLoaderTimeouts parses the --loader-timeout name=duration values.
+func (f *LoadFlags) parseLoaderTimeouts() (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, val := range f.LoaderTimeouts {
+		name, duration, ok := strings.Cut(val, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --loader-timeout value %q: must be name=duration", val)
+		}
+		d, err := time.ParseDuration(duration)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid --loader-timeout value %q: must be a positive duration (e.g. 30m)", val)
+		}
+		timeouts[name] = d
+	}
+	return timeouts, nil
+}
+
+// printLoaderSummary prints each loader's duration, error count and rows written, in the order they finished.
+func printLoaderSummary(results []loaderwithmetrics.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOADER\tDURATION\tERRORS\tROWS WRITTEN")
+	for _, r := range results {
+		rows := "-"
+		if r.RowsWritten >= 0 {
+			rows = fmt.Sprintf("%d", r.RowsWritten)
+		}
+		errs := fmt.Sprintf("%d", r.Errors)
+		if r.TimedOut {
+			errs += " (timed out)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Duration.Round(time.Second), errs, rows)
+	}
+	w.Flush()
+}
+
 func (f *LoadFlags) jobVariantsLoader(ctx context.Context) (dataloader.DataLoader, error) {
 	bigQueryClient, err := bigquery.NewClient(ctx, f.BigQueryFlags.BigQueryProject,
 		option.WithCredentialsFile(f.GoogleCloudFlags.ServiceAccountCredentialFile))
@@ -491,22 +560,27 @@ func (f *LoadFlags) jobVariantsLoader(ctx context.Context) (dataloader.DataLoade
 }
 
 func (f *LoadFlags) prowLoader(ctx context.Context, dbc *db.DB, sippyConfig *v1.SippyConfig, releaseConfigs []sippyv1.Release, promPusher *push.Pusher) (dataloader.DataLoader, error) {
-	gcsClient, err := gcs.NewGCSClient(ctx,
-		f.GoogleCloudFlags.ServiceAccountCredentialFile,
-		f.GoogleCloudFlags.OAuthClientCredentialFile,
-	)
+	artifacts, err := f.ArtifactSourceFlags.GetArtifactSource(ctx)
 	if err != nil {
-		log.WithError(err).Error("CRITICAL error getting GCS client which prevents importing prow jobs")
+		log.WithError(err).Error("CRITICAL error getting artifact source which prevents importing prow jobs")
 		return nil, err
 	}
 
+	// BigQuery provides the job list, labels and variants for OpenShift CI. Other prow instances' jobs are
+	// read from the prow URL in the sippy config instead, which may also be a local prowjobs.js file.
 	opCtx, ctx := bqcachedclient.OpCtxForCronEnv(ctx, "load")
-	bigQueryClient, err := bqcachedclient.New(
-		ctx, opCtx, nil,
-		f.GoogleCloudFlags.ServiceAccountCredentialFile, f.BigQueryFlags.BigQueryProject, f.BigQueryFlags.BigQueryDataset, f.BigQueryFlags.ReleasesTable)
-	if err != nil {
-		log.WithError(err).Error("CRITICAL error getting BigQuery client which prevents importing prow jobs")
-		return nil, err
+	var bigQueryClient *bqcachedclient.Client
+	if f.ArtifactSourceFlags.UsesGoogleCloud() {
+		bigQueryClient, err = bqcachedclient.New(
+			ctx, opCtx, nil,
+			f.GoogleCloudFlags.ServiceAccountCredentialFile, f.BigQueryFlags.BigQueryProject, f.BigQueryFlags.BigQueryDataset, f.BigQueryFlags.ReleasesTable)
+		if err != nil {
+			log.WithError(err).Error("CRITICAL error getting BigQuery client which prevents importing prow jobs")
+			return nil, err
+		}
+	} else if f.ModeFlags.Mode == flags.ModeOpenshift {
+		return nil, fmt.Errorf("--mode=%s requires BigQuery, use --mode=%s to load from --artifact-source=%s",
+			flags.ModeOpenshift, flags.ModeNone, f.ArtifactSourceFlags.Source)
 	}
 
 	var githubClient *github.Client
@@ -547,7 +621,7 @@ func (f *LoadFlags) prowLoader(ctx context.Context, dbc *db.DB, sippyConfig *v1.
 	return prowloader.New(
 		ctx,
 		dbc,
-		gcsClient,
+		artifacts,
 		bigQueryClient,
 		githubClient,
 		f.ModeFlags.GetVariantManager(ctx, bigQueryClient),
@@ -53,6 +53,7 @@ func main() {
 		NewComponentReadinessCommand(),
 		NewAutomateJiraCommand(),
 		NewVariantsCommand(),
+		NewFeatureGatesCommand(),
 		NewVersionCommand(),
 		NewAnnotateJobRunsCommand(),
 		NewSeedDataCommand(),
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strconv"
 
 	gomigrate "github.com/golang-migrate/migrate/v4"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	gormlogger "gorm.io/gorm/logger"
 
 	"github.com/openshift/sippy/pkg/db"
 	sippymigrate "github.com/openshift/sippy/pkg/db/migrate"
+	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/flags"
+	"github.com/openshift/sippy/pkg/regressionallowances"
 )
 
 func init() {
@@ -31,7 +36,7 @@ func init() {
 				return errors.WithMessage(err, "could not migrate db")
 			}
 
-			return nil
+			return seedRegressionAllowances(cmd.Context(), dbc)
 		},
 	}
 
@@ -114,6 +119,57 @@ func init() {
 	}
 	f.BindFlags(downCmd.Flags())
 
-	cmd.AddCommand(versionCmd, forceCmd, downCmd)
+	var allowancesDir string
+	allowancesCmd := &cobra.Command{
+		Use:   "regression-allowances",
+		Short: "Import intentional regression allowances from JSON files into the database.",
+		Long: `Import intentional regression allowances from JSON files into the database. By default the
+files compiled into sippy from pkg/regressionallowances/regressions are imported; use --dir to import
+a directory with the same layout (one sub-directory of JSON files per release). Allowances that already
+exist for the same release, test and variants are skipped, so this is safe to run repeatedly.
+Migrating the schema imports the built-in set automatically while the database has no allowances.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := regressionallowances.Embedded()
+			if allowancesDir != "" {
+				source = os.DirFS(allowancesDir)
+			}
+			allowances, err := regressionallowances.LoadJSON(source)
+			if err != nil {
+				return errors.WithMessage(err, "could not load regression allowances")
+			}
+
+			dbc, err := db.New(f.DSN, gormlogger.LogLevel(f.LogLevel))
+			if err != nil {
+				return errors.WithMessage(err, "could not connect to db")
+			}
+
+			ctx := context.WithValue(cmd.Context(), models.CurrentUserKey, "sippy migrate regression-allowances")
+			created, skipped, err := regressionallowances.Import(dbc.DB.WithContext(ctx), allowances)
+			if err != nil {
+				return errors.WithMessage(err, "could not import regression allowances")
+			}
+
+			fmt.Printf("imported %d regression allowance(s), skipped %d already present\n", created, skipped)
+			return nil
+		},
+	}
+	f.BindFlags(allowancesCmd.Flags())
+	allowancesCmd.Flags().StringVar(&allowancesDir, "dir", "", "Directory of per-release regression allowance JSON files to import instead of the built-in set")
+
+	cmd.AddCommand(versionCmd, forceCmd, downCmd, allowancesCmd)
 	rootCmd.AddCommand(cmd)
 }
+
+// seedRegressionAllowances imports the built-in regression allowances after a schema update if the
+// database has none yet, see regressionallowances.SeedEmbedded.
+func seedRegressionAllowances(ctx context.Context, dbc *db.DB) error {
+	ctx = context.WithValue(ctx, models.CurrentUserKey, "sippy migrate")
+	created, err := regressionallowances.SeedEmbedded(dbc.DB.WithContext(ctx))
+	if err != nil {
+		return errors.WithMessage(err, "could not seed regression allowances")
+	}
+	if created > 0 {
+		log.Infof("seeded %d built-in regression allowance(s)", created)
+	}
+	return nil
+}
@@ -80,6 +80,9 @@ Drop and recreate the database to re-seed (e.g. docker compose down -v).
 				if err := dbc.UpdateSchema(t); err != nil {
 					return errors.WithMessage(err, "could not migrate database")
 				}
+				if err := seedRegressionAllowances(cmd.Context(), dbc); err != nil {
+					return err
+				}
 				log.Info("Database schema initialized successfully")
 
 				// Create partitions for synthetic releases
@@ -963,7 +966,7 @@ func syncRegressions(dbc *db.DB) error {
 		return fmt.Errorf("parsing views file: %w", err)
 	}
 
-	backend := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	backend := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	rLog := log.WithField("source", "seed-regression-sync")
 
 	// Aggregate active IDs per release across all enabled views before closing.
@@ -1872,7 +1875,7 @@ func seedSymptomJobRunLinkage(dbc *db.DB) error {
 	log.WithField("count", updatedCount).Info("Populated job_symptoms on regression job runs")
 
 	// Sync triage symptoms from the regression job run data.
-	backend := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	backend := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	var activeRegs []*models.TestRegression
 	var allRegs []models.TestRegression
 	if err := dbc.DB.Where("release = ? AND closed IS NULL", "4.22").Find(&allRegs).Error; err != nil {
@@ -173,6 +173,16 @@ func NewServeCommand() *cobra.Command {
 				log.WithError(err).Warn("unable to initialize Jira client, bug filing will be disabled")
 			}
 
+			authorization, err := f.APIFlags.GetAuthorizationConfig()
+			if err != nil {
+				log.WithError(err).Fatal("unable to load authorization config")
+			}
+
+			rateLimits, err := f.APIFlags.GetRateLimits()
+			if err != nil {
+				log.WithError(err).Fatal("invalid rate limits")
+			}
+
 			server := sippyserver.NewServer(
 				f.ModeFlags.GetServerMode(),
 				f.APIFlags.ListenAddr,
@@ -195,6 +205,8 @@ func NewServeCommand() *cobra.Command {
 				f.APIFlags.EnableWriteEndpoints,
 				f.APIFlags.ChatAPIURL,
 				jiraClient,
+				authorization,
+				rateLimits,
 			)
 
 			if f.APIFlags.MetricsAddr != "" {
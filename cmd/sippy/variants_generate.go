@@ -29,6 +29,7 @@ type VariantsGenerateFlags struct {
 	OutputFile              string
 	Mode                    string
 	BigqueryJobsTable       string
+	RulesPath               string
 }
 
 func NewVariantsGenerateFlags() *VariantsGenerateFlags {
@@ -48,6 +49,7 @@ func (f *VariantsGenerateFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.OutputFile, "o", "expected-job-variants.json", "Output json file for job variant data")
 	fs.StringVar(&f.Mode, "mode", "ocp", "Implementation of job variant generator")
 	fs.StringVar(&f.BigqueryJobsTable, "bigquery-jobs-table", "jobs", "Jobs table to load job names from")
+	fs.StringVar(&f.RulesPath, "variant-rules", "", "Optional yaml file of variant classification rules, defaults to the built-in rules")
 }
 
 func (f *VariantsGenerateFlags) Validate() error {
@@ -105,6 +107,11 @@ func NewVariantsGenerateCommand() *cobra.Command {
 					return errors.Wrap(err, "error building synthetic release job overrides")
 				}
 
+				rules, err := variantregistry.LoadClassificationRules(f.RulesPath)
+				if err != nil {
+					return err
+				}
+
 				jvs := variantregistry.NewOCPVariantLoader(
 					bigQueryClient, opCtx,
 					f.BigQueryFlags.BigQueryProject,
@@ -113,7 +120,8 @@ func NewVariantsGenerateCommand() *cobra.Command {
 					gcsClient,
 					config,
 					views.ComponentReadiness,
-					syntheticReleaseJobOverrides)
+					syntheticReleaseJobOverrides,
+					rules)
 				expectedVariants, err := jvs.LoadExpectedJobVariants(ctx)
 				if err != nil {
 					return err
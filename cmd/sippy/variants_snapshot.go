@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ import (
 
 type VariantSnapshotFlags struct {
 	Path                    string
+	RulesPath               string
 	ConfigFlags             *configflags.ConfigFlags
 	ComponentReadinessFlags *flags.ComponentReadinessFlags
 }
@@ -30,6 +32,7 @@ func (f *VariantSnapshotFlags) BindFlags(fs *pflag.FlagSet) {
 	f.ConfigFlags.BindFlags(fs)
 	f.ComponentReadinessFlags.BindFlags(fs)
 	fs.StringVar(&f.Path, "out", f.Path, "Path to write results to")
+	fs.StringVar(&f.RulesPath, "variant-rules", f.RulesPath, "Optional yaml file of variant classification rules, defaults to the built-in rules")
 }
 
 func NewVariantSnapshotCommand() *cobra.Command {
@@ -38,6 +41,7 @@ func NewVariantSnapshotCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "snapshot",
 		Short: "Update the variants snapshot with local data",
+		Long:  "Classify all configured jobs and write their variants to the snapshot file, reporting how the classification differs from the previous snapshot so rule changes can be reviewed.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if f.ConfigFlags.Path == "" {
 				return fmt.Errorf("--config is required")
@@ -58,11 +62,18 @@ func NewVariantSnapshotCommand() *cobra.Command {
 				return err
 			}
 
+			rules, err := variantregistry.LoadClassificationRules(f.RulesPath)
+			if err != nil {
+				return err
+			}
+
 			lgr := log.New()
-			snapshot := variantregistry.NewVariantSnapshot(cfg, views.ComponentReadiness, syntheticReleaseJobOverrides, lgr)
-			if err := snapshot.Save(f.Path); err != nil {
+			snapshot := variantregistry.NewVariantSnapshot(cfg, views.ComponentReadiness, syntheticReleaseJobOverrides, rules, lgr)
+			changes, err := snapshot.Save(f.Path)
+			if err != nil {
 				lgr.WithError(err).Fatal("error updating snapshot")
 			}
+			printVariantChanges(changes)
 
 			lgr.Infof("variants successfully snapshotted")
 			return nil
@@ -73,3 +84,28 @@ func NewVariantSnapshotCommand() *cobra.Command {
 
 	return cmd
 }
+
+// printVariantChanges reports snapshot differences grouped by change, listing the affected jobs.
+func printVariantChanges(changes []variantregistry.VariantChange) {
+	if len(changes) == 0 {
+		fmt.Println("No variant classification changes")
+		return
+	}
+	jobsByChange := map[string][]string{}
+	for _, c := range changes {
+		jobsByChange[c.Description()] = append(jobsByChange[c.Description()], c.Job)
+	}
+	descriptions := make([]string, 0, len(jobsByChange))
+	for d := range jobsByChange {
+		descriptions = append(descriptions, d)
+	}
+	sort.Strings(descriptions)
+
+	fmt.Printf("%d variant classification changes:\n", len(changes))
+	for _, d := range descriptions {
+		fmt.Printf("\n%s (%d jobs)\n", d, len(jobsByChange[d]))
+		for _, job := range jobsByChange[d] {
+			fmt.Printf("  %s\n", job)
+		}
+	}
+}
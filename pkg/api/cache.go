@@ -3,18 +3,44 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/openshift/sippy/pkg/apis/cache"
 	"github.com/openshift/sippy/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sirupsen/logrus"
 )
 
 var defaultCacheDuration = 8 * time.Hour
 
+var cacheRequestMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "sippy_api_cache_requests",
+	Help: "Number of requests for cached API data by result: hit, miss, or coalesced when waiting on a generation already running for the same key. Coalesced requests are usually also misses.",
+}, []string{"result"})
+
+// generation is a run of a generator that callers wanting the same cache key wait on, rather than
+// each repeating the same expensive queries.
+type generation struct {
+	done    chan struct{}
+	content []byte
+	errs    []error
+}
+
+// generationTimeout bounds a generation, which outlives the request that started it.
+var generationTimeout = time.Hour
+
+var (
+	generationsLock sync.Mutex
+	generations     = map[string]*generation{}
+)
+
 // CacheSpec specifies caching parameters for an individual query
 type CacheSpec struct {
 	// function to turn the specified key struct into cacheable bytes
@@ -90,6 +116,7 @@ func GetDataFromCacheOrGenerate[T any](
 		refreshRecent := cacheOptions.RefreshRecent && !hasStableData
 		if !cacheOptions.ForceRefresh && !refreshRecent {
 			if res, err := c.Get(ctx, string(cacheKey), cacheDuration); err == nil {
+				cacheRequestMetric.WithLabelValues("hit").Inc()
 				logrus.WithFields(logrus.Fields{
 					"key":  string(cacheKey),
 					"type": reflect.TypeOf(defaultVal).String(),
@@ -103,38 +130,127 @@ func GetDataFromCacheOrGenerate[T any](
 			} else if strings.Contains(err.Error(), "connection refused") {
 				logrus.WithError(err).Fatalf("redis URL specified but got connection refused, exiting due to cost issues in this configuration")
 			}
+			cacheRequestMetric.WithLabelValues("miss").Inc()
 			logrus.WithFields(logrus.Fields{
 				"key": string(cacheKey),
 			}).Infof("cache miss")
 		}
 
 		// Cache has missed or we're deliberately refreshing the data:
-		result, errs := generateFn(ctx)
-		if len(errs) == 0 && !cacheOptions.SkipCacheWrites {
-			CacheSet(ctx, c, result, cacheKey, cacheDuration)
-		}
-		return result, errs
+		return generateOnce(ctx, string(cacheKey), defaultVal, func(ctx context.Context) (T, []byte, []error) {
+			result, errs := generateFn(ctx)
+			if len(errs) > 0 {
+				return result, nil, errs
+			}
+			content, err := json.Marshal(result)
+			if err != nil {
+				logrus.WithError(err).Errorf("Failed to marshall cache item: %v", result)
+				return result, nil, nil
+			}
+			if !cacheOptions.SkipCacheWrites {
+				cacheSetContent(ctx, c, content, cacheKey, cacheDuration)
+			}
+			return result, content, nil
+		})
 	}
 
 	return generateFn(ctx)
 }
 
+// generateOnce runs generate for key unless a run for the key is already in progress, in which case
+// it waits for that run and decodes its result. generate returns its result both as is, for the
+// caller that started the run, and as JSON to share with waiting callers, so that no two callers
+// share the same value. The run is not tied to any caller: it keeps going, with its own timeout, if
+// the caller that started it goes away, and every caller stops waiting when its own context ends.
+func generateOnce[T any](ctx context.Context, key string, defaultVal T, generate func(ctx context.Context) (T, []byte, []error)) (T, []error) {
+	generationsLock.Lock()
+	g, running := generations[key]
+	var own chan T
+	if running {
+		cacheRequestMetric.WithLabelValues("coalesced").Inc()
+		logrus.WithField("key", key).Infof("waiting for data already being generated")
+	} else {
+		g = &generation{
+			done: make(chan struct{}),
+			// reported to waiters if generate panics
+			errs: []error{errors.New("data generation failed")},
+		}
+		generations[key] = g
+		own = make(chan T, 1)
+		go runGeneration(ctx, key, g, own, generate)
+	}
+	generationsLock.Unlock()
+
+	select {
+	case <-g.done:
+	case <-ctx.Done():
+		return defaultVal, []error{ctx.Err()}
+	}
+	if own != nil {
+		select {
+		case result := <-own:
+			return result, g.errs
+		default: // generate panicked
+			return defaultVal, g.errs
+		}
+	}
+	if len(g.errs) > 0 {
+		return defaultVal, g.errs
+	}
+	var result T
+	if err := json.Unmarshal(g.content, &result); err != nil {
+		return defaultVal, []error{err}
+	}
+	return result, nil
+}
+
+// runGeneration runs generate for g, sending its result to the caller that started it on own.
+func runGeneration[T any](ctx context.Context, key string, g *generation, own chan<- T, generate func(ctx context.Context) (T, []byte, []error)) {
+	genCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), generationTimeout)
+	defer cancel()
+	defer func() {
+		generationsLock.Lock()
+		delete(generations, key)
+		generationsLock.Unlock()
+		close(g.done)
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.WithField("key", key).Errorf("panic generating data: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	result, content, errs := generate(genCtx)
+	g.errs = errs
+	if len(errs) == 0 {
+		g.content = content
+		if content == nil {
+			g.errs = []error{errors.New("generated data could not be shared")}
+		}
+	}
+	own <- result
+}
+
 func CacheSet[T any](ctx context.Context, c cache.Cache, result T, cacheKey []byte, cacheDuration time.Duration) {
 	cr, err := json.Marshal(result)
 	if err == nil {
-		if err := c.Set(ctx, string(cacheKey), cr, cacheDuration); err != nil {
-			if strings.Contains(err.Error(), "connection refused") {
-				logrus.WithError(err).Fatalf("redis URL specified but got connection refused, exiting due to cost issues in this configuration")
-			}
-			logrus.WithError(err).Warningf("couldn't persist new item to cache")
-		} else {
-			logrus.Debugf("cache set for cache key: %s", string(cacheKey))
-		}
+		cacheSetContent(ctx, c, cr, cacheKey, cacheDuration)
 	} else {
 		logrus.WithError(err).Errorf("Failed to marshall cache item: %v", result)
 	}
 }
 
+func cacheSetContent(ctx context.Context, c cache.Cache, content []byte, cacheKey []byte, cacheDuration time.Duration) {
+	if err := c.Set(ctx, string(cacheKey), content, cacheDuration); err != nil {
+		if strings.Contains(err.Error(), "connection refused") {
+			logrus.WithError(err).Fatalf("redis URL specified but got connection refused, exiting due to cost issues in this configuration")
+		}
+		logrus.WithError(err).Warningf("couldn't persist new item to cache")
+	} else {
+		logrus.Debugf("cache set for cache key: %s", string(cacheKey))
+	}
+}
+
 func CalculateRoundedCacheDuration(cacheOptions cache.RequestOptions) time.Duration {
 	// require cacheDuration for persistence logic
 	cacheDuration := defaultCacheDuration
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -533,3 +535,134 @@ func TestGetDataFromCacheOrGenerate_MalformedCacheEntry(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+// TestGenerateOnce_CoalescesConcurrentCallers verifies that callers wanting a key already being generated
+// wait for that generation rather than starting their own, and each get their own copy of the result.
+func TestGenerateOnce_CoalescesConcurrentCallers(t *testing.T) {
+	var generateCalls atomic.Int32
+	release := make(chan struct{})
+	generate := func(context.Context) (testResult, []byte, []error) {
+		generateCalls.Add(1)
+		<-release
+		result := testResult{Value: "generated"}
+		content, err := json.Marshal(result)
+		require.NoError(t, err)
+		return result, content, nil
+	}
+
+	results := make(chan testResult, 5)
+	leaderStarted := make(chan struct{})
+	go func() {
+		result, errs := generateOnce(context.Background(), "coalesce", testResult{}, func(ctx context.Context) (testResult, []byte, []error) {
+			close(leaderStarted)
+			return generate(ctx)
+		})
+		assert.Empty(t, errs)
+		results <- result
+	}()
+	<-leaderStarted
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, errs := generateOnce(context.Background(), "coalesce", testResult{}, generate)
+			assert.Empty(t, errs)
+			results <- result
+		}()
+	}
+	// give the waiters time to find the running generation before it finishes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, testResult{Value: "generated"}, <-results)
+	}
+	assert.Equal(t, int32(1), generateCalls.Load())
+}
+
+// TestGenerateOnce_SharesErrors verifies that waiters get the errors of a failed generation.
+func TestGenerateOnce_SharesErrors(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_, errs := generateOnce(context.Background(), "fail", testResult{}, func(context.Context) (testResult, []byte, []error) {
+			close(started)
+			<-release
+			return testResult{}, nil, []error{fmt.Errorf("generate failed")}
+		})
+		assert.Len(t, errs, 1)
+	}()
+	<-started
+
+	done := make(chan []error)
+	go func() {
+		_, errs := generateOnce(context.Background(), "fail", testResult{Value: "default"}, func(context.Context) (testResult, []byte, []error) {
+			return testResult{Value: "unexpected"}, nil, nil
+		})
+		done <- errs
+	}()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	assert.EqualError(t, (<-done)[0], "generate failed")
+
+	// a waiter whose context ends stops waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	release = make(chan struct{})
+	started = make(chan struct{})
+	go func() {
+		_, _ = generateOnce(context.Background(), "slow", testResult{}, func(context.Context) (testResult, []byte, []error) {
+			close(started)
+			<-release
+			return testResult{}, nil, nil
+		})
+	}()
+	<-started
+	cancel()
+	_, errs := generateOnce(ctx, "slow", testResult{}, func(context.Context) (testResult, []byte, []error) {
+		return testResult{}, nil, nil
+	})
+	close(release)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
+
+// TestGenerateOnce_OutlivesStartingCaller verifies that a generation keeps running when the caller
+// that started it goes away, so that other callers waiting on it still get its result.
+func TestGenerateOnce_OutlivesStartingCaller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	started := make(chan struct{})
+	generate := func(genCtx context.Context) (testResult, []byte, []error) {
+		close(started)
+		<-release
+		if err := genCtx.Err(); err != nil {
+			return testResult{}, nil, []error{err}
+		}
+		result := testResult{Value: "generated"}
+		content, err := json.Marshal(result)
+		require.NoError(t, err)
+		return result, content, nil
+	}
+
+	first := make(chan []error)
+	go func() {
+		_, errs := generateOnce(ctx, "outlive", testResult{}, generate)
+		first <- errs
+	}()
+	<-started
+
+	second := make(chan testResult)
+	go func() {
+		result, errs := generateOnce(context.Background(), "outlive", testResult{}, generate)
+		assert.Empty(t, errs)
+		second <- result
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, (<-first)[0], context.Canceled)
+	close(release)
+	assert.Equal(t, testResult{Value: "generated"}, <-second)
+}
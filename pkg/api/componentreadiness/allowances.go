@@ -0,0 +1,164 @@
+package componentreadiness
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	sippyapi "github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/regressionallowances"
+)
+
+const (
+	regressionAllowanceLink          = "%s/api/component_readiness/allowances/%d"
+	regressionAllowanceAuditLogsLink = "%s/api/component_readiness/allowances/%d/audit"
+)
+
+// ListRegressionAllowances lists the intentional regression allowances, optionally filtered by the
+// release and test_id query parameters.
+func ListRegressionAllowances(dbc *gorm.DB, req *http.Request) ([]models.RegressionAllowance, error) {
+	q := dbc.Order("release, test_id, id")
+	if release := req.URL.Query().Get("release"); release != "" {
+		q = q.Where("release = ?", release)
+	}
+	if testID := req.URL.Query().Get("test_id"); testID != "" {
+		q = q.Where("test_id = ?", testID)
+	}
+	allowances := []models.RegressionAllowance{}
+	if err := q.Find(&allowances).Error; err != nil {
+		return nil, err
+	}
+	for i := range allowances {
+		injectRegressionAllowanceHATEOASLinks(&allowances[i], sippyapi.GetBaseURL(req))
+	}
+	return allowances, nil
+}
+
+func GetRegressionAllowance(dbc *gorm.DB, id int, req *http.Request) (*models.RegressionAllowance, error) {
+	allowance := &models.RegressionAllowance{}
+	res := dbc.First(allowance, id)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.WithError(res.Error).Errorf("error looking up regression allowance record: %d", id)
+		return nil, res.Error
+	}
+	injectRegressionAllowanceHATEOASLinks(allowance, sippyapi.GetBaseURL(req))
+	return allowance, nil
+}
+
+// validateRegressionAllowance ensures the allowance coming into the API appears valid, and returns it
+// normalized to how it is stored. Small changes in logic for create vs update are controlled by the update param.
+func validateRegressionAllowance(allowance models.RegressionAllowance, update bool) (models.RegressionAllowance, error) {
+	if !update && allowance.ID > 0 {
+		return allowance, fmt.Errorf("cannot specify an id for a new regression allowance, one will be autogenerated")
+	}
+	if update && allowance.ID == 0 {
+		return allowance, fmt.Errorf("must specify an id for a regression allowance update")
+	}
+	if allowance.Release == "" {
+		return allowance, fmt.Errorf("release is required for a regression allowance")
+	}
+	ir := regressionallowances.FromModel(allowance)
+	if err := regressionallowances.Validate(ir); err != nil {
+		return allowance, err
+	}
+
+	normalized := regressionallowances.ToModel(allowance.Release, ir)
+	normalized.ID = allowance.ID
+	return normalized, nil
+}
+
+func CreateRegressionAllowance(dbc *gorm.DB, allowance models.RegressionAllowance, req *http.Request) (models.RegressionAllowance, error) {
+	allowance, err := validateRegressionAllowance(allowance, false)
+	if err != nil {
+		log.WithError(err).Error("error validating regression allowance")
+		return allowance, err
+	}
+
+	if err := dbc.Create(&allowance).Error; err != nil {
+		log.WithError(err).Error("error creating regression allowance")
+		return allowance, err
+	}
+	log.WithField("allowanceID", allowance.ID).Info("regression allowance created")
+	injectRegressionAllowanceHATEOASLinks(&allowance, sippyapi.GetBaseURL(req))
+	return allowance, nil
+}
+
+func UpdateRegressionAllowance(dbc *gorm.DB, allowance models.RegressionAllowance, req *http.Request) (models.RegressionAllowance, error) {
+	allowance, err := validateRegressionAllowance(allowance, true)
+	if err != nil {
+		log.WithError(err).Error("error validating regression allowance")
+		return allowance, err
+	}
+
+	existing := models.RegressionAllowance{}
+	if err := dbc.First(&existing, allowance.ID).Error; err != nil {
+		log.WithError(err).Errorf("error looking up existing regression allowance: %v", allowance.ID)
+		return allowance, err
+	}
+	allowance.CreatedAt = existing.CreatedAt
+
+	if err := dbc.Save(&allowance).Error; err != nil {
+		log.WithError(err).Error("error updating regression allowance")
+		return allowance, err
+	}
+	injectRegressionAllowanceHATEOASLinks(&allowance, sippyapi.GetBaseURL(req))
+	return allowance, nil
+}
+
+// DeleteRegressionAllowance deletes the allowance with id, returning gorm.ErrRecordNotFound if there is none.
+func DeleteRegressionAllowance(dbc *gorm.DB, id int) error {
+	existing := &models.RegressionAllowance{}
+	if err := dbc.First(existing, id).Error; err != nil {
+		return fmt.Errorf("error looking up regression allowance %d: %w", id, err)
+	}
+	if err := dbc.Delete(existing).Error; err != nil {
+		return fmt.Errorf("error deleting regression allowance: %w", err)
+	}
+	return nil
+}
+
+// RegressionAllowanceAuditLog is an audit log entry with the full allowance before and after the change.
+type RegressionAllowanceAuditLog struct {
+	Operation string          `json:"operation"`
+	User      string          `json:"user"`
+	CreatedAt time.Time       `json:"created_at"`
+	OldData   json.RawMessage `json:"old_data,omitempty"`
+	NewData   json.RawMessage `json:"new_data,omitempty"`
+}
+
+func GetRegressionAllowanceAuditLogs(dbc *gorm.DB, id int) ([]RegressionAllowanceAuditLog, error) {
+	var auditLogs []models.AuditLog
+	res := dbc.Where("table_name = 'regression_allowances' and row_id = ?", id).Order("created_at DESC").Find(&auditLogs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	response := make([]RegressionAllowanceAuditLog, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		response = append(response, RegressionAllowanceAuditLog{
+			Operation: auditLog.Operation,
+			User:      auditLog.User,
+			CreatedAt: auditLog.CreatedAt,
+			OldData:   auditLog.OldData,
+			NewData:   auditLog.NewData,
+		})
+	}
+	return response, nil
+}
+
+// injectRegressionAllowanceHATEOASLinks adds restful links clients can follow for this allowance.
+func injectRegressionAllowanceHATEOASLinks(allowance *models.RegressionAllowance, baseURL string) {
+	allowance.Links = map[string]string{
+		"self":       fmt.Sprintf(regressionAllowanceLink, baseURL, allowance.ID),
+		"audit_logs": fmt.Sprintf(regressionAllowanceAuditLogsLink, baseURL, allowance.ID),
+	}
+}
@@ -0,0 +1,56 @@
+package componentreadiness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func Test_validateRegressionAllowance(t *testing.T) {
+	valid := models.RegressionAllowance{
+		Release:       "4.20",
+		JiraComponent: "Networking",
+		TestID:        "openshift-tests:abc123",
+		TestName:      "[sig-network] pods should talk",
+		Variants: []string{"Upgrade:none", "Architecture:amd64", "FeatureSet:default", "Installer:ipi", "Network:ovn",
+			"Platform:aws", "Suite:unknown", "Topology:ha"},
+		PreviousRelease:           "4.19",
+		PreviousSuccesses:         100,
+		RegressedSuccesses:        90,
+		RegressedFailures:         10,
+		JiraBug:                   "https://issues.redhat.com/browse/OCPBUGS-1",
+		ReasonToAllowInsteadOfFix: "fix needs an upstream rebase",
+	}
+
+	normalized, err := validateRegressionAllowance(valid, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Architecture:amd64", normalized.Variants[0], "variants should be stored sorted")
+
+	withID := valid
+	withID.ID = 3
+	_, err = validateRegressionAllowance(withID, false)
+	assert.ErrorContains(t, err, "cannot specify an id")
+	normalized, err = validateRegressionAllowance(withID, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), normalized.ID)
+	_, err = validateRegressionAllowance(valid, true)
+	assert.ErrorContains(t, err, "must specify an id")
+
+	noRelease := valid
+	noRelease.Release = ""
+	_, err = validateRegressionAllowance(noRelease, false)
+	assert.ErrorContains(t, err, "release is required")
+
+	missingVariant := valid
+	missingVariant.Variants = valid.Variants[1:]
+	_, err = validateRegressionAllowance(missingVariant, false)
+	assert.ErrorContains(t, err, "Upgrade must be specified")
+
+	improved := valid
+	improved.PreviousFailures = 100
+	_, err = validateRegressionAllowance(improved, false)
+	assert.ErrorContains(t, err, "regressedPassPercentage must be less")
+}
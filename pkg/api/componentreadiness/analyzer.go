@@ -0,0 +1,33 @@
+package componentreadiness
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+)
+
+// Analyzer decides whether a test's sample results have regressed (or improved) relative to its basis,
+// filling in the ReportStatus, Comparison, Explanations and any analyzer specific stats on testStats.
+type Analyzer interface {
+	Analyze(testStats *testdetails.TestComparison, logger *log.Entry)
+}
+
+// analyzer returns the Analyzer selected by the request's advanced options.
+func (c *ComponentReportGenerator) analyzer() Analyzer {
+	switch c.ReqOptions.AdvancedOption.Analyzer {
+	case reqopts.AnalyzerBayesian:
+		return &bayesianAnalyzer{opts: c.ReqOptions.AdvancedOption}
+	default:
+		return &fisherExactAnalyzer{generator: c}
+	}
+}
+
+// fisherExactAnalyzer is the default analyzer, comparing basis and sample with Fisher's Exact test.
+type fisherExactAnalyzer struct {
+	generator *ComponentReportGenerator
+}
+
+func (f *fisherExactAnalyzer) Analyze(testStats *testdetails.TestComparison, logger *log.Entry) {
+	f.generator.buildFisherExactTestStats(testStats, logger)
+}
@@ -0,0 +1,252 @@
+package componentreadiness
+
+import (
+	"fmt"
+	"math"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+)
+
+const (
+	// intervals used for Simpson's rule when integrating over a posterior (must be even)
+	bayesianIntegrationIntervals = 512
+	// posteriors are integrated over mean +/- this many standard deviations, clipped to [0,1]
+	bayesianIntegrationWidth = 12.0
+	// bisection steps used to locate credible interval bounds in [-1,1]
+	bayesianQuantileIterations = 40
+)
+
+// bayesianAnalyzer models the basis and sample pass rates with Beta-Binomial posteriors under a
+// uniform Beta(1,1) prior, and reports a regression when the posterior probability that the sample
+// pass rate fell by more than the pity factor reaches the required confidence. Unlike Fisher's Exact
+// test this yields a direct probability of regression, which moves smoothly as runs trickle in on
+// low volume jobs rather than flip-flopping around a p-value threshold.
+type bayesianAnalyzer struct {
+	opts reqopts.Advanced
+}
+
+func (b *bayesianAnalyzer) Analyze(testStats *testdetails.TestComparison, logger *log.Entry) {
+	testStats.Comparison = crtest.Bayesian
+	opts := b.opts
+
+	if testStats.SampleStats.Total() == 0 {
+		testStats.ReportStatus = crtest.MissingSample
+		if opts.IgnoreMissing {
+			testStats.ReportStatus = crtest.NotSignificant
+		}
+		testStats.Explanations = append(testStats.Explanations, explanationNoRegression)
+		return
+	}
+	if testStats.BaseStats == nil || testStats.BaseStats.Total() == 0 {
+		testStats.ReportStatus = crtest.MissingBasis
+		return
+	}
+
+	samplePass := testStats.SampleStats.Passes(opts.FlakeAsFailure)
+	sampleFail := testStats.SampleStats.Total() - samplePass
+	basePass := testStats.BaseStats.Passes(opts.FlakeAsFailure)
+	baseFail := testStats.BaseStats.Total() - basePass
+
+	// are we below the MinimumFailure threshold?
+	if opts.MinimumFailure != 0 && sampleFail < opts.MinimumFailure {
+		testStats.ReportStatus = crtest.NotSignificant
+		return
+	}
+
+	basisPassPercentage := float64(basePass) / float64(testStats.BaseStats.Total())
+	samplePassPercentage := float64(samplePass) / float64(testStats.SampleStats.Total())
+	pity := (float64(opts.PityFactor) + testStats.PityAdjustment) / 100
+	confidence := float64(testStats.RequiredConfidence) / 100
+
+	base := betaPosterior(basePass, baseFail)
+	sample := betaPosterior(samplePass, sampleFail)
+	stats := &testdetails.BayesianStats{
+		RegressionProbability:  probDifferenceExceeds(base, sample, pity),
+		ImprovementProbability: probDifferenceExceeds(sample, base, 0),
+		PassRateDrop:           base.mean() - sample.mean(),
+	}
+	testStats.Bayesian = stats
+	logger.Debugf("computed Bayesian info: regression probability: %v, improvement probability: %v",
+		stats.RegressionProbability, stats.ImprovementProbability)
+
+	status := crtest.NotSignificant
+	if samplePassPercentage >= basisPassPercentage {
+		if stats.ImprovementProbability >= confidence {
+			status = crtest.SignificantImprovement
+		}
+	} else if basisPassPercentage-samplePassPercentage > pity && stats.RegressionProbability >= confidence {
+		status = getRegressionStatus(basisPassPercentage, samplePassPercentage)
+	}
+	logger.Debugf("computed status: %d", int(status))
+	testStats.ReportStatus = status
+
+	if status > crtest.SignificantRegression {
+		return
+	}
+	low, high := credibleInterval(base, sample, confidence)
+	stats.CredibleInterval = &testdetails.CredibleInterval{Low: low, High: high, Level: confidence}
+	logger.Debugf("regression detected against: %s", testStats.BaseStats.Release)
+	testStats.Explanations = append(testStats.Explanations,
+		fmt.Sprintf("%s regression detected.", crtest.StringForStatus(status)),
+		fmt.Sprintf("Bayesian probability of a regression: %.2f%%.", stats.RegressionProbability*100),
+		fmt.Sprintf("Test pass rate dropped from %.2f%% to %.2f%%.",
+			testStats.BaseStats.SuccessRate*float64(100),
+			testStats.SampleStats.SuccessRate*float64(100)),
+		fmt.Sprintf("%.0f%% credible interval for the pass rate drop: %.2f%% to %.2f%%.",
+			confidence*100, low*100, high*100))
+}
+
+// betaDist is a Beta(alpha, beta) distribution over a pass rate.
+type betaDist struct {
+	alpha, beta float64
+}
+
+// betaPosterior returns the posterior for a pass rate after observing the given passes and failures,
+// starting from a uniform Beta(1,1) prior.
+func betaPosterior(passes, failures int) betaDist {
+	return betaDist{alpha: float64(passes) + 1, beta: float64(failures) + 1}
+}
+
+func (d betaDist) mean() float64 {
+	return d.alpha / (d.alpha + d.beta)
+}
+
+func (d betaDist) stddev() float64 {
+	sum := d.alpha + d.beta
+	return math.Sqrt(d.alpha * d.beta / (sum * sum * (sum + 1)))
+}
+
+func (d betaDist) pdf(x float64) float64 {
+	if x < 0 || x > 1 {
+		return 0
+	}
+	logp := -logBeta(d.alpha, d.beta)
+	if d.alpha != 1 {
+		if x == 0 {
+			return 0
+		}
+		logp += (d.alpha - 1) * math.Log(x)
+	}
+	if d.beta != 1 {
+		if x == 1 {
+			return 0
+		}
+		logp += (d.beta - 1) * math.Log1p(-x)
+	}
+	return math.Exp(logp)
+}
+
+func (d betaDist) cdf(x float64) float64 {
+	return regularizedIncompleteBeta(x, d.alpha, d.beta)
+}
+
+// support returns the range holding all but a negligible amount of the distribution's mass.
+func (d betaDist) support() (float64, float64) {
+	width := bayesianIntegrationWidth * d.stddev()
+	return math.Max(0, d.mean()-width), math.Min(1, d.mean()+width)
+}
+
+// probDifferenceExceeds returns P(X - Y > delta) for independent X and Y, integrating
+// P(Y < x - delta) over the density of X.
+func probDifferenceExceeds(x, y betaDist, delta float64) float64 {
+	lo, hi := x.support()
+	lo = math.Max(lo, delta) // P(Y < x - delta) is zero below delta
+	if lo >= hi {
+		return 0
+	}
+	h := (hi - lo) / bayesianIntegrationIntervals
+	sum := 0.0
+	for i := 0; i <= bayesianIntegrationIntervals; i++ {
+		u := lo + float64(i)*h
+		weight := 2.0
+		if i == 0 || i == bayesianIntegrationIntervals {
+			weight = 1
+		} else if i%2 == 1 {
+			weight = 4
+		}
+		sum += weight * x.pdf(u) * y.cdf(u-delta)
+	}
+	return math.Max(0, math.Min(1, sum*h/3))
+}
+
+// credibleInterval returns the equal-tailed interval holding the given mass of the posterior
+// for the pass rate drop (base - sample), located by bisection on its distribution function.
+func credibleInterval(base, sample betaDist, level float64) (float64, float64) {
+	quantile := func(q float64) float64 {
+		lo, hi := -1.0, 1.0
+		for i := 0; i < bayesianQuantileIterations; i++ {
+			mid := (lo + hi) / 2
+			if 1-probDifferenceExceeds(base, sample, mid) < q {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return (lo + hi) / 2
+	}
+	tail := (1 - level) / 2
+	return quantile(tail), quantile(1 - tail)
+}
+
+func logBeta(a, b float64) float64 {
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	return lga + lgb - lgab
+}
+
+// regularizedIncompleteBeta returns I_x(a, b), the distribution function of Beta(a, b) at x.
+// It uses the continued fraction from Numerical Recipes, which converges quickly on whichever
+// side of the mean x falls after applying the symmetry I_x(a, b) = 1 - I_(1-x)(b, a).
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	front := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - logBeta(a, b))
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 10000
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+	clampTiny := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c := 1.0
+	d := 1 / clampTiny(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m <= maxIterations; m++ {
+		// even step
+		numerator := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clampTiny(1+numerator*d)
+		c = clampTiny(1 + numerator/c)
+		h *= d * c
+		// odd step
+		numerator = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clampTiny(1+numerator*d)
+		c = clampTiny(1 + numerator/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,177 @@
+package componentreadiness
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+)
+
+func TestRegularizedIncompleteBeta(t *testing.T) {
+	for _, x := range []float64{0.01, 0.2, 0.5, 0.77, 0.99} {
+		assert.InDelta(t, x, regularizedIncompleteBeta(x, 1, 1), 1e-9, "uniform at %v", x)
+		assert.InDelta(t, x*x, regularizedIncompleteBeta(x, 2, 1), 1e-9, "Beta(2,1) at %v", x)
+		assert.InDelta(t, 1-math.Pow(1-x, 5), regularizedIncompleteBeta(x, 1, 5), 1e-9, "Beta(1,5) at %v", x)
+	}
+	// symmetric distributions split their mass at one half, even when heavily concentrated
+	assert.InDelta(t, 0.5, regularizedIncompleteBeta(0.5, 7, 7), 1e-9)
+	assert.InDelta(t, 0.5, regularizedIncompleteBeta(0.5, 50000, 50000), 1e-6)
+	assert.Equal(t, 0.0, regularizedIncompleteBeta(0, 3, 4))
+	assert.Equal(t, 1.0, regularizedIncompleteBeta(1, 3, 4))
+}
+
+func TestProbDifferenceExceeds(t *testing.T) {
+	uniform := betaDist{alpha: 1, beta: 1}
+	assert.InDelta(t, 0.5, probDifferenceExceeds(uniform, uniform, 0), 1e-6)
+	// the region x - y > 0.5 of the unit square is a triangle of area 1/8
+	assert.InDelta(t, 0.125, probDifferenceExceeds(uniform, uniform, 0.5), 1e-6)
+	assert.InDelta(t, 0.875, probDifferenceExceeds(uniform, uniform, -0.5), 1e-6)
+
+	base := betaPosterior(990, 10)
+	sample := betaPosterior(900, 100)
+	assert.Greater(t, probDifferenceExceeds(base, sample, 0.05), 0.999)
+	assert.Less(t, probDifferenceExceeds(sample, base, 0), 0.001)
+
+	low, high := credibleInterval(base, sample, 0.95)
+	assert.Less(t, low, base.mean()-sample.mean())
+	assert.Greater(t, high, base.mean()-sample.mean())
+	assert.Greater(t, low, 0.05)
+	assert.Less(t, high, 0.12)
+}
+
+func Test_bayesianAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		sampleTotal   int
+		sampleSuccess int
+		baseTotal     int
+		baseSuccess   int
+		minFail       int
+		ignoreMissing bool
+
+		expectedStatus   crtest.Status
+		expectBayesian   bool
+		expectedInterval bool
+	}{
+		{
+			name:           "low volume failures are not enough evidence",
+			sampleTotal:    5,
+			sampleSuccess:  3,
+			baseTotal:      20,
+			baseSuccess:    19,
+			expectedStatus: crtest.NotSignificant,
+			expectBayesian: true,
+		},
+		{
+			name:             "significant regression",
+			sampleTotal:      200,
+			sampleSuccess:    180,
+			baseTotal:        1000,
+			baseSuccess:      995,
+			expectedStatus:   crtest.SignificantRegression,
+			expectBayesian:   true,
+			expectedInterval: true,
+		},
+		{
+			name:             "extreme regression",
+			sampleTotal:      50,
+			sampleSuccess:    20,
+			baseTotal:        1000,
+			baseSuccess:      995,
+			expectedStatus:   crtest.ExtremeRegression,
+			expectBayesian:   true,
+			expectedInterval: true,
+		},
+		{
+			name:           "drop within pity factor",
+			sampleTotal:    1000,
+			sampleSuccess:  960,
+			baseTotal:      1000,
+			baseSuccess:    990,
+			expectedStatus: crtest.NotSignificant,
+			expectBayesian: true,
+		},
+		{
+			name:           "improvement",
+			sampleTotal:    1000,
+			sampleSuccess:  999,
+			baseTotal:      1000,
+			baseSuccess:    900,
+			expectedStatus: crtest.SignificantImprovement,
+			expectBayesian: true,
+		},
+		{
+			name:           "below minimum failures",
+			sampleTotal:    50,
+			sampleSuccess:  48,
+			baseTotal:      1000,
+			baseSuccess:    1000,
+			minFail:        3,
+			expectedStatus: crtest.NotSignificant,
+		},
+		{
+			name:           "missing sample",
+			baseTotal:      100,
+			baseSuccess:    100,
+			expectedStatus: crtest.MissingSample,
+		},
+		{
+			name:           "ignored missing sample",
+			baseTotal:      100,
+			baseSuccess:    100,
+			ignoreMissing:  true,
+			expectedStatus: crtest.NotSignificant,
+		},
+		{
+			name:           "missing basis",
+			sampleTotal:    100,
+			sampleSuccess:  50,
+			expectedStatus: crtest.MissingBasis,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ComponentReportGenerator{}
+			c.ReqOptions.AdvancedOption = reqopts.Advanced{
+				Analyzer:       reqopts.AnalyzerBayesian,
+				Confidence:     95,
+				PityFactor:     5,
+				MinimumFailure: tt.minFail,
+				IgnoreMissing:  tt.ignoreMissing,
+			}
+			testAnalysis := &testdetails.TestComparison{
+				SampleStats: testdetails.ReleaseStats{
+					Stats: crtest.NewTestStats(tt.sampleSuccess, tt.sampleTotal-tt.sampleSuccess, 0, false),
+				},
+				BaseStats: &testdetails.ReleaseStats{
+					Stats: crtest.NewTestStats(tt.baseSuccess, tt.baseTotal-tt.baseSuccess, 0, false),
+				},
+			}
+
+			c.assessComponentStatus(testAnalysis, logrus.NewEntry(logrus.New()))
+			assert.Equal(t, tt.expectedStatus, testAnalysis.ReportStatus)
+			assert.Nil(t, testAnalysis.FisherExact)
+			if !tt.expectBayesian {
+				assert.Nil(t, testAnalysis.Bayesian)
+				return
+			}
+			assert.Equal(t, crtest.Bayesian, testAnalysis.Comparison)
+			require.NotNil(t, testAnalysis.Bayesian)
+			if tt.expectedInterval {
+				require.NotNil(t, testAnalysis.Bayesian.CredibleInterval)
+				assert.GreaterOrEqual(t, testAnalysis.Bayesian.RegressionProbability, 0.95)
+				assert.Less(t, testAnalysis.Bayesian.CredibleInterval.Low, testAnalysis.Bayesian.PassRateDrop)
+				assert.Greater(t, testAnalysis.Bayesian.CredibleInterval.High, testAnalysis.Bayesian.PassRateDrop)
+				assert.Len(t, testAnalysis.Explanations, 4)
+			} else {
+				assert.Nil(t, testAnalysis.Bayesian.CredibleInterval)
+			}
+		})
+	}
+}
@@ -290,7 +290,7 @@ func (c *ComponentReportGenerator) initializeMiddleware() {
 	} else {
 		log.Warnf("no db connection provided, skipping regressiontracker middleware")
 	}
-	c.middlewares = append(c.middlewares, regressionallowances2.NewRegressionAllowancesMiddleware(c.ReqOptions, c.releaseConfigs))
+	c.middlewares = append(c.middlewares, regressionallowances2.NewRegressionAllowancesMiddleware(c.ReqOptions, c.releaseConfigs, c.dbc))
 
 	// Initialize LinkInjector middleware
 	linkInjector := linkinjector.NewLinkInjectorMiddleware(c.ReqOptions, c.baseURL)
@@ -702,10 +702,9 @@ func getRegressionStatus(basisPassPercentage, samplePassPercentage float64) crte
 	return crtest.SignificantRegression
 }
 
-// TODO: this will eventually become the analyze step on a Middleware, or possibly a separate
-// set of objects relating to analysis, as there's not a lot of overlap between the analyzers
-// (fishers, pass rate, bayes (future)) and the middlewares (fallback, intentional regressions,
-// cross variant compare, rarely run jobs, etc.)
+// assessComponentStatus applies the pass rate checks if configured, and otherwise hands the test to the
+// Analyzer selected in the advanced options (Fisher's Exact by default, or Bayesian).
+// TODO: pass rate mode could become an Analyzer too, though it currently composes with the others for new tests.
 func (c *ComponentReportGenerator) assessComponentStatus(testStats *testdetails.TestComparison, logger *log.Entry) {
 	// Catch unset required confidence, typically unit tests
 	opts := c.ReqOptions.AdvancedOption
@@ -728,8 +727,8 @@ func (c *ComponentReportGenerator) assessComponentStatus(testStats *testdetails.
 		return
 	}
 
-	// Otherwise we fall back to default behavior of Fishers Exact test:
-	c.buildFisherExactTestStats(testStats, logger)
+	// Otherwise we compare sample and basis with the requested analyzer, Fishers Exact test by default:
+	c.analyzer().Analyze(testStats, logger)
 }
 
 func (c *ComponentReportGenerator) buildFisherExactTestStats(testStats *testdetails.TestComparison, logger *log.Entry) {
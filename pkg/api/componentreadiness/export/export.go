@@ -0,0 +1,309 @@
+// Package export flattens component reports and regressions into a row per regressed test, and
+// writes them as CSV, Markdown or spreadsheets for status decks.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatXLSX     = "xlsx"
+)
+
+// Formats lists the supported export formats.
+var Formats = []string{FormatCSV, FormatMarkdown, FormatXLSX}
+
+// Row is a regressed test.
+type Row struct {
+	Component  string
+	Capability string
+	TestName   string
+	TestID     string
+	// Variants are sorted key:value pairs.
+	Variants []string
+	Status   string
+	// BaseRelease and SampleRelease are empty, and the pass rates nil, when unknown.
+	BaseRelease    string
+	BasePassRate   *float64
+	SampleRelease  string
+	SamplePassRate *float64
+	Opened         *time.Time
+	// Triages are the URLs, typically Jira bugs, the regression was triaged to.
+	Triages        []string
+	TestDetailsURL string
+}
+
+// FromComponentReport returns a row for each regressed test in the report, by component.
+func FromComponentReport(report componentreport.ComponentReport) []Row {
+	rows := []Row{}
+	for _, reportRow := range report.Rows {
+		for _, col := range reportRow.Columns {
+			for _, test := range col.RegressedTests {
+				rows = append(rows, fromTestSummary(test))
+			}
+		}
+	}
+	sortRows(rows)
+	return rows
+}
+
+func fromTestSummary(test componentreport.ReportTestSummary) Row {
+	row := Row{
+		Component:      test.Component,
+		Capability:     test.Capability,
+		TestName:       test.TestName,
+		TestID:         test.TestID,
+		Variants:       sortedVariants(test.Variants),
+		Status:         crtest.StringForStatus(test.ReportStatus),
+		SampleRelease:  test.SampleStats.Release,
+		SamplePassRate: passRate(&test.SampleStats),
+		TestDetailsURL: test.Links["test_details"],
+	}
+	if test.BaseStats != nil {
+		row.BaseRelease = test.BaseStats.Release
+		row.BasePassRate = passRate(test.BaseStats)
+	}
+	if test.Regression != nil {
+		row.Opened = &test.Regression.Opened
+		row.Triages = triageURLs(test.Regression.Triages)
+	}
+	return row
+}
+
+// FromRegressions returns a row for each regression. Tracked regressions do not record pass
+// rates, and are linked to the test details of each view they are active in; the link for
+// viewName is preferred.
+func FromRegressions(regressions []models.TestRegression, viewName string) []Row {
+	rows := make([]Row, 0, len(regressions))
+	for _, r := range regressions {
+		status := "Open"
+		if r.Closed.Valid {
+			status = "Closed"
+		}
+		opened := r.Opened
+		rows = append(rows, Row{
+			Component:      r.Component,
+			Capability:     r.Capability,
+			TestName:       r.TestName,
+			TestID:         r.TestID,
+			Variants:       sortedStrings(r.Variants),
+			Status:         status,
+			BaseRelease:    r.BaseRelease,
+			SampleRelease:  r.Release,
+			Opened:         &opened,
+			Triages:        triageURLs(r.Triages),
+			TestDetailsURL: regressionTestDetailsURL(r.Links, viewName),
+		})
+	}
+	sortRows(rows)
+	return rows
+}
+
+func regressionTestDetailsURL(links map[string]string, viewName string) string {
+	if link, ok := links["test_details:"+viewName]; ok && viewName != "" {
+		return link
+	}
+	var keys []string
+	for key := range links {
+		if strings.HasPrefix(key, "test_details:") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return links[keys[0]]
+}
+
+func passRate(stats *testdetails.ReleaseStats) *float64 {
+	if stats.Total() == 0 {
+		return nil
+	}
+	rate := stats.SuccessRate * 100
+	return &rate
+}
+
+func triageURLs(triages []models.Triage) []string {
+	urls := make([]string, 0, len(triages))
+	for _, t := range triages {
+		urls = append(urls, t.URL)
+	}
+	return sortedStrings(urls)
+}
+
+func sortedVariants(variants map[string]string) []string {
+	pairs := make([]string, 0, len(variants))
+	for k, v := range variants {
+		pairs = append(pairs, crtest.VariantKeyValueToString(k, v))
+	}
+	return sortedStrings(pairs)
+}
+
+func sortedStrings(s []string) []string {
+	sorted := append([]string{}, s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func sortRows(rows []Row) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Component != rows[j].Component {
+			return rows[i].Component < rows[j].Component
+		}
+		if rows[i].Capability != rows[j].Capability {
+			return rows[i].Capability < rows[j].Capability
+		}
+		if rows[i].TestName != rows[j].TestName {
+			return rows[i].TestName < rows[j].TestName
+		}
+		return strings.Join(rows[i].Variants, ",") < strings.Join(rows[j].Variants, ",")
+	})
+}
+
+// column is a field of the export. Values are strings, or float64 for numbers, with nil for
+// missing values.
+type column struct {
+	header string
+	value  func(Row) any
+}
+
+var columns = []column{
+	{"Component", func(r Row) any { return r.Component }},
+	{"Capability", func(r Row) any { return r.Capability }},
+	{"Test", func(r Row) any { return r.TestName }},
+	{"Test ID", func(r Row) any { return r.TestID }},
+	{"Variants", func(r Row) any { return strings.Join(r.Variants, " ") }},
+	{"Status", func(r Row) any { return r.Status }},
+	{"Base Release", func(r Row) any { return r.BaseRelease }},
+	{"Base Pass Rate", func(r Row) any { return optional(r.BasePassRate) }},
+	{"Sample Release", func(r Row) any { return r.SampleRelease }},
+	{"Sample Pass Rate", func(r Row) any { return optional(r.SamplePassRate) }},
+	{"Opened", func(r Row) any {
+		if r.Opened == nil {
+			return nil
+		}
+		return r.Opened.UTC().Format(time.RFC3339)
+	}},
+	{"Triages", func(r Row) any { return strings.Join(r.Triages, " ") }},
+	{"Test Details", func(r Row) any { return r.TestDetailsURL }},
+}
+
+func optional(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return ""
+}
+
+// Write writes the rows in the format.
+func Write(w io.Writer, format string, rows []Row) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatMarkdown:
+		return writeMarkdown(w, rows)
+	case FormatXLSX:
+		return writeXLSX(w, rows)
+	}
+	return fmt.Errorf("unsupported export format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func writeCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.header
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		for i, c := range columns {
+			record[i] = formatValue(c.value(r))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+// MarkdownCell escapes text for a markdown table cell.
+func MarkdownCell(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func writeMarkdown(w io.Writer, rows []Row) error {
+	var b strings.Builder
+	for _, c := range columns {
+		b.WriteString("| " + c.header + " ")
+	}
+	b.WriteString("|\n")
+	for range columns {
+		b.WriteString("|---")
+	}
+	b.WriteString("|\n")
+	for _, r := range rows {
+		for _, c := range columns {
+			cell := MarkdownCell(formatValue(c.value(r)))
+			switch c.header {
+			case "Test Details":
+				if cell != "" {
+					cell = "[details](" + cell + ")"
+				}
+			case "Triages":
+				links := make([]string, 0, len(r.Triages))
+				for _, url := range r.Triages {
+					links = append(links, "<"+MarkdownCell(url)+">")
+				}
+				cell = strings.Join(links, " ")
+			}
+			b.WriteString("| " + cell + " ")
+		}
+		b.WriteString("|\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func regressedTest(component, testName string, variants map[string]string, status crtest.Status) componentreport.ReportTestSummary {
+	return componentreport.ReportTestSummary{
+		Identification: crtest.Identification{
+			RowIdentification:    crtest.RowIdentification{Component: component, Capability: "Other", TestName: testName, TestID: "id-" + testName},
+			ColumnIdentification: crtest.ColumnIdentification{Variants: variants},
+		},
+		TestComparison: testdetails.TestComparison{
+			ReportStatus: status,
+			SampleStats: testdetails.ReleaseStats{
+				Release: "4.20",
+				Stats:   crtest.NewTestStats(8, 2, 0, false),
+			},
+			BaseStats: &testdetails.ReleaseStats{
+				Release: "4.19",
+				Stats:   crtest.NewTestStats(100, 0, 0, false),
+			},
+			Links: map[string]string{"test_details": "https://sippy/test_details?testId=id-" + testName},
+		},
+	}
+}
+
+func TestFromComponentReport(t *testing.T) {
+	opened := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	triaged := regressedTest("etcd", "slow | leader election", map[string]string{"Platform": "aws", "Arch": "amd64"}, crtest.ExtremeRegression)
+	triaged.Regression = &models.TestRegression{Opened: opened, Triages: []models.Triage{{URL: "https://issues/OCPBUGS-2"}, {URL: "https://issues/OCPBUGS-1"}}}
+	newTest := regressedTest("apiserver", "new test", map[string]string{"Platform": "gcp"}, crtest.SignificantRegression)
+	newTest.BaseStats = nil
+
+	report := componentreport.ComponentReport{Rows: []componentreport.ReportRow{
+		{Columns: []componentreport.ReportColumn{{RegressedTests: []componentreport.ReportTestSummary{triaged}}}},
+		{Columns: []componentreport.ReportColumn{{}, {RegressedTests: []componentreport.ReportTestSummary{newTest}}}},
+	}}
+	rows := FromComponentReport(report)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "apiserver", rows[0].Component)
+	assert.Nil(t, rows[0].BasePassRate)
+	assert.Equal(t, "Significant", rows[0].Status)
+
+	assert.Equal(t, []string{"Arch:amd64", "Platform:aws"}, rows[1].Variants)
+	assert.Equal(t, "Extreme", rows[1].Status)
+	assert.InDelta(t, 100, *rows[1].BasePassRate, 0.001)
+	assert.InDelta(t, 80, *rows[1].SamplePassRate, 0.001)
+	assert.Equal(t, []string{"https://issues/OCPBUGS-1", "https://issues/OCPBUGS-2"}, rows[1].Triages)
+	assert.Equal(t, &opened, rows[1].Opened)
+	assert.Equal(t, "https://sippy/test_details?testId=id-slow | leader election", rows[1].TestDetailsURL)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, rows))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "Component", records[0][0])
+	assert.Equal(t, []string{"etcd", "Other", "slow | leader election", "id-slow | leader election", "Arch:amd64 Platform:aws",
+		"Extreme", "4.19", "100.00", "4.20", "80.00", "2026-10-01T12:00:00Z",
+		"https://issues/OCPBUGS-1 https://issues/OCPBUGS-2", "https://sippy/test_details?testId=id-slow | leader election"}, records[2])
+	assert.Equal(t, "", records[1][7], "missing pass rates are left empty")
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatMarkdown, rows))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[1], "|---|"))
+	assert.Contains(t, lines[3], `| slow \| leader election |`)
+	assert.Contains(t, lines[3], "| <https://issues/OCPBUGS-1> <https://issues/OCPBUGS-2> |")
+	assert.Contains(t, lines[3], `[details](https://sippy/test_details?testId=id-slow \| leader election)`)
+
+	assert.Error(t, Write(&buf, "pdf", rows))
+}
+
+func TestFromRegressions(t *testing.T) {
+	regressions := []models.TestRegression{
+		{
+			Release: "4.20", BaseRelease: "4.19", Component: "etcd", TestName: "closed", Variants: []string{"Platform:aws", "Arch:amd64"},
+			Closed: sql.NullTime{Valid: true, Time: time.Now()},
+			Links: map[string]string{
+				"self":                   "https://sippy/api/component_readiness/regressions/1",
+				"test_details:4.20-b":    "https://sippy/b",
+				"test_details:4.20-a":    "https://sippy/a",
+				"test_details:4.20-main": "https://sippy/main",
+			},
+		},
+		{Release: "4.20", Component: "apiserver", TestName: "open"},
+	}
+	rows := FromRegressions(regressions, "4.20-main")
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Open", rows[0].Status)
+	assert.Empty(t, rows[0].TestDetailsURL)
+	assert.Equal(t, "Closed", rows[1].Status)
+	assert.Equal(t, []string{"Arch:amd64", "Platform:aws"}, rows[1].Variants)
+	assert.Equal(t, "https://sippy/main", rows[1].TestDetailsURL)
+	assert.Nil(t, rows[1].SamplePassRate)
+
+	rows = FromRegressions(regressions, "")
+	assert.Equal(t, "https://sippy/a", rows[1].TestDetailsURL, "without a view, the first view's link is used")
+}
+
+func TestWriteXLSX(t *testing.T) {
+	rate := 93.456
+	rows := []Row{{Component: "etcd", TestName: "a <b> & c", SamplePassRate: &rate}}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatXLSX, rows))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		parts[f.Name] = string(content)
+	}
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "xl/workbook.xml")
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Component</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t xml:space="preserve">a &lt;b&gt; &amp; c</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="J2"><v>93.46</v></c>`)
+	assert.NotContains(t, sheet, `r="B2"`, "empty cells are omitted")
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", cellRef(0, 1))
+	assert.Equal(t, "Z3", cellRef(25, 3))
+	assert.Equal(t, "AA10", cellRef(26, 10))
+	assert.Equal(t, "BA2", cellRef(52, 2))
+}
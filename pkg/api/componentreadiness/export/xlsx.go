@@ -0,0 +1,113 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The parts of a minimal workbook with a single sheet. Cells hold inline strings, so no shared
+// string table is needed, and the only style is a bold font for the header row.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Regressions" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font/><font><b/></font></fonts>` +
+		`<fills count="1"><fill/></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf/><xf fontId="1" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+// headerStyle is the index of the bold cell format in styles.xml.
+const headerStyle = 1
+
+func writeXLSX(w io.Writer, rows []Row) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	// keep the header visible while scrolling
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+	b.WriteString(`<row r="1">`)
+	for i, c := range columns {
+		writeXLSXCell(&b, cellRef(i, 1), c.header, headerStyle)
+	}
+	b.WriteString(`</row>`)
+	for n, r := range rows {
+		rowNum := n + 2
+		b.WriteString(`<row r="` + strconv.Itoa(rowNum) + `">`)
+		for i, c := range columns {
+			writeXLSXCell(&b, cellRef(i, rowNum), c.value(r), 0)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	if _, err := io.WriteString(f, b.String()); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeXLSXCell(b *strings.Builder, ref string, value any, style int) {
+	attrs := `r="` + ref + `"`
+	if style != 0 {
+		attrs += ` s="` + strconv.Itoa(style) + `"`
+	}
+	switch v := value.(type) {
+	case nil:
+		return
+	case float64:
+		b.WriteString(`<c ` + attrs + `><v>` + strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) + `</v></c>`)
+	default:
+		s := formatValue(v)
+		if s == "" {
+			return
+		}
+		b.WriteString(`<c ` + attrs + ` t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(b, []byte(s))
+		b.WriteString(`</t></is></c>`)
+	}
+}
+
+// cellRef returns the A1 style reference of the zero based column and one based row.
+func cellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
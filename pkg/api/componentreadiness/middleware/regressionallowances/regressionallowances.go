@@ -12,17 +12,25 @@ import (
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
 	v1 "github.com/openshift/sippy/pkg/apis/sippy/v1"
+	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/regressionallowances"
 	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 var _ middleware.Middleware = &RegressionAllowances{}
 
-func NewRegressionAllowancesMiddleware(reqOptions reqopts.RequestOptions, releaseConfigs []v1.Release) *RegressionAllowances {
+// NewRegressionAllowancesMiddleware creates the middleware, reading allowances from the database as the
+// report is generated. Without a database connection no allowances are applied.
+func NewRegressionAllowancesMiddleware(reqOptions reqopts.RequestOptions, releaseConfigs []v1.Release, dbc *db.DB) *RegressionAllowances {
+	var gormDB *gorm.DB
+	if dbc != nil {
+		gormDB = dbc.DB
+	}
 	return &RegressionAllowances{
 		log:                  log.WithField("middleware", "RegressionAllowances"),
 		reqOptions:           reqOptions,
-		regressionGetterFunc: regressionallowances.IntentionalRegressionFor,
+		regressionGetterFunc: regressionallowances.NewStore(gormDB).IntentionalRegressionFor,
 		releaseConfigs:       releaseConfigs,
 	}
 }
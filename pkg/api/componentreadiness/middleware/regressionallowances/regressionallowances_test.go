@@ -149,7 +149,7 @@ func Test_PreAnalysis(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			rfb := NewRegressionAllowancesMiddleware(test.reqOpts, releaseConfigs)
+			rfb := NewRegressionAllowancesMiddleware(test.reqOpts, releaseConfigs, nil)
 			rfb.regressionGetterFunc = test.regressionGetter
 			err := rfb.PreAnalysis(test.testKey, test.testStatus)
 			assert.NoError(t, err)
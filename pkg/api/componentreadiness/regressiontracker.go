@@ -11,8 +11,10 @@ import (
 	"github.com/openshift/sippy/pkg/api/componentreadiness/middleware/regressiontracker"
 	"github.com/openshift/sippy/pkg/api/componentreadiness/utils"
 	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+	"github.com/openshift/sippy/pkg/componentreadiness/notifications"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
 	log "github.com/sirupsen/logrus"
@@ -34,6 +36,13 @@ type RegressionStore interface {
 	ListCurrentRegressionsForRelease(release string) ([]*models.TestRegression, error)
 	OpenRegression(view crview.View, newRegressedTest crtype.ReportTestSummary) (*models.TestRegression, error)
 	UpdateRegression(reg *models.TestRegression) error
+	// ReopenRegression clears the closed date of a recently closed regression seen again in a view.
+	ReopenRegression(view crview.View, reg *models.TestRegression) error
+	// CloseRegression closes a regression that no longer appears in any report.
+	CloseRegression(reg *models.TestRegression, closed time.Time) error
+	// UpdateFailedFix records whether a view's report shows a regression still failing after its triage was
+	// resolved as fixed. The regression is a failed fix while any of its active views reports one.
+	UpdateFailedFix(view crview.View, reg *models.TestRegression, failedFix bool) error
 	// ResolveTriages sets the resolution time on any triages that no longer have active regressions
 	ResolveTriages() error
 	// MergeJobRuns upserts job runs for a regression, adding new ones and skipping duplicates.
@@ -49,10 +58,12 @@ type RegressionStore interface {
 type PostgresRegressionStore struct {
 	dbc        *db.DB
 	jiraClient *jira.Client
+	// notifier is optional, and sent regression lifecycle events when set
+	notifier notifications.Notifier
 }
 
-func NewPostgresRegressionStore(dbc *db.DB, jiraClient *jira.Client) RegressionStore {
-	return &PostgresRegressionStore{dbc: dbc, jiraClient: jiraClient}
+func NewPostgresRegressionStore(dbc *db.DB, jiraClient *jira.Client, notifier notifications.Notifier) RegressionStore {
+	return &PostgresRegressionStore{dbc: dbc, jiraClient: jiraClient, notifier: notifier}
 }
 
 func (prs *PostgresRegressionStore) ListCurrentRegressionsForRelease(release string) ([]*models.TestRegression, error) {
@@ -98,6 +109,8 @@ func (prs *PostgresRegressionStore) OpenRegression(view crview.View, newRegresse
 		return &models.TestRegression{}, res.Error
 	}
 	log.Infof("opened a new regression: %v", newRegression)
+	notifications.Notify(context.Background(), prs.notifier,
+		notifications.RegressionEvent(notifications.RegressionOpened, newRegression, view.Name))
 	return newRegression, nil
 
 }
@@ -107,6 +120,80 @@ func (prs *PostgresRegressionStore) UpdateRegression(reg *models.TestRegression)
 	return res.Error
 }
 
+func (prs *PostgresRegressionStore) ReopenRegression(view crview.View, reg *models.TestRegression) error {
+	reg.Closed = sql.NullTime{Valid: false}
+	if err := prs.UpdateRegression(reg); err != nil {
+		return err
+	}
+	event := notifications.RegressionEvent(notifications.RegressionOpened, reg, view.Name)
+	event.Regression.Reopened = true
+	notifications.Notify(context.Background(), prs.notifier, event)
+	return nil
+}
+
+func (prs *PostgresRegressionStore) CloseRegression(reg *models.TestRegression, closed time.Time) error {
+	// the views are looked up before closing, as rolled-off views are deactivated afterward
+	var views []string
+	if prs.notifier != nil {
+		res := prs.dbc.DB.Model(&models.RegressionView{}).
+			Where("test_regression_id = ? AND active = true", reg.ID).
+			Pluck("view_name", &views)
+		if res.Error != nil {
+			log.WithError(res.Error).Warnf("error listing views for closed regression %d", reg.ID)
+		}
+	}
+
+	reg.Closed = sql.NullTime{Valid: true, Time: closed}
+	if err := prs.UpdateRegression(reg); err != nil {
+		return err
+	}
+	notifications.Notify(context.Background(), prs.notifier,
+		notifications.RegressionEvent(notifications.RegressionClosed, reg, views...))
+	return nil
+}
+
+func (prs *PostgresRegressionStore) UpdateFailedFix(view crview.View, reg *models.TestRegression, failedFix bool) error {
+	// views disagree when their reports differ, so the state is kept per view and combined below,
+	// rather than each view's sync overwriting the others'
+	detected := "NULL"
+	if failedFix {
+		detected = "COALESCE(regression_views.failed_fix_detected, NOW())"
+	}
+	res := prs.dbc.DB.Exec(
+		`INSERT INTO regression_views (test_regression_id, view_name, active, opened_at, failed_fix_detected)
+		 VALUES (?, ?, true, NOW(), CASE WHEN ? THEN NOW() END)
+		 ON CONFLICT (test_regression_id, view_name) DO UPDATE
+		 SET failed_fix_detected = `+detected,
+		reg.ID, view.Name, failedFix)
+	if res.Error != nil {
+		return fmt.Errorf("error updating failed fix of view %s: %w", view.Name, res.Error)
+	}
+
+	var firstDetected sql.NullTime
+	res = prs.dbc.DB.Model(&models.RegressionView{}).
+		Where("test_regression_id = ? AND active = true", reg.ID).
+		Select("MIN(failed_fix_detected)").
+		Scan(&firstDetected)
+	if res.Error != nil {
+		return fmt.Errorf("error combining failed fix of views: %w", res.Error)
+	}
+	if firstDetected.Valid == reg.FailedFixDetected.Valid {
+		return nil
+	}
+	if !firstDetected.Valid {
+		reg.FailedFixDetected = sql.NullTime{}
+		return prs.UpdateRegression(reg)
+	}
+
+	reg.FailedFixDetected = firstDetected
+	if err := prs.UpdateRegression(reg); err != nil {
+		return err
+	}
+	notifications.Notify(context.Background(), prs.notifier,
+		notifications.RegressionEvent(notifications.FailedFix, reg, view.Name))
+	return nil
+}
+
 func (prs *PostgresRegressionStore) MergeJobRuns(regressionID uint, jobRuns []models.RegressionJobRun) error {
 	for i := range jobRuns {
 		jobRuns[i].RegressionID = regressionID
@@ -223,7 +310,7 @@ func (prs *PostgresRegressionStore) ResolveTriages() error {
 	res := prs.dbc.DB.Table("triages").
 		Where("resolved IS NULL").
 		Where("NOT EXISTS (?)", subQuery).
-		Preload("Regressions").
+		Preload("Regressions.Views").
 		Find(&triagesToResolve)
 
 	if res.Error != nil {
@@ -259,6 +346,7 @@ func (prs *PostgresRegressionStore) ResolveTriages() error {
 		}
 
 		ReportTriageResolved(prs.jiraClient, triage)
+		notifications.Notify(context.Background(), prs.notifier, notifications.TriageEvent(notifications.TriageResolved, &triage))
 		log.Infof("Resolved triage %d with resolution time %v", triage.ID, triage.Resolved.Time)
 	}
 
@@ -369,8 +457,7 @@ func SyncRegressionsForReport(
 				// in / out of the report depending on the data available in the sample/basis.
 				rLog.Infof("re-opening existing regression: %v", openReg)
 				reopenedRegs++
-				openReg.Closed = sql.NullTime{Valid: false}
-				err := backend.UpdateRegression(openReg)
+				err := backend.ReopenRegression(view, openReg)
 				if err != nil {
 					rLog.WithError(err).Errorf("error re-opening regression: %v", openReg)
 					return nil, fmt.Errorf("error re-opening regression: %v: %w", openReg, err)
@@ -382,6 +469,10 @@ func SyncRegressionsForReport(
 					"test": regTest.TestName,
 				}).Debugf("reusing already opened regression: %v", openReg)
 			}
+
+			if err := backend.UpdateFailedFix(view, openReg, regTest.ReportStatus == crtest.FailedFixedRegression); err != nil {
+				return nil, fmt.Errorf("error updating failed fix for regression %d: %w", openReg.ID, err)
+			}
 			activeRegressions = append(activeRegressions, openReg)
 		} else {
 			openedRegs++
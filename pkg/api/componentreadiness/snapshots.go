@@ -0,0 +1,113 @@
+package componentreadiness
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crsnapshot"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// ReportSnapshotInfo describes a stored component report snapshot without its contents.
+type ReportSnapshotInfo struct {
+	View    string `json:"view"`
+	Release string `json:"release"`
+	Date    string `json:"date"`
+}
+
+// SaveReportSnapshot stores the day's snapshot of a view's component report, replacing any earlier one from
+// the same day.
+func SaveReportSnapshot(dbc *gorm.DB, view crview.View, report *crtype.ComponentReport, now time.Time) error {
+	date := now.UTC().Format(crsnapshot.DateFormat)
+	jsonb := pgtype.JSONB{}
+	if err := jsonb.Set(crsnapshot.FromReport(view.Name, view.SampleRelease.Name, date, report)); err != nil {
+		return fmt.Errorf("error encoding snapshot for view %s: %w", view.Name, err)
+	}
+	day, _ := time.Parse(crsnapshot.DateFormat, date)
+	snapshot := models.ComponentReportSnapshot{
+		View:    view.Name,
+		Date:    day,
+		Release: view.SampleRelease.Name,
+		Report:  jsonb,
+	}
+	res := dbc.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "view"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"release", "report", "updated_at"}),
+	}).Create(&snapshot)
+	if res.Error != nil {
+		return fmt.Errorf("error saving snapshot for view %s: %w", view.Name, res.Error)
+	}
+	return nil
+}
+
+// ListReportSnapshots lists the stored snapshots, newest first, optionally for a single view.
+func ListReportSnapshots(dbc *gorm.DB, view string) ([]ReportSnapshotInfo, error) {
+	q := dbc.Model(&models.ComponentReportSnapshot{}).Select("view, release, date").Order("date DESC, view")
+	if view != "" {
+		q = q.Where("view = ?", view)
+	}
+	var snapshots []models.ComponentReportSnapshot
+	if err := q.Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	infos := make([]ReportSnapshotInfo, 0, len(snapshots))
+	for _, s := range snapshots {
+		infos = append(infos, ReportSnapshotInfo{View: s.View, Release: s.Release, Date: s.Date.Format(crsnapshot.DateFormat)})
+	}
+	return infos, nil
+}
+
+// GetReportSnapshot returns a view's snapshot for the given date, or nil if there is none.
+func GetReportSnapshot(dbc *gorm.DB, view, date string) (*crsnapshot.Snapshot, error) {
+	if _, err := time.Parse(crsnapshot.DateFormat, date); err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	stored := models.ComponentReportSnapshot{}
+	res := dbc.Where("view = ? AND date = ?", view, date).First(&stored)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, res.Error
+	}
+	snapshot := &crsnapshot.Snapshot{}
+	if err := json.Unmarshal(stored.Report.Bytes, snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot for view %s on %s: %w", view, date, err)
+	}
+	return snapshot, nil
+}
+
+// DiffReportSnapshots compares a view's snapshots from two dates. If to is empty, the latest snapshot is used.
+// A nil diff is returned if either snapshot does not exist.
+func DiffReportSnapshots(dbc *gorm.DB, view, from, to string) (*crsnapshot.Diff, error) {
+	if to == "" {
+		var latest models.ComponentReportSnapshot
+		res := dbc.Select("date").Where("view = ?", view).Order("date DESC").First(&latest)
+		if res.Error != nil {
+			if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+				return nil, nil
+			}
+			return nil, res.Error
+		}
+		to = latest.Date.Format(crsnapshot.DateFormat)
+	}
+
+	fromSnapshot, err := GetReportSnapshot(dbc, view, from)
+	if err != nil || fromSnapshot == nil {
+		return nil, err
+	}
+	toSnapshot, err := GetReportSnapshot(dbc, view, to)
+	if err != nil || toSnapshot == nil {
+		return nil, err
+	}
+	diff := crsnapshot.Compare(*fromSnapshot, *toSnapshot)
+	return &diff, nil
+}
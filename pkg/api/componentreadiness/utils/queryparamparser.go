@@ -196,6 +196,9 @@ func mergeAdvancedOptions(req *http.Request, hasView bool, viewOpts, parsedOpts
 	if len(req.URL.Query()["keyTestName"]) > 0 {
 		viewOpts.KeyTestNames = parsedOpts.KeyTestNames
 	}
+	if req.URL.Query().Get("analyzer") != "" {
+		viewOpts.Analyzer = parsedOpts.Analyzer
+	}
 	return viewOpts
 }
 
@@ -423,6 +426,11 @@ func parseAdvancedOptions(req *http.Request) (advancedOption reqopts.Advanced, e
 	// all other test failures in that job are excluded from regression analysis
 	advancedOption.KeyTestNames = req.URL.Query()["keyTestName"]
 
+	advancedOption.Analyzer = req.URL.Query().Get("analyzer")
+	if err = advancedOption.ValidateAnalyzer(); err != nil {
+		return advancedOption, &api.ValidationError{Message: err.Error()}
+	}
+
 	return
 }
 
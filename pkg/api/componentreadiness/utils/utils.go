@@ -173,6 +173,9 @@ func addAdvancedOptionsParams(params url.Values, advancedOptions reqopts.Advance
 	params.Add("ignoreMissing", strconv.FormatBool(advancedOptions.IgnoreMissing))
 	params.Add("flakeAsFailure", strconv.FormatBool(advancedOptions.FlakeAsFailure))
 	params.Add("includeMultiReleaseAnalysis", strconv.FormatBool(advancedOptions.IncludeMultiReleaseAnalysis))
+	if advancedOptions.Analyzer != "" {
+		params.Add("analyzer", advancedOptions.Analyzer)
+	}
 }
 
 // addVariantOptionsParams adds variant options to URL parameters
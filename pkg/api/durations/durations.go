@@ -0,0 +1,269 @@
+// Package durations finds tests that have become significantly slower, which pushes jobs into
+// timeouts that then show up as unexplained failures.
+package durations
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	v1 "github.com/openshift/sippy/pkg/apis/sippyprocessing/v1"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+const (
+	DefaultBasisDays             = 14
+	DefaultSampleDays            = 7
+	DefaultMinSamples            = 30
+	DefaultAlpha                 = 0.01
+	DefaultMinSlowdownPercentage = 20.0
+	DefaultMinSlowdownSeconds    = 10.0
+	DefaultLimit                 = 100
+)
+
+// excludedVariants are jobs whose durations would skew the comparison: aggregated jobs repeat the
+// results of the jobs they aggregate, and never-stable jobs are not expected to pass.
+var excludedVariants = []string{"aggregated", "never-stable"}
+
+// Options select the tests to compare and the thresholds for a regression.
+type Options struct {
+	Release string
+	// Variant limits the report to jobs with the variant, e.g. Platform:aws.
+	Variant string
+	// GroupBy compares each test separately per value of the variant, e.g. Platform. Runs in jobs
+	// without the variant are grouped under an empty variant.
+	GroupBy string
+	// SampleDays is the length of the recent window, which is compared to the BasisDays before it.
+	BasisDays  int
+	SampleDays int
+	// MinSamples is the fewest passing runs in each window for a test to be compared.
+	MinSamples int
+	// Alpha is the p-value below which the sample is significantly slower than the basis.
+	Alpha float64
+	// MinSlowdownPercentage and MinSlowdownSeconds are how much the median duration must have
+	// grown, relatively and absolutely, so that large samples do not flag trivial slowdowns.
+	MinSlowdownPercentage float64
+	MinSlowdownSeconds    float64
+	Limit                 int
+}
+
+// WithDefaults fills in unset options.
+func (o Options) WithDefaults() Options {
+	if o.BasisDays <= 0 {
+		o.BasisDays = DefaultBasisDays
+	}
+	if o.SampleDays <= 0 {
+		o.SampleDays = DefaultSampleDays
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = DefaultMinSamples
+	}
+	if o.Alpha <= 0 {
+		o.Alpha = DefaultAlpha
+	}
+	if o.MinSlowdownPercentage <= 0 {
+		o.MinSlowdownPercentage = DefaultMinSlowdownPercentage
+	}
+	if o.MinSlowdownSeconds <= 0 {
+		o.MinSlowdownSeconds = DefaultMinSlowdownSeconds
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultLimit
+	}
+	return o
+}
+
+// Report lists duration regressions, largest slowdown first.
+type Report struct {
+	Release     string               `json:"release"`
+	Variant     string               `json:"variant,omitempty"`
+	GroupBy     string               `json:"group_by,omitempty"`
+	BasisStart  civil.Date           `json:"basis_start"`
+	BasisEnd    civil.Date           `json:"basis_end"`
+	SampleStart civil.Date           `json:"sample_start"`
+	SampleEnd   civil.Date           `json:"sample_end"`
+	Regressions []DurationRegression `json:"regressions"`
+}
+
+// DurationRegression compares the passing run durations of a test, in seconds, between the
+// basis and sample windows.
+type DurationRegression struct {
+	TestID   uint   `json:"test_id"`
+	TestName string `json:"test_name"`
+	// Variant is the value of the group_by variant, if any.
+	Variant string `json:"variant,omitempty"`
+
+	BasisRuns          int     `json:"basis_runs"`
+	SampleRuns         int     `json:"sample_runs"`
+	BasisMedian        float64 `json:"basis_median"`
+	SampleMedian       float64 `json:"sample_median"`
+	SlowdownPercentage float64 `json:"slowdown_percentage"`
+
+	// U is the Mann-Whitney U statistic of the sample, and PValue the one-sided probability of a
+	// U at least this large if sample and basis durations came from the same distribution.
+	U      float64 `json:"u"`
+	PValue float64 `json:"p_value"`
+	// SlowerProbability is the chance a sample run is slower than a basis run, 0.5 if unchanged.
+	SlowerProbability float64 `json:"slower_probability"`
+}
+
+// rankedDurations summarizes the durations of a test, ranked across both windows.
+type rankedDurations struct {
+	TestID       uint
+	TestName     string
+	Variant      string
+	BasisRuns    int
+	SampleRuns   int
+	BasisMedian  float64
+	SampleMedian float64
+	// SampleRankSum is the sum of the ranks of sample durations, ties taking their average rank.
+	SampleRankSum float64
+	// TieTerm is the sum of t^3-t over groups of t equal durations.
+	TieTerm float64
+}
+
+// GetDurationRegressions compares the sample window ending at reportEnd to the basis window
+// before it.
+func GetDurationRegressions(dbc *db.DB, opts Options, reportEnd time.Time) (Report, error) {
+	opts = opts.WithDefaults()
+	sampleEnd := civil.DateOf(reportEnd.UTC())
+	sampleStart := sampleEnd.AddDays(-opts.SampleDays + 1)
+	basisStart := sampleStart.AddDays(-opts.BasisDays)
+	report := Report{
+		Release:     opts.Release,
+		Variant:     opts.Variant,
+		GroupBy:     opts.GroupBy,
+		BasisStart:  basisStart,
+		BasisEnd:    sampleStart.AddDays(-1),
+		SampleStart: sampleStart,
+		SampleEnd:   sampleEnd,
+		Regressions: []DurationRegression{},
+	}
+
+	group := "''"
+	args := []interface{}{}
+	if opts.GroupBy != "" {
+		group = "COALESCE((SELECT v FROM unnest(pj.variants) v WHERE v LIKE ? LIMIT 1), '')"
+		args = append(args, opts.GroupBy+":%")
+	}
+	variantFilter := ""
+	args = append(args, sampleStart, opts.Release, basisStart, sampleEnd.AddDays(1),
+		[]int{int(v1.TestStatusSuccess), int(v1.TestStatusFlake)}, pq.Array(excludedVariants))
+	if opts.Variant != "" {
+		variantFilter = "AND ? = ANY(pj.variants)"
+		args = append(args, opts.Variant)
+	}
+	args = append(args, opts.MinSamples, opts.MinSamples)
+
+	// Ranks are computed in the database so that only a row per test is returned, rather than
+	// every run of every test.
+	var rows []rankedDurations
+	res := dbc.DB.Raw(fmt.Sprintf(`WITH runs AS (
+			SELECT pjrt.test_id, %s AS variant, pjrt.duration, pjrt.prow_job_run_timestamp >= ? AS in_sample
+			FROM prow_job_run_tests pjrt
+			JOIN prow_jobs pj ON pj.id = pjrt.prow_job_id
+			JOIN prow_job_runs pjr ON pjr.id = pjrt.prow_job_run_id AND pjr.prow_job_release = pjrt.prow_job_run_release AND pjr.timestamp = pjrt.prow_job_run_timestamp
+			WHERE pjrt.prow_job_run_release = ?
+				AND pjrt.prow_job_run_timestamp >= ? AND pjrt.prow_job_run_timestamp < ?
+				AND pjrt.status IN ? AND pjrt.duration > 0 AND pjrt.deleted_at IS NULL
+				AND (pjr.labels IS NULL OR NOT (pjr.labels @> ARRAY['InfraFailure']))
+				AND NOT (COALESCE(pj.variants, '{}') && ?)
+				%s
+		), ranked AS (
+			SELECT test_id, variant, duration, in_sample,
+				rank() OVER (PARTITION BY test_id, variant ORDER BY duration)
+					+ (count(*) OVER (PARTITION BY test_id, variant, duration) - 1) / 2.0 AS rank
+			FROM runs
+		), ties AS (
+			SELECT test_id, variant, SUM(t * t * t - t) AS tie_term
+			FROM (SELECT test_id, variant, count(*)::float8 AS t FROM runs GROUP BY test_id, variant, duration) counts
+			GROUP BY test_id, variant
+		)
+		SELECT r.test_id, t.name AS test_name, r.variant,
+			COUNT(*) FILTER (WHERE NOT r.in_sample) AS basis_runs,
+			COUNT(*) FILTER (WHERE r.in_sample) AS sample_runs,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY r.duration) FILTER (WHERE NOT r.in_sample) AS basis_median,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY r.duration) FILTER (WHERE r.in_sample) AS sample_median,
+			SUM(r.rank) FILTER (WHERE r.in_sample) AS sample_rank_sum,
+			MAX(ties.tie_term) AS tie_term
+		FROM ranked r
+		JOIN ties ON ties.test_id = r.test_id AND ties.variant = r.variant
+		JOIN tests t ON t.id = r.test_id
+		GROUP BY r.test_id, t.name, r.variant
+		HAVING COUNT(*) FILTER (WHERE NOT r.in_sample) >= ? AND COUNT(*) FILTER (WHERE r.in_sample) >= ?`,
+		group, variantFilter), args...).Scan(&rows)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("error querying test durations")
+		return report, res.Error
+	}
+
+	report.Regressions = findRegressions(rows, opts)
+	return report, nil
+}
+
+// findRegressions returns the tests whose sample durations are significantly and substantially
+// longer than their basis durations, largest slowdown first.
+func findRegressions(rows []rankedDurations, opts Options) []DurationRegression {
+	regressions := []DurationRegression{}
+	for _, r := range rows {
+		if r.BasisMedian <= 0 || r.SampleMedian-r.BasisMedian < opts.MinSlowdownSeconds {
+			continue
+		}
+		slowdown := (r.SampleMedian - r.BasisMedian) * 100 / r.BasisMedian
+		if slowdown < opts.MinSlowdownPercentage {
+			continue
+		}
+		u, p := mannWhitneyU(r.BasisRuns, r.SampleRuns, r.SampleRankSum, r.TieTerm)
+		if p >= opts.Alpha {
+			continue
+		}
+		regressions = append(regressions, DurationRegression{
+			TestID:             r.TestID,
+			TestName:           r.TestName,
+			Variant:            r.Variant,
+			BasisRuns:          r.BasisRuns,
+			SampleRuns:         r.SampleRuns,
+			BasisMedian:        r.BasisMedian,
+			SampleMedian:       r.SampleMedian,
+			SlowdownPercentage: slowdown,
+			U:                  u,
+			PValue:             p,
+			SlowerProbability:  u / float64(r.BasisRuns*r.SampleRuns),
+		})
+	}
+
+	sort.SliceStable(regressions, func(i, j int) bool {
+		if regressions[i].SlowdownPercentage != regressions[j].SlowdownPercentage {
+			return regressions[i].SlowdownPercentage > regressions[j].SlowdownPercentage
+		}
+		return regressions[i].PValue < regressions[j].PValue
+	})
+	if len(regressions) > opts.Limit {
+		regressions = regressions[:opts.Limit]
+	}
+	return regressions
+}
+
+// mannWhitneyU returns the U statistic of a sample of n2 values, ranked together with a basis of
+// n1 values, and the one-sided p-value of the sample being larger. It uses the normal
+// approximation with tie and continuity corrections, which is accurate for the sample sizes
+// compared here.
+func mannWhitneyU(n1, n2 int, sampleRankSum, tieTerm float64) (float64, float64) {
+	fn1, fn2 := float64(n1), float64(n2)
+	u := sampleRankSum - fn2*(fn2+1)/2
+	n := fn1 + fn2
+	if n1 == 0 || n2 == 0 {
+		return u, 1
+	}
+	variance := fn1 * fn2 / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 { // every duration is the same
+		return u, 1
+	}
+	z := (u - fn1*fn2/2 - 0.5) / math.Sqrt(variance)
+	return u, 0.5 * math.Erfc(z/math.Sqrt2)
+}
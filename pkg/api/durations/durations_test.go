@@ -0,0 +1,92 @@
+package durations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rank computes the summary the database query returns, for durations given in seconds.
+func rank(basis, sample []float64) rankedDurations {
+	all := append(append([]float64{}, basis...), sample...)
+	counts := map[float64]int{}
+	for _, d := range all {
+		counts[d]++
+	}
+	r := rankedDurations{BasisRuns: len(basis), SampleRuns: len(sample)}
+	for _, d := range sample {
+		below := 0
+		for _, other := range all {
+			if other < d {
+				below++
+			}
+		}
+		r.SampleRankSum += float64(below) + float64(counts[d]+1)/2
+	}
+	for _, t := range counts {
+		r.TieTerm += float64(t*t*t - t)
+	}
+	return r
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	r := rank([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+	u, p := mannWhitneyU(r.BasisRuns, r.SampleRuns, r.SampleRankSum, r.TieTerm)
+	assert.Equal(t, 25.0, u)
+	assert.InDelta(t, 0.0061, p, 0.0001)
+
+	r = rank([]float64{1, 2, 3}, []float64{2, 4, 5})
+	assert.Equal(t, 13.5, r.SampleRankSum)
+	assert.Equal(t, 6.0, r.TieTerm)
+	u, p = mannWhitneyU(r.BasisRuns, r.SampleRuns, r.SampleRankSum, r.TieTerm)
+	assert.Equal(t, 7.5, u)
+	assert.InDelta(t, 0.1341, p, 0.0001)
+
+	r = rank([]float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5})
+	u, p = mannWhitneyU(r.BasisRuns, r.SampleRuns, r.SampleRankSum, r.TieTerm)
+	assert.Zero(t, u)
+	assert.Greater(t, p, 0.99, "faster samples are not a regression")
+
+	r = rank([]float64{5, 5, 5}, []float64{5, 5, 5})
+	_, p = mannWhitneyU(r.BasisRuns, r.SampleRuns, r.SampleRankSum, r.TieTerm)
+	assert.Equal(t, 1.0, p)
+}
+
+func TestFindRegressions(t *testing.T) {
+	durations := func(from float64, n int) []float64 {
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = from + float64(i)
+		}
+		return d
+	}
+	withTest := func(r rankedDurations, id uint, basisMedian, sampleMedian float64) rankedDurations {
+		r.TestID, r.TestName, r.BasisMedian, r.SampleMedian = id, "test", basisMedian, sampleMedian
+		return r
+	}
+	rows := []rankedDurations{
+		// twice as slow
+		withTest(rank(durations(100, 40), durations(200, 40)), 1, 119.5, 219.5),
+		// a third slower
+		withTest(rank(durations(100, 40), durations(140, 40)), 2, 119.5, 159.5),
+		// significantly but only slightly slower
+		withTest(rank(durations(100, 40), durations(110, 40)), 3, 119.5, 129.5),
+		// much slower relatively, but only by a few seconds
+		withTest(rank(durations(1, 40), durations(41, 40)), 4, 1.5, 6.5),
+		// unchanged
+		withTest(rank(durations(100, 40), durations(100, 40)), 5, 119.5, 119.5),
+	}
+	opts := Options{}.WithDefaults()
+
+	regressions := findRegressions(rows, opts)
+	require.Len(t, regressions, 2)
+	assert.Equal(t, uint(1), regressions[0].TestID)
+	assert.InDelta(t, 83.68, regressions[0].SlowdownPercentage, 0.01)
+	assert.Equal(t, 1.0, regressions[0].SlowerProbability)
+	assert.Less(t, regressions[0].PValue, opts.Alpha)
+	assert.Equal(t, uint(2), regressions[1].TestID)
+
+	opts.Limit = 1
+	assert.Len(t, findRegressions(rows, opts), 1)
+}
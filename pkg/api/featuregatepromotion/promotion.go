@@ -394,6 +394,7 @@ func buildVariantResult(jv JobVariant, allTests []apitype.Test) VariantResult {
 
 	vr := VariantResult{
 		Variants:   variants,
+		JobTiers:   JobTiersForVariant(jv),
 		Optional:   jv.Optional,
 		Sufficient: true,
 	}
@@ -0,0 +1,392 @@
+package featuregatepromotion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness/export"
+	"github.com/openshift/sippy/pkg/apis/cache"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// ReportDateFormat is the format of the dates release reports are stored under.
+const ReportDateFormat = "2006-01-02"
+
+// TrendLookback is how far back the report a release report is compared with is by default.
+const TrendLookback = 7 * 24 * time.Hour
+
+// PreviewFeatureSets are the feature sets gates are enabled in before they are promoted to Default.
+var PreviewFeatureSets = []string{"DevPreviewNoUpgrade", "TechPreviewNoUpgrade"}
+
+// Changes of a gate between two release reports.
+const (
+	ChangeAdded           = "added"
+	ChangeRemoved         = "removed"
+	ChangeNowSufficient   = "now_sufficient"
+	ChangeNowBlocked      = "now_blocked"
+	ChangeBlockersChanged = "blockers_changed"
+)
+
+// CandidateGate is a feature gate that is enabled in a preview feature set, and not yet promoted.
+type CandidateGate struct {
+	FeatureGate string
+	FeatureSets []string
+}
+
+// ReleaseReport is the promotion readiness of every unpromoted feature gate of a release on a day.
+type ReleaseReport struct {
+	Release     string        `json:"release"`
+	Date        string        `json:"date"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Gates       []GateSummary `json:"gates"`
+	// Trend compares the report with an earlier stored one. It is added when the report is
+	// requested, and is not stored with it.
+	Trend *ReportTrend `json:"trend,omitempty"`
+}
+
+// GateSummary is the promotion readiness of a feature gate.
+type GateSummary struct {
+	FeatureGate string   `json:"feature_gate"`
+	FeatureSets []string `json:"feature_sets"`
+	Sufficient  bool     `json:"sufficient"`
+	// Blockers are the required variants without sufficient test results.
+	Blockers []VariantBlocker `json:"blockers"`
+	// FailingCapabilityTests is how many tests in jobs owned by the gate have a pass rate below the
+	// required one, not counting those ignored as failures of other unpromoted gates.
+	FailingCapabilityTests int `json:"failing_capability_tests"`
+	// Error is why the gate could not be analyzed, in which case it is not sufficient.
+	Error string `json:"error,omitempty"`
+}
+
+// VariantBlocker is a required variant whose test results block the promotion of a gate.
+type VariantBlocker struct {
+	// Variant is the label of the variant, e.g. ha/metal/amd64/ipv6
+	Variant  string   `json:"variant"`
+	JobTiers []string `json:"job_tiers"`
+	Errors   []string `json:"errors"`
+}
+
+// ReportTrend is how the gates of a release report changed since an earlier report.
+type ReportTrend struct {
+	ComparedTo string       `json:"compared_to"`
+	Changes    []GateChange `json:"changes"`
+}
+
+// GateChange is how a gate changed between two release reports.
+type GateChange struct {
+	FeatureGate string `json:"feature_gate"`
+	// Change is one of ChangeAdded, ChangeRemoved, ChangeNowSufficient, ChangeNowBlocked or ChangeBlockersChanged
+	Change string `json:"change"`
+	// NewBlockers and ResolvedBlockers are the variants that started and stopped blocking the gate.
+	NewBlockers      []string `json:"new_blockers,omitempty"`
+	ResolvedBlockers []string `json:"resolved_blockers,omitempty"`
+}
+
+// ListCandidateGates returns the gates of a release that are enabled in a preview feature set and
+// are not promoted to Default, by name.
+func ListCandidateGates(dbc *db.DB, release string) ([]CandidateGate, error) {
+	var rows []struct {
+		FeatureGate string
+		FeatureSets pq.StringArray `gorm:"type:text[]"`
+	}
+	res := dbc.DB.Table("feature_gates").
+		Select("feature_gate, ARRAY_AGG(DISTINCT feature_set ORDER BY feature_set) AS feature_sets").
+		Where("release = ? AND status = 'enabled' AND feature_set IN ?", release, PreviewFeatureSets).
+		Group("feature_gate").
+		Order("feature_gate").
+		Scan(&rows)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	promotedGates, err := getPromotedGateNames(dbc, release)
+	if err != nil {
+		return nil, fmt.Errorf("querying promoted gate names: %w", err)
+	}
+	candidates := make([]CandidateGate, 0, len(rows))
+	for _, row := range rows {
+		if promotedGates.Has(row.FeatureGate) {
+			continue
+		}
+		candidates = append(candidates, CandidateGate{FeatureGate: row.FeatureGate, FeatureSets: row.FeatureSets})
+	}
+	return candidates, nil
+}
+
+// GenerateReleaseReport runs the promotion analysis of every unpromoted feature gate of a release.
+// A gate that cannot be analyzed is reported with its error, rather than failing the report.
+func GenerateReleaseReport(ctx context.Context, dbc *db.DB, cacheClient cache.Cache, release string, now time.Time) (*ReleaseReport, error) {
+	candidates, err := ListCandidateGates(dbc, release)
+	if err != nil {
+		return nil, fmt.Errorf("listing feature gates: %w", err)
+	}
+
+	report := &ReleaseReport{
+		Release:     release,
+		Date:        now.UTC().Format(ReportDateFormat),
+		GeneratedAt: now.UTC(),
+		Gates:       make([]GateSummary, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		status, err := GetPromotionStatus(ctx, dbc, cacheClient, release, candidate.FeatureGate)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"release":      release,
+				"feature_gate": candidate.FeatureGate,
+			}).Error("error computing feature gate promotion status")
+			report.Gates = append(report.Gates, GateSummary{
+				FeatureGate: candidate.FeatureGate,
+				FeatureSets: candidate.FeatureSets,
+				Blockers:    []VariantBlocker{},
+				Error:       err.Error(),
+			})
+			continue
+		}
+		report.Gates = append(report.Gates, summarizeGate(candidate, status))
+	}
+	return report, nil
+}
+
+// summarizeGate reduces a gate's promotion status to what blocks it.
+func summarizeGate(candidate CandidateGate, status *PromotionStatus) GateSummary {
+	summary := GateSummary{
+		FeatureGate: candidate.FeatureGate,
+		FeatureSets: candidate.FeatureSets,
+		Sufficient:  status.Sufficient,
+		Blockers:    []VariantBlocker{},
+	}
+	for _, vr := range status.ResultsByVariant {
+		if vr.Sufficient || vr.Optional {
+			continue
+		}
+		errs := vr.Errors
+		if errs == nil {
+			errs = []string{}
+		}
+		summary.Blockers = append(summary.Blockers, VariantBlocker{
+			Variant:  variantResultLabel(vr),
+			JobTiers: vr.JobTiers,
+			Errors:   errs,
+		})
+	}
+	for _, r := range status.CapabilityTestRegressions {
+		if !r.Ignored {
+			summary.FailingCapabilityTests++
+		}
+	}
+	return summary
+}
+
+func variantResultLabel(vr VariantResult) string {
+	return variantLabel(JobVariant{
+		Cloud:        vr.Variants["Platform"],
+		Architecture: vr.Variants["Architecture"],
+		Topology:     vr.Variants["Topology"],
+		NetworkStack: vr.Variants["NetworkStack"],
+		OS:           vr.Variants["OS"],
+	})
+}
+
+// CompareReports returns how the gates changed from the previous report to the current one.
+func CompareReports(previous, current ReleaseReport) ReportTrend {
+	trend := ReportTrend{ComparedTo: previous.Date, Changes: []GateChange{}}
+
+	previousGates := make(map[string]GateSummary, len(previous.Gates))
+	for _, gate := range previous.Gates {
+		previousGates[gate.FeatureGate] = gate
+	}
+	currentGates := sets.New[string]()
+	for _, gate := range current.Gates {
+		currentGates.Insert(gate.FeatureGate)
+		before, ok := previousGates[gate.FeatureGate]
+		if !ok {
+			trend.Changes = append(trend.Changes, GateChange{FeatureGate: gate.FeatureGate, Change: ChangeAdded})
+			continue
+		}
+
+		beforeBlockers, afterBlockers := blockerLabels(before), blockerLabels(gate)
+		change := GateChange{
+			FeatureGate:      gate.FeatureGate,
+			NewBlockers:      sets.List(afterBlockers.Difference(beforeBlockers)),
+			ResolvedBlockers: sets.List(beforeBlockers.Difference(afterBlockers)),
+		}
+		switch {
+		case gate.Sufficient && !before.Sufficient:
+			change.Change = ChangeNowSufficient
+		case !gate.Sufficient && before.Sufficient:
+			change.Change = ChangeNowBlocked
+		case len(change.NewBlockers) > 0 || len(change.ResolvedBlockers) > 0:
+			change.Change = ChangeBlockersChanged
+		default:
+			continue
+		}
+		trend.Changes = append(trend.Changes, change)
+	}
+	for _, gate := range previous.Gates {
+		if !currentGates.Has(gate.FeatureGate) {
+			trend.Changes = append(trend.Changes, GateChange{FeatureGate: gate.FeatureGate, Change: ChangeRemoved})
+		}
+	}
+
+	sort.Slice(trend.Changes, func(i, j int) bool {
+		return trend.Changes[i].FeatureGate < trend.Changes[j].FeatureGate
+	})
+	return trend
+}
+
+func blockerLabels(gate GateSummary) sets.Set[string] {
+	labels := sets.New[string]()
+	for _, b := range gate.Blockers {
+		labels.Insert(b.Variant)
+	}
+	return labels
+}
+
+// SaveReleaseReport stores the day's report of a release, replacing any earlier one from the same
+// day. The trend is not stored.
+func SaveReleaseReport(dbc *gorm.DB, report ReleaseReport) error {
+	day, err := time.Parse(ReportDateFormat, report.Date)
+	if err != nil {
+		return fmt.Errorf("invalid report date %q: %w", report.Date, err)
+	}
+	report.Trend = nil
+	jsonb := pgtype.JSONB{}
+	if err := jsonb.Set(report); err != nil {
+		return fmt.Errorf("error encoding feature gate promotion report for %s: %w", report.Release, err)
+	}
+	stored := models.FeatureGatePromotionReport{
+		Release: report.Release,
+		Date:    day,
+		Report:  jsonb,
+	}
+	res := dbc.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "release"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"report", "updated_at"}),
+	}).Create(&stored)
+	if res.Error != nil {
+		return fmt.Errorf("error saving feature gate promotion report for %s: %w", report.Release, res.Error)
+	}
+	return nil
+}
+
+// GetReleaseReport returns the stored report of a release from the latest day on or before date,
+// or nil if there is none.
+func GetReleaseReport(dbc *gorm.DB, release, date string) (*ReleaseReport, error) {
+	if _, err := time.Parse(ReportDateFormat, date); err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	stored := models.FeatureGatePromotionReport{}
+	res := dbc.Where("release = ? AND date <= ?", release, date).Order("date DESC").First(&stored)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, res.Error
+	}
+	report := &ReleaseReport{}
+	if err := json.Unmarshal(stored.Report.Bytes, report); err != nil {
+		return nil, fmt.Errorf("error decoding feature gate promotion report for %s on %s: %w", release, stored.Date.Format(ReportDateFormat), err)
+	}
+	return report, nil
+}
+
+// AddTrend compares the report with the stored report of the latest day on or before compareTo,
+// or TrendLookback before the report when compareTo is empty. The trend is left empty if there is
+// no such report.
+func AddTrend(dbc *gorm.DB, report *ReleaseReport, compareTo string) error {
+	if compareTo == "" {
+		day, err := time.Parse(ReportDateFormat, report.Date)
+		if err != nil {
+			return fmt.Errorf("invalid report date %q: %w", report.Date, err)
+		}
+		compareTo = day.Add(-TrendLookback).Format(ReportDateFormat)
+	}
+	previous, err := GetReleaseReport(dbc, report.Release, compareTo)
+	if err != nil || previous == nil {
+		return err
+	}
+	trend := CompareReports(*previous, *report)
+	report.Trend = &trend
+	return nil
+}
+
+// WriteMarkdown writes the report as a markdown document, with a table of the gates and what
+// blocks them, and the changes since the report it is compared with.
+func (r ReleaseReport) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sufficient := 0
+	for _, gate := range r.Gates {
+		if gate.Sufficient {
+			sufficient++
+		}
+	}
+	fmt.Fprintf(&sb, "# Feature gate promotion readiness for %s\n\n", r.Release)
+	fmt.Fprintf(&sb, "Report of %s: %d unpromoted gates, %d ready for promotion, %d blocked.\n",
+		r.Date, len(r.Gates), sufficient, len(r.Gates)-sufficient)
+
+	if len(r.Gates) > 0 {
+		sb.WriteString("\n| Feature gate | Feature sets | Ready | Blocking variants | Failing capability tests |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, gate := range r.Gates {
+			ready := "no"
+			if gate.Sufficient {
+				ready = "yes"
+			}
+			blockers := make([]string, 0, len(gate.Blockers))
+			for _, b := range gate.Blockers {
+				blockers = append(blockers, describeBlocker(b))
+			}
+			blocking := strings.Join(blockers, ", ")
+			if gate.Error != "" {
+				blocking = "error: " + gate.Error
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %d |\n",
+				export.MarkdownCell(gate.FeatureGate), export.MarkdownCell(strings.Join(gate.FeatureSets, ", ")), ready,
+				export.MarkdownCell(blocking), gate.FailingCapabilityTests)
+		}
+	}
+
+	if r.Trend != nil {
+		fmt.Fprintf(&sb, "\n## Changes since %s\n\n", r.Trend.ComparedTo)
+		if len(r.Trend.Changes) == 0 {
+			sb.WriteString("No changes.\n")
+		} else {
+			sb.WriteString("| Feature gate | Change | New blockers | Resolved blockers |\n")
+			sb.WriteString("| --- | --- | --- | --- |\n")
+			for _, change := range r.Trend.Changes {
+				fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+					export.MarkdownCell(change.FeatureGate), strings.ReplaceAll(change.Change, "_", " "),
+					export.MarkdownCell(strings.Join(change.NewBlockers, ", ")),
+					export.MarkdownCell(strings.Join(change.ResolvedBlockers, ", ")))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// describeBlocker labels a blocking variant, with its job tiers when they are restricted.
+func describeBlocker(b VariantBlocker) string {
+	if len(b.JobTiers) == 0 || sets.New(b.JobTiers...).Equal(sets.New(JobTiersForVariant(JobVariant{})...)) {
+		return b.Variant
+	}
+	return fmt.Sprintf("%s (%s)", b.Variant, strings.Join(b.JobTiers, ", "))
+}
@@ -0,0 +1,120 @@
+package featuregatepromotion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeGate(t *testing.T) {
+	status := &PromotionStatus{
+		FeatureGate: "MetalNetworking",
+		Sufficient:  false,
+		ResultsByVariant: []VariantResult{
+			{
+				Variants:   map[string]string{"Platform": "metal", "Architecture": "amd64", "Topology": "ha", "NetworkStack": "ipv4"},
+				JobTiers:   JobTiersForVariant(JobVariant{}),
+				Sufficient: true,
+			},
+			{
+				Variants:   map[string]string{"Platform": "metal", "Architecture": "amd64", "Topology": "ha", "NetworkStack": "ipv6"},
+				JobTiers:   JobTiersForVariant(JobVariant{}),
+				Sufficient: false,
+				Errors:     []string{"only 3 tests found, need at least 5 on ha/metal/amd64/ipv6"},
+			},
+			{
+				Variants:   map[string]string{"Platform": "metal", "Architecture": "amd64", "Topology": "two-node-fencing", "NetworkStack": "ipv4"},
+				JobTiers:   []string{"blocking", "candidate"},
+				Optional:   true,
+				Sufficient: false,
+				Errors:     []string{"optional variants do not block"},
+			},
+		},
+		CapabilityTestRegressions: []CapabilityTestRegression{
+			{TestName: "a"},
+			{TestName: "b", Ignored: true},
+		},
+	}
+
+	summary := summarizeGate(CandidateGate{FeatureGate: "MetalNetworking", FeatureSets: []string{"TechPreviewNoUpgrade"}}, status)
+	assert.False(t, summary.Sufficient)
+	assert.Equal(t, []string{"TechPreviewNoUpgrade"}, summary.FeatureSets)
+	require.Len(t, summary.Blockers, 1)
+	assert.Equal(t, "ha/metal/amd64/ipv6", summary.Blockers[0].Variant)
+	assert.Equal(t, []string{"only 3 tests found, need at least 5 on ha/metal/amd64/ipv6"}, summary.Blockers[0].Errors)
+	assert.Equal(t, 1, summary.FailingCapabilityTests)
+}
+
+func TestCompareReports(t *testing.T) {
+	previous := ReleaseReport{
+		Release: "4.22",
+		Date:    "2026-10-10",
+		Gates: []GateSummary{
+			{FeatureGate: "Promoted", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}}},
+			{FeatureGate: "NowReady", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/gcp/amd64"}}},
+			{FeatureGate: "Regressed", Sufficient: true},
+			{FeatureGate: "Shifting", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}, {Variant: "ha/gcp/amd64"}}},
+			{FeatureGate: "Unchanged", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}}},
+		},
+	}
+	current := ReleaseReport{
+		Release: "4.22",
+		Date:    "2026-10-17",
+		Gates: []GateSummary{
+			{FeatureGate: "Added", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}}},
+			{FeatureGate: "NowReady", Sufficient: true},
+			{FeatureGate: "Regressed", Sufficient: false, Blockers: []VariantBlocker{{Variant: "hypershift/aws/amd64"}}},
+			{FeatureGate: "Shifting", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}, {Variant: "ha/azure/amd64"}}},
+			{FeatureGate: "Unchanged", Sufficient: false, Blockers: []VariantBlocker{{Variant: "ha/aws/amd64"}}},
+		},
+	}
+
+	trend := CompareReports(previous, current)
+	assert.Equal(t, "2026-10-10", trend.ComparedTo)
+	assert.Equal(t, []GateChange{
+		{FeatureGate: "Added", Change: ChangeAdded},
+		{FeatureGate: "NowReady", Change: ChangeNowSufficient, NewBlockers: []string{}, ResolvedBlockers: []string{"ha/gcp/amd64"}},
+		{FeatureGate: "Promoted", Change: ChangeRemoved},
+		{FeatureGate: "Regressed", Change: ChangeNowBlocked, NewBlockers: []string{"hypershift/aws/amd64"}, ResolvedBlockers: []string{}},
+		{FeatureGate: "Shifting", Change: ChangeBlockersChanged, NewBlockers: []string{"ha/azure/amd64"}, ResolvedBlockers: []string{"ha/gcp/amd64"}},
+	}, trend.Changes)
+}
+
+func TestReleaseReportWriteMarkdown(t *testing.T) {
+	report := ReleaseReport{
+		Release: "4.22",
+		Date:    "2026-10-17",
+		Gates: []GateSummary{
+			{FeatureGate: "AWSDualStackInstall", FeatureSets: []string{"TechPreviewNoUpgrade"}, Sufficient: true, Blockers: []VariantBlocker{}},
+			{
+				FeatureGate: "DualReplica",
+				FeatureSets: []string{"DevPreviewNoUpgrade", "TechPreviewNoUpgrade"},
+				Blockers: []VariantBlocker{
+					{Variant: "ha/aws/amd64", JobTiers: JobTiersForVariant(JobVariant{})},
+					{Variant: "two-node-fencing/metal/amd64/ipv4", JobTiers: []string{"blocking", "candidate"}},
+				},
+				FailingCapabilityTests: 2,
+			},
+			{FeatureGate: "Broken", FeatureSets: []string{"TechPreviewNoUpgrade"}, Error: "querying annotation test results: timeout"},
+		},
+		Trend: &ReportTrend{
+			ComparedTo: "2026-10-10",
+			Changes: []GateChange{
+				{FeatureGate: "AWSDualStackInstall", Change: ChangeNowSufficient, ResolvedBlockers: []string{"ha/aws/amd64"}},
+			},
+		},
+	}
+
+	var sb strings.Builder
+	require.NoError(t, report.WriteMarkdown(&sb))
+	md := sb.String()
+	assert.Contains(t, md, "# Feature gate promotion readiness for 4.22")
+	assert.Contains(t, md, "Report of 2026-10-17: 3 unpromoted gates, 1 ready for promotion, 2 blocked.")
+	assert.Contains(t, md, "| AWSDualStackInstall | TechPreviewNoUpgrade | yes |  | 0 |")
+	assert.Contains(t, md, "| DualReplica | DevPreviewNoUpgrade, TechPreviewNoUpgrade | no | ha/aws/amd64, two-node-fencing/metal/amd64/ipv4 (blocking, candidate) | 2 |")
+	assert.Contains(t, md, "| Broken | TechPreviewNoUpgrade | no | error: querying annotation test results: timeout | 0 |")
+	assert.Contains(t, md, "## Changes since 2026-10-10")
+	assert.Contains(t, md, "| AWSDualStackInstall | now sufficient |  | ha/aws/amd64 |")
+}
@@ -24,6 +24,7 @@ type CapabilityTestRegression struct {
 // VariantResult represents the promotion readiness for a single variant combination.
 type VariantResult struct {
 	Variants    map[string]string `json:"variants"`
+	JobTiers    []string          `json:"job_tiers"`
 	Optional    bool              `json:"optional"`
 	Sufficient  bool              `json:"sufficient"`
 	TestResults []TestResult      `json:"test_results"`
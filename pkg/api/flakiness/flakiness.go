@@ -0,0 +1,331 @@
+// Package flakiness scores tests by how often they pass only on retry, to find chronically flaky
+// tests worth quarantining or fixing before they turn into regressions.
+package flakiness
+
+import (
+	"sort"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/db"
+)
+
+const (
+	DefaultWindowDays         = 14
+	DefaultMinRuns            = 20
+	DefaultMinFlakePercentage = 5.0
+	DefaultMinPeerRatio       = 3.0
+	DefaultLimit              = 100
+
+	// trendThreshold is the change in flake percentage between windows below which a test is stable.
+	trendThreshold = 2.0
+	// maxVariants limits the per variant breakdown of each test to its flakiest variants.
+	maxVariants = 10
+)
+
+const (
+	TrendIncreasing = "increasing"
+	TrendDecreasing = "decreasing"
+	TrendStable     = "stable"
+	// TrendUnknown is reported when the previous window has too few runs to compare against.
+	TrendUnknown = "unknown"
+)
+
+// excludedVariants are jobs whose results would skew flake rates: aggregated jobs repeat the
+// results of the jobs they aggregate, and never-stable jobs are not expected to pass.
+var excludedVariants = []string{"aggregated", "never-stable"}
+
+// Options select the tests to score and the thresholds for quarantine candidates.
+type Options struct {
+	Release string
+	// Variant limits the report to jobs with the variant, e.g. Platform:aws.
+	Variant string
+	// WindowDays is the length of the current window, which is compared to the window before it.
+	WindowDays int
+	// MinRuns is the fewest runs in the current window for a test to be scored.
+	MinRuns int
+	// MinFlakePercentage and MinPeerRatio are the flake rate, absolute and relative to the
+	// flake rate of all tests in the release, above which a test is a quarantine candidate.
+	MinFlakePercentage float64
+	MinPeerRatio       float64
+	// All lists every scored test rather than only quarantine candidates.
+	All   bool
+	Limit int
+}
+
+// WithDefaults fills in unset options.
+func (o Options) WithDefaults() Options {
+	if o.WindowDays <= 0 {
+		o.WindowDays = DefaultWindowDays
+	}
+	if o.MinRuns <= 0 {
+		o.MinRuns = DefaultMinRuns
+	}
+	if o.MinFlakePercentage <= 0 {
+		o.MinFlakePercentage = DefaultMinFlakePercentage
+	}
+	if o.MinPeerRatio <= 0 {
+		o.MinPeerRatio = DefaultMinPeerRatio
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultLimit
+	}
+	return o
+}
+
+// Report lists flaky tests, flakiest relative to their peers first.
+type Report struct {
+	Release string     `json:"release"`
+	Variant string     `json:"variant,omitempty"`
+	Start   civil.Date `json:"start"`
+	End     civil.Date `json:"end"`
+	// PeerFlakePercentage is the flake rate of all tests in the release over the window.
+	PeerFlakePercentage float64     `json:"peer_flake_percentage"`
+	Tests               []FlakyTest `json:"tests"`
+}
+
+type FlakyTest struct {
+	TestID        uint   `json:"test_id"`
+	TestName      string `json:"test_name"`
+	Component     string `json:"component,omitempty"`
+	JiraComponent string `json:"jira_component,omitempty"`
+
+	Runs            int     `json:"runs"`
+	Flakes          int     `json:"flakes"`
+	Failures        int     `json:"failures"`
+	FlakePercentage float64 `json:"flake_percentage"`
+
+	PreviousRuns            int     `json:"previous_runs"`
+	PreviousFlakes          int     `json:"previous_flakes"`
+	PreviousFlakePercentage float64 `json:"previous_flake_percentage"`
+	Trend                   string  `json:"trend"`
+
+	// PeerRatio is how many times more often the test flakes than tests in the release overall.
+	PeerRatio           float64            `json:"peer_ratio"`
+	QuarantineCandidate bool               `json:"quarantine_candidate"`
+	Variants            []VariantFlakiness `json:"variants,omitempty"`
+}
+
+// VariantFlakiness is the flake rate of a test in jobs with a variant.
+type VariantFlakiness struct {
+	Variant         string  `json:"variant"`
+	Runs            int     `json:"runs"`
+	Flakes          int     `json:"flakes"`
+	FlakePercentage float64 `json:"flake_percentage"`
+}
+
+// testCounts are the summed daily totals of a test in the current and previous windows.
+type testCounts struct {
+	TestID         uint
+	TestName       string
+	Runs           int
+	Flakes         int
+	Failures       int
+	PreviousRuns   int
+	PreviousFlakes int
+}
+
+// GetFlakyTests scores the tests of a release over the window ending at reportEnd.
+func GetFlakyTests(dbc *db.DB, opts Options, reportEnd time.Time) (Report, error) {
+	opts = opts.WithDefaults()
+	end := civil.DateOf(reportEnd.UTC())
+	start := end.AddDays(-opts.WindowDays + 1)
+	report := Report{Release: opts.Release, Variant: opts.Variant, Start: start, End: end, Tests: []FlakyTest{}}
+
+	var counts []testCounts
+	q := dbc.DB.Table("test_daily_totals tds").
+		Select(`tds.test_id, t.name AS test_name,
+			COALESCE(SUM(tds.runs) FILTER (WHERE tds.date >= ?), 0) AS runs,
+			COALESCE(SUM(tds.flakes) FILTER (WHERE tds.date >= ?), 0) AS flakes,
+			COALESCE(SUM(tds.failures) FILTER (WHERE tds.date >= ?), 0) AS failures,
+			COALESCE(SUM(tds.runs) FILTER (WHERE tds.date < ?), 0) AS previous_runs,
+			COALESCE(SUM(tds.flakes) FILTER (WHERE tds.date < ?), 0) AS previous_flakes`,
+			start, start, start, start, start).
+		Joins("JOIN tests t ON t.id = tds.test_id").
+		Joins("JOIN prow_jobs pj ON pj.id = tds.prow_job_id").
+		Where("tds.release = ?", opts.Release).
+		Where("tds.date >= ? AND tds.date <= ?", start.AddDays(-opts.WindowDays), end).
+		Where("NOT (COALESCE(pj.variants, '{}') && ?)", pq.Array(excludedVariants)).
+		Group("tds.test_id, t.name")
+	if opts.Variant != "" {
+		q = q.Where("? = ANY(pj.variants)", opts.Variant)
+	}
+	if res := q.Scan(&counts); res.Error != nil {
+		log.WithError(res.Error).Error("error querying test flakiness")
+		return report, res.Error
+	}
+
+	report.PeerFlakePercentage, report.Tests = scoreTests(counts, opts)
+	if len(report.Tests) == 0 {
+		return report, nil
+	}
+
+	if err := addOwnership(dbc, report.Tests); err != nil {
+		return report, err
+	}
+	if err := addVariants(dbc, report.Tests, opts, start, end); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// scoreTests compares the flake rate of each test with enough runs to that of all tests, returning
+// the peer flake percentage and the tests to report, flakiest relative to their peers first.
+func scoreTests(counts []testCounts, opts Options) (float64, []FlakyTest) {
+	var totalRuns, totalFlakes int
+	for _, c := range counts {
+		totalRuns += c.Runs
+		totalFlakes += c.Flakes
+	}
+	peerPercentage := percentage(totalFlakes, totalRuns)
+
+	tests := []FlakyTest{}
+	for _, c := range counts {
+		if c.Runs < opts.MinRuns {
+			continue
+		}
+		ft := FlakyTest{
+			TestID:                  c.TestID,
+			TestName:                c.TestName,
+			Runs:                    c.Runs,
+			Flakes:                  c.Flakes,
+			Failures:                c.Failures,
+			FlakePercentage:         percentage(c.Flakes, c.Runs),
+			PreviousRuns:            c.PreviousRuns,
+			PreviousFlakes:          c.PreviousFlakes,
+			PreviousFlakePercentage: percentage(c.PreviousFlakes, c.PreviousRuns),
+		}
+		ft.Trend = trend(ft, opts.MinRuns)
+		if peerPercentage > 0 { // otherwise nothing flaked
+			ft.PeerRatio = ft.FlakePercentage / peerPercentage
+		}
+		ft.QuarantineCandidate = ft.FlakePercentage >= opts.MinFlakePercentage && ft.PeerRatio >= opts.MinPeerRatio
+		if !ft.QuarantineCandidate && (!opts.All || ft.Flakes == 0) {
+			continue
+		}
+		tests = append(tests, ft)
+	}
+
+	sort.SliceStable(tests, func(i, j int) bool {
+		if tests[i].FlakePercentage != tests[j].FlakePercentage {
+			return tests[i].FlakePercentage > tests[j].FlakePercentage
+		}
+		return tests[i].Flakes > tests[j].Flakes
+	})
+	if len(tests) > opts.Limit {
+		tests = tests[:opts.Limit]
+	}
+	return peerPercentage, tests
+}
+
+func trend(ft FlakyTest, minRuns int) string {
+	if ft.PreviousRuns < minRuns {
+		return TrendUnknown
+	}
+	delta := ft.FlakePercentage - ft.PreviousFlakePercentage
+	switch {
+	case delta >= trendThreshold:
+		return TrendIncreasing
+	case delta <= -trendThreshold:
+		return TrendDecreasing
+	}
+	return TrendStable
+}
+
+// addOwnership sets the component and Jira component of each test from its highest priority owner.
+func addOwnership(dbc *db.DB, tests []FlakyTest) error {
+	var owners []struct {
+		TestID        uint
+		Component     string
+		JiraComponent string
+	}
+	res := dbc.DB.Raw(`SELECT DISTINCT ON (test_id) test_id, component, jira_component
+		FROM test_ownerships
+		WHERE test_id IN ? AND deleted_at IS NULL
+		ORDER BY test_id, priority DESC`, testIDs(tests)).Scan(&owners)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("error querying flaky test ownership")
+		return res.Error
+	}
+	byTest := make(map[uint]int, len(tests))
+	for i := range tests {
+		byTest[tests[i].TestID] = i
+	}
+	for _, o := range owners {
+		if i, ok := byTest[o.TestID]; ok {
+			tests[i].Component = o.Component
+			tests[i].JiraComponent = o.JiraComponent
+		}
+	}
+	return nil
+}
+
+// addVariants breaks down the current window of each test by the variants it flaked in.
+func addVariants(dbc *db.DB, tests []FlakyTest, opts Options, start, end civil.Date) error {
+	var rows []struct {
+		TestID  uint
+		Variant string
+		Runs    int
+		Flakes  int
+	}
+	q := dbc.DB.Table("test_daily_totals tds").
+		Select("tds.test_id, unnest(pj.variants) AS variant, SUM(tds.runs) AS runs, SUM(tds.flakes) AS flakes").
+		Joins("JOIN prow_jobs pj ON pj.id = tds.prow_job_id").
+		Where("tds.release = ?", opts.Release).
+		Where("tds.date >= ? AND tds.date <= ?", start, end).
+		Where("tds.test_id IN ?", testIDs(tests)).
+		Where("NOT (COALESCE(pj.variants, '{}') && ?)", pq.Array(excludedVariants)).
+		Group("tds.test_id, unnest(pj.variants)")
+	if opts.Variant != "" {
+		q = q.Where("? = ANY(pj.variants)", opts.Variant)
+	}
+	if res := q.Scan(&rows); res.Error != nil {
+		log.WithError(res.Error).Error("error querying flaky test variants")
+		return res.Error
+	}
+
+	byTest := map[uint][]VariantFlakiness{}
+	for _, r := range rows {
+		if r.Flakes == 0 || r.Variant == opts.Variant {
+			continue
+		}
+		byTest[r.TestID] = append(byTest[r.TestID], VariantFlakiness{
+			Variant:         r.Variant,
+			Runs:            r.Runs,
+			Flakes:          r.Flakes,
+			FlakePercentage: percentage(r.Flakes, r.Runs),
+		})
+	}
+	for i := range tests {
+		variants := byTest[tests[i].TestID]
+		sort.SliceStable(variants, func(a, b int) bool {
+			if variants[a].FlakePercentage != variants[b].FlakePercentage {
+				return variants[a].FlakePercentage > variants[b].FlakePercentage
+			}
+			return variants[a].Variant < variants[b].Variant
+		})
+		if len(variants) > maxVariants {
+			variants = variants[:maxVariants]
+		}
+		tests[i].Variants = variants
+	}
+	return nil
+}
+
+func testIDs(tests []FlakyTest) []uint {
+	ids := make([]uint, len(tests))
+	for i, t := range tests {
+		ids[i] = t.TestID
+	}
+	return ids
+}
+
+func percentage(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,54 @@
+package flakiness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreTests(t *testing.T) {
+	counts := []testCounts{
+		// 20% flakes, worse than before
+		{TestID: 1, TestName: "chronic", Runs: 100, Flakes: 20, PreviousRuns: 100, PreviousFlakes: 10},
+		// 6% flakes, but that is not far above its peers
+		{TestID: 2, TestName: "mild", Runs: 100, Flakes: 6, PreviousRuns: 100, PreviousFlakes: 6},
+		// flakes a lot, but has too few runs to judge
+		{TestID: 3, TestName: "rare", Runs: 5, Flakes: 5},
+		// new since the previous window
+		{TestID: 4, TestName: "new", Runs: 50, Flakes: 25},
+	}
+	for i := 0; i < 20; i++ {
+		counts = append(counts, testCounts{TestID: uint(100 + i), TestName: "stable", Runs: 100, PreviousRuns: 100})
+	}
+	opts := Options{Release: "4.20"}.WithDefaults()
+
+	peer, tests := scoreTests(counts, opts)
+	assert.InDelta(t, 56.0*100/2255, peer, 0.001)
+	require.Len(t, tests, 2)
+	assert.Equal(t, "new", tests[0].TestName)
+	assert.Equal(t, TrendUnknown, tests[0].Trend)
+	assert.Equal(t, "chronic", tests[1].TestName)
+	assert.Equal(t, TrendIncreasing, tests[1].Trend)
+	assert.InDelta(t, 20/peer, tests[1].PeerRatio, 0.001)
+	for _, ft := range tests {
+		assert.True(t, ft.QuarantineCandidate)
+	}
+
+	opts.All = true
+	_, tests = scoreTests(counts, opts)
+	require.Len(t, tests, 3, "all lists every test that flaked, except those with too few runs")
+	assert.Equal(t, "mild", tests[2].TestName)
+	assert.False(t, tests[2].QuarantineCandidate)
+	assert.Equal(t, TrendStable, tests[2].Trend)
+
+	opts.Limit = 1
+	_, tests = scoreTests(counts, opts)
+	assert.Len(t, tests, 1)
+}
+
+func TestScoreTestsWithoutFlakes(t *testing.T) {
+	peer, tests := scoreTests([]testCounts{{TestID: 1, Runs: 100}}, Options{All: true}.WithDefaults())
+	assert.Zero(t, peer)
+	assert.Empty(t, tests)
+}
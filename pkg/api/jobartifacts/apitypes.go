@@ -39,13 +39,19 @@ type JobRunArtifact struct {
 type MatchedContent struct {
 	// different matchers will fill in different content types
 	*ContentLineMatches `json:"line_matches,omitempty"`
+	JQMatches           *ContentStructuredMatches `json:"jq_matches,omitempty"`
+	XPathMatches        *ContentStructuredMatches `json:"xpath_matches,omitempty"`
 }
 
 // Matched returns true and text from the first match if there is any in the MatchedContent.
-// This could get more complicated with more matcher types, but for now we only have line matches.
 func (m MatchedContent) Matched() (string, bool) {
-	if m.ContentLineMatches != nil && len(m.Matches) > 0 {
-		return m.Matches[0].Match, true
+	if m.ContentLineMatches != nil && len(m.ContentLineMatches.Matches) > 0 {
+		return m.ContentLineMatches.Matches[0].Match, true
+	}
+	for _, structured := range []*ContentStructuredMatches{m.JQMatches, m.XPathMatches} {
+		if structured != nil && len(structured.Matches) > 0 {
+			return structured.Matches[0].Match, true
+		}
 	}
 	return "", false
 }
@@ -60,3 +66,16 @@ type ContentLineMatch struct {
 	Match  string   `json:"match"`
 	After  []string `json:"after,omitempty"`
 }
+
+// ContentStructuredMatches holds the results of a query (jq, xpath) against a structured artifact.
+type ContentStructuredMatches struct {
+	// NOTE: limited per maxFileMatches, sets Truncated if the query produced more results
+	Matches   []ContentStructuredMatch `json:"matches"`
+	Truncated bool                     `json:"truncated,omitempty"`
+}
+type ContentStructuredMatch struct {
+	// location of the result within the document, when the query language can express it (e.g. an XPath node path)
+	Path string `json:"path,omitempty"`
+	// the query result rendered as text: compact JSON for jq, the node text or attribute value for xpath
+	Match string `json:"match"`
+}
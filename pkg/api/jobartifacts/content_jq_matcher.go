@@ -0,0 +1,83 @@
+package jobartifacts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/itchyny/gojq"
+)
+
+// NewJQMatcher creates a ContentMatcher that runs a jq expression against JSON artifacts.
+// Every result of the expression that is not null or false counts as a match, so both
+// filters (`.items[] | select(.level == "Error")`) and predicates (`any(.items[]; .failed)`)
+// work as expected. Artifacts holding a stream of JSON values (e.g. JSON lines) are queried
+// value by value.
+func NewJQMatcher(expression string, maxMatches int) (ContentMatcher, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+	return &jqMatcher{
+		structuredMatcher: structuredMatcher{
+			cacheKey:       "jqMatcher: " + expression,
+			maxFileMatches: maxMatches,
+			field:          jqMatchesField,
+		},
+		code: code,
+	}, nil
+}
+
+type jqMatcher struct {
+	structuredMatcher
+	code *gojq.Code
+}
+
+// GetMatches parses the JSON values in the reader and returns the truthy results of the jq expression
+func (m *jqMatcher) GetMatches(reader *bufio.Reader) (MatchedContent, error) {
+	data, err := readStructuredArtifact(reader)
+	if err != nil {
+		return MatchedContent{}, err
+	}
+
+	matches := &ContentStructuredMatches{Matches: []ContentStructuredMatch{}}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return m.result(matches), fmt.Errorf("error parsing JSON artifact: %w", err)
+		}
+
+		iter := m.code.Run(value)
+		for {
+			result, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, isErr := result.(error); isErr {
+				return m.result(matches), fmt.Errorf("error evaluating jq expression: %w", err)
+			}
+			if result == nil || result == false {
+				continue
+			}
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return m.result(matches), fmt.Errorf("error encoding jq result: %w", err)
+			}
+			if !m.collect(matches, "", string(encoded)) {
+				return m.result(matches), nil
+			}
+		}
+	}
+	return m.result(matches), nil
+}
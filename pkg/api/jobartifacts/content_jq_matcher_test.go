@@ -0,0 +1,80 @@
+package jobartifacts
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timelineJSON = `{"items": [
+	{"level": "Info", "message": "cluster is fine"},
+	{"level": "Error", "message": "dns lookup timed out"},
+	{"level": "Error", "message": "etcd leader changed"}
+]}`
+
+func TestJQMatcherFilter(t *testing.T) {
+	matcher, err := NewJQMatcher(`.items[] | select(.level == "Error") | .message`, maxFileMatches)
+	require.NoError(t, err)
+	matches, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(timelineJSON)))
+	require.NoError(t, err)
+	require.NotNil(t, matches.JQMatches)
+	assert.Len(t, matches.JQMatches.Matches, 2)
+	assert.Equal(t, `"dns lookup timed out"`, matches.JQMatches.Matches[0].Match)
+
+	text, ok := matches.Matched()
+	assert.True(t, ok)
+	assert.Equal(t, `"dns lookup timed out"`, text)
+}
+
+func TestJQMatcherPredicate(t *testing.T) {
+	matcher, err := NewJQMatcher(`any(.items[]; .message | test("dns"))`, maxFileMatches)
+	require.NoError(t, err)
+	matches, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(timelineJSON)))
+	require.NoError(t, err)
+	text, ok := matches.Matched()
+	assert.True(t, ok)
+	assert.Equal(t, "true", text)
+
+	matcher, err = NewJQMatcher(`any(.items[]; .level == "Fatal")`, maxFileMatches)
+	require.NoError(t, err)
+	matches, err = matcher.GetMatches(bufio.NewReader(strings.NewReader(timelineJSON)))
+	require.NoError(t, err)
+	assert.Nil(t, matches.JQMatches, "a false result is not a match")
+}
+
+func TestJQMatcherJSONLines(t *testing.T) {
+	matcher, err := NewJQMatcher(`select(.failed) | .name`, maxFileMatches)
+	require.NoError(t, err)
+	content := "{\"name\": \"a\", \"failed\": true}\n{\"name\": \"b\", \"failed\": false}\n{\"name\": \"c\", \"failed\": true}\n"
+	matches, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(content)))
+	require.NoError(t, err)
+	require.NotNil(t, matches.JQMatches)
+	assert.Equal(t, []ContentStructuredMatch{{Match: `"a"`}, {Match: `"c"`}}, matches.JQMatches.Matches)
+}
+
+func TestJQMatcherErrors(t *testing.T) {
+	_, err := NewJQMatcher(`.items[`, maxFileMatches)
+	assert.Error(t, err)
+
+	matcher, err := NewJQMatcher(`.items[]`, maxFileMatches)
+	require.NoError(t, err)
+	_, err = matcher.GetMatches(bufio.NewReader(strings.NewReader("not json")))
+	assert.Error(t, err)
+}
+
+func TestJQMatcherPostProcess(t *testing.T) {
+	matcher, err := NewJQMatcher(`.[]`, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "jqMatcher: .[]", matcher.GetCacheKey())
+
+	content, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(`[1, 2, 3]`)))
+	require.NoError(t, err)
+	artifact := matcher.PostProcessMatch(JobRunArtifact{MatchedContent: content})
+	require.NotNil(t, artifact.JQMatches)
+	assert.Len(t, artifact.JQMatches.Matches, 1)
+	assert.True(t, artifact.JQMatches.Truncated)
+	assert.Len(t, content.JQMatches.Matches, 3, "post-processing should not modify the cached matches")
+}
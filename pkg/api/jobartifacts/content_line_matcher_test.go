@@ -84,7 +84,7 @@ func TestPostProcessMatch(t *testing.T) {
 	}
 
 	fullArtifact := JobRunArtifact{
-		MatchedContent: MatchedContent{&ContentLineMatches{
+		MatchedContent: MatchedContent{ContentLineMatches: &ContentLineMatches{
 			Matches: []ContentLineMatch{
 				{
 					Before: []string{"line before 1\n", "line before 2\n"},
@@ -0,0 +1,79 @@
+package jobartifacts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// structured artifacts (json, xml) have to be parsed whole, so limit how much we are willing to read
+const maxStructuredArtifactBytes = 64 << 20
+
+// structuredMatcher holds the behavior shared by matchers that query a whole parsed document
+// rather than scanning it line by line.
+type structuredMatcher struct {
+	cacheKey       string
+	maxFileMatches int
+	// which MatchedContent field this matcher fills in
+	field func(*MatchedContent) **ContentStructuredMatches
+}
+
+func (m *structuredMatcher) GetContentTemplate() interface{} {
+	return ContentStructuredMatches{}
+}
+
+func (m *structuredMatcher) GetCacheKey() string {
+	return m.cacheKey // ignore match limits for purposes of caching - cache everything we could need
+}
+
+func (m *structuredMatcher) PostProcessMatch(fullArtifact JobRunArtifact) JobRunArtifact {
+	artifact := fullArtifact
+	full := *m.field(&fullArtifact.MatchedContent)
+	if full == nil {
+		return artifact // no matches to process
+	}
+	trimmed := &ContentStructuredMatches{
+		Matches:   full.Matches,
+		Truncated: full.Truncated,
+	}
+	if len(trimmed.Matches) > m.maxFileMatches {
+		trimmed.Matches = trimmed.Matches[:m.maxFileMatches]
+		trimmed.Truncated = true
+	}
+	*m.field(&artifact.MatchedContent) = trimmed
+	return artifact
+}
+
+// collect records a query result, returning false once no more results should be recorded.
+func (m *structuredMatcher) collect(matches *ContentStructuredMatches, path, match string) bool {
+	if len(matches.Matches) >= maxFileMatches {
+		matches.Truncated = true
+		return false
+	}
+	matches.Matches = append(matches.Matches, ContentStructuredMatch{Path: path, Match: match})
+	return true
+}
+
+// result wraps the collected matches in a MatchedContent; no matches means no content.
+func (m *structuredMatcher) result(matches *ContentStructuredMatches) MatchedContent {
+	content := MatchedContent{}
+	if len(matches.Matches) > 0 {
+		*m.field(&content) = matches
+	}
+	return content
+}
+
+// readStructuredArtifact reads a whole artifact for parsing, refusing artifacts that are too large.
+func readStructuredArtifact(reader *bufio.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxStructuredArtifactBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxStructuredArtifactBytes {
+		return nil, fmt.Errorf("artifact exceeds the %d byte limit for structured matching", maxStructuredArtifactBytes)
+	}
+	return data, nil
+}
+
+func jqMatchesField(m *MatchedContent) **ContentStructuredMatches    { return &m.JQMatches }
+func xpathMatchesField(m *MatchedContent) **ContentStructuredMatches { return &m.XPathMatches }
@@ -0,0 +1,102 @@
+package jobartifacts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// NewXPathMatcher creates a ContentMatcher that evaluates an XPath expression against XML artifacts
+// such as junit results. Node-set results match once per selected node (recording its path and text);
+// boolean, number and string results match when true, non-zero or non-empty respectively.
+func NewXPathMatcher(expression string, maxMatches int) (ContentMatcher, error) {
+	expr, err := xpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid xpath expression: %w", err)
+	}
+	return &xpathMatcher{
+		structuredMatcher: structuredMatcher{
+			cacheKey:       "xpathMatcher: " + expression,
+			maxFileMatches: maxMatches,
+			field:          xpathMatchesField,
+		},
+		expr: expr,
+	}, nil
+}
+
+type xpathMatcher struct {
+	structuredMatcher
+	expr *xpath.Expr
+}
+
+// GetMatches parses the XML document in the reader and returns the results of the xpath expression
+func (m *xpathMatcher) GetMatches(reader *bufio.Reader) (MatchedContent, error) {
+	data, err := readStructuredArtifact(reader)
+	if err != nil {
+		return MatchedContent{}, err
+	}
+	root, err := xmlquery.ParseWithOptions(bytes.NewReader(data), xmlquery.ParserOptions{
+		Decoder: &xmlquery.DecoderOptions{Strict: false},
+	})
+	if err != nil {
+		return MatchedContent{}, fmt.Errorf("error parsing XML artifact: %w", err)
+	}
+
+	matches := &ContentStructuredMatches{Matches: []ContentStructuredMatch{}}
+	switch result := m.expr.Evaluate(xmlquery.CreateXPathNavigator(root)).(type) {
+	case *xpath.NodeIterator:
+		for result.MoveNext() {
+			nav := result.Current().(*xmlquery.NodeNavigator)
+			if !m.collect(matches, xpathPath(nav), nav.Value()) {
+				break
+			}
+		}
+	case bool:
+		if result {
+			m.collect(matches, "", "true")
+		}
+	case float64:
+		if result != 0 {
+			m.collect(matches, "", strconv.FormatFloat(result, 'f', -1, 64))
+		}
+	case string:
+		if result != "" {
+			m.collect(matches, "", result)
+		}
+	}
+	return m.result(matches), nil
+}
+
+// xpathPath renders a simple location path for the node the navigator is on, e.g. /testsuite[1]/testcase[3]/@name
+func xpathPath(nav *xmlquery.NodeNavigator) string {
+	var segments []string
+	for n := nav.Current(); n != nil && n.Parent != nil; n = n.Parent {
+		switch n.Type {
+		case xmlquery.ElementNode:
+			position := 1
+			for sibling := n.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+				if sibling.Type == xmlquery.ElementNode && sibling.Data == n.Data && sibling.Prefix == n.Prefix {
+					position++
+				}
+			}
+			segments = append(segments, fmt.Sprintf("%s[%d]", n.Data, position))
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			segments = append(segments, "text()")
+		case xmlquery.CommentNode:
+			segments = append(segments, "comment()")
+		}
+	}
+	var sb strings.Builder
+	for i := len(segments) - 1; i >= 0; i-- {
+		sb.WriteString("/" + segments[i])
+	}
+	if nav.NodeType() == xpath.AttributeNode {
+		sb.WriteString("/@" + nav.LocalName())
+	}
+	return sb.String()
+}
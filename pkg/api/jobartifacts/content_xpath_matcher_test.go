@@ -0,0 +1,74 @@
+package jobartifacts
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const junitXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="openshift-tests" tests="3" failures="1">
+	<testcase name="[sig-network] DNS should resolve" time="12"/>
+	<testcase name="[sig-etcd] leader should not change" time="3">
+		<failure message="leader changed">etcd leader changed 4 times</failure>
+	</testcase>
+	<testcase name="[sig-api] API is available" time="1"/>
+</testsuite>`
+
+func TestXPathMatcherNodes(t *testing.T) {
+	matcher, err := NewXPathMatcher(`//testcase[failure]/@name`, maxFileMatches)
+	require.NoError(t, err)
+	matches, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(junitXML)))
+	require.NoError(t, err)
+	require.NotNil(t, matches.XPathMatches)
+	assert.Equal(t, []ContentStructuredMatch{{
+		Path:  "/testsuite[1]/testcase[2]/@name",
+		Match: "[sig-etcd] leader should not change",
+	}}, matches.XPathMatches.Matches)
+
+	matcher, err = NewXPathMatcher(`//failure`, maxFileMatches)
+	require.NoError(t, err)
+	matches, err = matcher.GetMatches(bufio.NewReader(strings.NewReader(junitXML)))
+	require.NoError(t, err)
+	text, ok := matches.Matched()
+	assert.True(t, ok)
+	assert.Equal(t, "etcd leader changed 4 times", text)
+}
+
+func TestXPathMatcherScalars(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		matched    bool
+	}{
+		{expression: `count(//testcase) > 2`, want: "true", matched: true},
+		{expression: `count(//testcase) > 5`, matched: false},
+		{expression: `count(//failure)`, want: "1", matched: true},
+		{expression: `string(/testsuite/@name)`, want: "openshift-tests", matched: true},
+		{expression: `//testcase[@name="missing"]`, matched: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			matcher, err := NewXPathMatcher(tt.expression, maxFileMatches)
+			require.NoError(t, err)
+			matches, err := matcher.GetMatches(bufio.NewReader(strings.NewReader(junitXML)))
+			require.NoError(t, err)
+			text, ok := matches.Matched()
+			assert.Equal(t, tt.matched, ok)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
+
+func TestXPathMatcherErrors(t *testing.T) {
+	_, err := NewXPathMatcher(`//testcase[`, maxFileMatches)
+	assert.Error(t, err)
+
+	matcher, err := NewXPathMatcher(`//testcase`, maxFileMatches)
+	require.NoError(t, err)
+	_, err = matcher.GetMatches(bufio.NewReader(strings.NewReader(`<testsuite name="unterminated`)))
+	assert.Error(t, err)
+}
@@ -30,8 +30,7 @@ type JobArtifactQuery struct {
 	cache.Cache
 	JobRunIDs      []int64
 	PathGlob       string // A simple glob to match files in the artifact bucket for each queried run
-	ContentMatcher        // An interface to match in the content of the files
-	// TODO: jq, xpath support for matching content
+	ContentMatcher        // An interface to match in the content of the files (line, jq or xpath matchers)
 }
 
 func (q *JobArtifactQuery) queryJobArtifacts(ctx context.Context, jobRunID int64, mgr *Manager, logger *log.Entry) (JobRun, error) {
@@ -175,7 +175,7 @@ func TestJobRunCaching(t *testing.T) {
 		Artifacts: []JobRunArtifact{
 			{
 				MatchedContent: MatchedContent{
-					&ContentLineMatches{
+					ContentLineMatches: &ContentLineMatches{
 						Matches: []ContentLineMatch{{Match: "stuff"}},
 					},
 				},
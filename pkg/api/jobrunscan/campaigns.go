@@ -0,0 +1,154 @@
+package jobrunscan
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	sippyapi "github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// campaignNameRegexp matches the release and build cluster names a campaign can be limited to
+var campaignNameRegexp = regexp.MustCompile(`^[\w.-]+$`)
+
+// validateCampaign ensures the AnnotationCampaign record coming into the API appears valid.
+// Variant names and values are checked against BigQuery when the campaign runs.
+func validateCampaign(dbc *gorm.DB, campaign jobrunscan.AnnotationCampaign) error {
+	if campaign.ID != 0 {
+		return fmt.Errorf("cannot specify an id for a new annotation campaign, one will be autogenerated")
+	}
+	if campaign.Name == "" {
+		return fmt.Errorf("name is required for an annotation campaign")
+	}
+	if campaign.StartTime.IsZero() {
+		return fmt.Errorf("start_time is required for an annotation campaign")
+	}
+	if campaign.EndTime != nil && !campaign.EndTime.After(campaign.StartTime) {
+		return fmt.Errorf("end_time must be after start_time")
+	}
+	if campaign.Release != "" && !campaignNameRegexp.MatchString(campaign.Release) {
+		return fmt.Errorf("release %q is not a valid release name", campaign.Release)
+	}
+	for _, cluster := range campaign.BuildClusters {
+		if !campaignNameRegexp.MatchString(cluster) {
+			return fmt.Errorf("build cluster %q is not a valid cluster name", cluster)
+		}
+	}
+	for _, variant := range campaign.Variants {
+		if parts := strings.Split(variant, ":"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("variant %s is not in key:value format", variant)
+		}
+	}
+	if campaign.PathGlob != "" && campaign.TextContains == "" && campaign.TextRegex == "" {
+		return fmt.Errorf("text_contains or text_regex must be provided with path_glob")
+	}
+	if campaign.TextRegex != "" {
+		if _, err := regexp.Compile(campaign.TextRegex); err != nil {
+			return fmt.Errorf("invalid text_regex: %v", err)
+		}
+	}
+
+	// Validate that the label exists
+	var count int64
+	res := dbc.Model(&jobrunscan.Label{}).Where("id = ?", campaign.Label).Count(&count)
+	if res.Error != nil {
+		return fmt.Errorf("error validating label: %v", res.Error)
+	}
+	if count == 0 {
+		return fmt.Errorf("label %q does not exist in the database", campaign.Label)
+	}
+	return nil
+}
+
+// GetCampaign retrieves a single annotation campaign, with its progress, by ID
+func GetCampaign(dbc *db.DB, id int, req *http.Request) (*jobrunscan.AnnotationCampaign, error) {
+	var campaign jobrunscan.AnnotationCampaign
+	res := dbc.DB.First(&campaign, id)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.WithError(res.Error).Errorf("error looking up annotation campaign: %d", id)
+		return nil, res.Error
+	}
+	injectCampaignHATEOASLinks(&campaign, sippyapi.GetBaseURL(req))
+	return &campaign, nil
+}
+
+// ListCampaigns retrieves all annotation campaigns, with their progress
+func ListCampaigns(dbc *db.DB, req *http.Request) ([]jobrunscan.AnnotationCampaign, error) {
+	var campaigns []jobrunscan.AnnotationCampaign
+	res := dbc.DB.Order("id").Find(&campaigns)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("error listing annotation campaigns")
+		return nil, res.Error
+	}
+	for i := range campaigns {
+		injectCampaignHATEOASLinks(&campaigns[i], sippyapi.GetBaseURL(req))
+	}
+	return campaigns, nil
+}
+
+// CreateCampaign creates a new annotation campaign, which the daemon starts running unless it is paused.
+func CreateCampaign(dbc *gorm.DB, campaign jobrunscan.AnnotationCampaign, user string, req *http.Request) (jobrunscan.AnnotationCampaign, error) {
+	if err := validateCampaign(dbc, campaign); err != nil {
+		log.WithError(err).Error("error validating annotation campaign")
+		return campaign, err
+	}
+
+	// Progress starts from scratch, whatever was submitted
+	campaign.AnnotationCampaignProgress = jobrunscan.AnnotationCampaignProgress{ScannedThrough: campaign.StartTime}
+
+	// Set user tracking fields
+	campaign.CreatedBy = user
+	campaign.UpdatedBy = user
+
+	res := dbc.Create(&campaign)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("error creating annotation campaign")
+		return campaign, res.Error
+	}
+	log.WithField("campaignID", campaign.ID).Infof("annotation campaign created by user: %s", user)
+	injectCampaignHATEOASLinks(&campaign, sippyapi.GetBaseURL(req))
+	return campaign, nil
+}
+
+// SetCampaignPaused pauses or resumes an annotation campaign. It returns nil if there is no such campaign.
+func SetCampaignPaused(dbc *gorm.DB, id int, paused bool, user string, req *http.Request) (*jobrunscan.AnnotationCampaign, error) {
+	res := dbc.Model(&jobrunscan.AnnotationCampaign{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"paused": paused, "updated_by": user})
+	if res.Error != nil {
+		log.WithError(res.Error).Errorf("error updating annotation campaign: %d", id)
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	log.WithFields(log.Fields{"campaignID": id, "paused": paused}).Infof("annotation campaign updated by user: %s", user)
+
+	var campaign jobrunscan.AnnotationCampaign
+	if err := dbc.First(&campaign, id).Error; err != nil {
+		return nil, err
+	}
+	injectCampaignHATEOASLinks(&campaign, sippyapi.GetBaseURL(req))
+	return &campaign, nil
+}
+
+const (
+	campaignLink       = "%s/api/jobs/annotation_campaigns/%d"
+	campaignPausedLink = "%s/api/jobs/annotation_campaigns/%d/paused"
+)
+
+// injectCampaignHATEOASLinks adds restful links clients can follow for this campaign record.
+func injectCampaignHATEOASLinks(campaign *jobrunscan.AnnotationCampaign, baseURL string) {
+	campaign.Links = map[string]string{
+		"self":   fmt.Sprintf(campaignLink, baseURL, campaign.ID),
+		"paused": fmt.Sprintf(campaignPausedLink, baseURL, campaign.ID),
+	}
+}
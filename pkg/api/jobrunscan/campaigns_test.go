@@ -0,0 +1,22 @@
+package jobrunscan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+)
+
+func TestValidateCampaignRejectsUnsafeNames(t *testing.T) {
+	campaign := jobrunscan.AnnotationCampaign{Name: "etcd leader changes"}
+	campaign.StartTime = time.Now()
+	campaign.Release = "4.20' OR '1'='1"
+	assert.ErrorContains(t, validateCampaign(nil, campaign), "not a valid release name")
+
+	campaign.Release = "4.20"
+	campaign.BuildClusters = pq.StringArray{"build01", "build02' OR TRUE --"}
+	assert.ErrorContains(t, validateCampaign(nil, campaign), "not a valid cluster name")
+}
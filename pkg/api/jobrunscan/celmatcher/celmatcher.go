@@ -0,0 +1,294 @@
+// Package celmatcher evaluates compound symptom expressions against the labels
+// already applied to a job run.
+//
+// Compound symptoms use the boolean subset of the Common Expression Language:
+// label IDs are identifiers that evaluate to true when the label is applied, and
+// they can be combined with `!`, `&&`, `||`, parentheses and the literals `true`
+// and `false`, e.g. "DNSTimeout && !OperatorError".
+package celmatcher
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+)
+
+// Expression is a compiled compound symptom expression.
+type Expression struct {
+	source string
+	root   node
+	idents sets.Set[string]
+}
+
+// Compile parses a compound symptom expression.
+func Compile(expr string) (*Expression, error) {
+	p := &parser{src: expr}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	e := &Expression{source: expr, root: root, idents: sets.New[string]()}
+	root.collect(e.idents)
+	return e, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// LabelIDs returns the sorted label IDs referenced by the expression.
+func (e *Expression) LabelIDs() []string {
+	return sets.List(e.idents)
+}
+
+// Evaluate reports whether the expression holds for the given applied labels.
+func (e *Expression) Evaluate(applied sets.Set[string]) bool {
+	return e.root.eval(applied)
+}
+
+// CompoundSymptom pairs a CEL symptom with its compiled expression.
+type CompoundSymptom struct {
+	Symptom    jobrunscan.Symptom
+	Expression *Expression
+}
+
+// CompileSymptoms compiles the expressions of all CEL symptoms in the list, ignoring other
+// matcher types. Symptoms whose expressions do not compile are returned as errors keyed by
+// symptom ID so callers can decide whether to log or fail.
+func CompileSymptoms(symptoms []jobrunscan.Symptom) ([]CompoundSymptom, map[string]error) {
+	var compiled []CompoundSymptom
+	errs := map[string]error{}
+	for _, s := range symptoms {
+		if s.MatcherType != jobrunscan.MatcherTypeCEL {
+			continue
+		}
+		expr, err := Compile(s.MatchString)
+		if err != nil {
+			errs[s.ID] = fmt.Errorf("invalid cel expression for symptom %q: %w", s.ID, err)
+			continue
+		}
+		compiled = append(compiled, CompoundSymptom{Symptom: s, Expression: expr})
+	}
+	return compiled, errs
+}
+
+// MatchSymptoms evaluates compound symptoms against the labels applied to a job run and
+// returns those that match, in input order. Labels produced by a matching compound symptom
+// are visible to the others, so evaluation repeats until no new symptom matches.
+func MatchSymptoms(symptoms []CompoundSymptom, appliedLabels []string) []CompoundSymptom {
+	applied := sets.New[string](appliedLabels...)
+	matched := make([]bool, len(symptoms))
+	for changed := true; changed; {
+		changed = false
+		for i, s := range symptoms {
+			if matched[i] || !s.Expression.Evaluate(applied) {
+				continue
+			}
+			matched[i] = true
+			changed = true
+			applied.Insert(s.Symptom.LabelIDs...)
+		}
+	}
+
+	var result []CompoundSymptom
+	for i, s := range symptoms {
+		if matched[i] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+type node interface {
+	eval(applied sets.Set[string]) bool
+	collect(idents sets.Set[string])
+}
+
+type identNode string
+
+func (n identNode) eval(applied sets.Set[string]) bool { return applied.Has(string(n)) }
+func (n identNode) collect(idents sets.Set[string])    { idents.Insert(string(n)) }
+
+type literalNode bool
+
+func (n literalNode) eval(sets.Set[string]) bool { return bool(n) }
+func (n literalNode) collect(sets.Set[string])   {}
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(applied sets.Set[string]) bool { return !n.operand.eval(applied) }
+func (n notNode) collect(idents sets.Set[string])    { n.operand.collect(idents) }
+
+type binaryNode struct {
+	and         bool
+	left, right node
+}
+
+func (n binaryNode) eval(applied sets.Set[string]) bool {
+	if n.and {
+		return n.left.eval(applied) && n.right.eval(applied)
+	}
+	return n.left.eval(applied) || n.right.eval(applied)
+}
+
+func (n binaryNode) collect(idents sets.Set[string]) {
+	n.left.collect(idents)
+	n.right.collect(idents)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	next   int
+}
+
+func (p *parser) tokenize() error {
+	runes := []rune(p.src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			p.tokens = append(p.tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			p.tokens = append(p.tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == '!':
+			p.tokens = append(p.tokens, token{kind: tokNot, text: "!", pos: i})
+			i++
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return fmt.Errorf("unexpected %q at position %d (did you mean %q?)", string(r), i, strings.Repeat(string(r), 2))
+			}
+			kind := tokAnd
+			if r == '|' {
+				kind = tokOr
+			}
+			p.tokens = append(p.tokens, token{kind: kind, text: string(runes[i : i+2]), pos: i})
+			i += 2
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			p.tokens = append(p.tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+		default:
+			return fmt.Errorf("unsupported character %q at position %d", string(r), i)
+		}
+	}
+	return nil
+}
+
+func (p *parser) peek() token {
+	if p.next >= len(p.tokens) {
+		return token{kind: tokEOF, text: "end of expression", pos: len(p.src)}
+	}
+	return p.tokens[p.next]
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tokNot {
+		p.next++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokIdent:
+		p.next++
+		switch tok.text {
+		case "true":
+			return literalNode(true), nil
+		case "false":
+			return literalNode(false), nil
+		}
+		return identNode(tok.text), nil
+	case tokLParen:
+		p.next++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokRParen {
+			return nil, fmt.Errorf("expected \")\" at position %d, found %q", closing.pos, closing.text)
+		}
+		p.next++
+		return inner, nil
+	default:
+		return nil, fmt.Errorf("expected label identifier at position %d, found %q", tok.pos, tok.text)
+	}
+}
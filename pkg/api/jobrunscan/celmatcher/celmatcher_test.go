@@ -0,0 +1,82 @@
+package celmatcher
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+)
+
+func TestCompileAndEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		applied []string
+		want    bool
+		labels  []string
+	}{
+		{name: "single label applied", expr: "DNSTimeout", applied: []string{"DNSTimeout"}, want: true, labels: []string{"DNSTimeout"}},
+		{name: "single label missing", expr: "DNSTimeout", applied: []string{"Other"}, want: false, labels: []string{"DNSTimeout"}},
+		{name: "and not", expr: "DNSTimeout && !OperatorError", applied: []string{"DNSTimeout"}, want: true, labels: []string{"DNSTimeout", "OperatorError"}},
+		{name: "and not suppressed", expr: "DNSTimeout && !OperatorError", applied: []string{"DNSTimeout", "OperatorError"}, want: false, labels: []string{"DNSTimeout", "OperatorError"}},
+		{name: "or", expr: "A || B", applied: []string{"B"}, want: true, labels: []string{"A", "B"}},
+		{name: "and binds tighter than or", expr: "A || B && C", applied: []string{"A"}, want: true, labels: []string{"A", "B", "C"}},
+		{name: "parentheses", expr: "(A || B) && C", applied: []string{"A"}, want: false, labels: []string{"A", "B", "C"}},
+		{name: "double negation", expr: "!!A", applied: []string{"A"}, want: true, labels: []string{"A"}},
+		{name: "literals", expr: "true && !false && A_1", applied: []string{"A_1"}, want: true, labels: []string{"A_1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Compile(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Evaluate(sets.New(tt.applied...)))
+			assert.Equal(t, tt.labels, expr.LabelIDs())
+			assert.Equal(t, tt.expr, expr.String())
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"   ",
+		"A &&",
+		"A & B",
+		"A | B",
+		"(A || B",
+		"A B",
+		"A == B",
+		"1A",
+		")",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Compile(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMatchSymptoms(t *testing.T) {
+	symptoms := []jobrunscan.Symptom{
+		{SymptomContent: jobrunscan.SymptomContent{ID: "chained", MatcherType: jobrunscan.MatcherTypeCEL, MatchString: "ClusterDNSFlake && Upgrade", LabelIDs: pq.StringArray{"UpgradeDNSFlake"}}},
+		{SymptomContent: jobrunscan.SymptomContent{ID: "dns", MatcherType: jobrunscan.MatcherTypeCEL, MatchString: "DNSTimeout && !OperatorError", LabelIDs: pq.StringArray{"ClusterDNSFlake"}}},
+		{SymptomContent: jobrunscan.SymptomContent{ID: "simple", MatcherType: jobrunscan.MatcherTypeString, MatchString: "ignored"}},
+		{SymptomContent: jobrunscan.SymptomContent{ID: "invalid", MatcherType: jobrunscan.MatcherTypeCEL, MatchString: "&&"}},
+	}
+
+	compiled, errs := CompileSymptoms(symptoms)
+	require.Len(t, compiled, 2)
+	assert.Contains(t, errs, "invalid")
+
+	matched := MatchSymptoms(compiled, []string{"DNSTimeout", "Upgrade"})
+	require.Len(t, matched, 2, "labels from a matched compound symptom should satisfy later evaluations")
+	assert.Equal(t, "chained", matched[0].Symptom.ID)
+	assert.Equal(t, "dns", matched[1].Symptom.ID)
+
+	assert.Empty(t, MatchSymptoms(compiled, []string{"DNSTimeout", "OperatorError", "Upgrade"}))
+}
@@ -17,6 +17,7 @@ import (
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/storage"
 	"github.com/openshift/sippy/pkg/api/jobartifacts"
+	"github.com/openshift/sippy/pkg/api/jobrunscan/celmatcher"
 	"github.com/openshift/sippy/pkg/apis/cache"
 	bqclient "github.com/openshift/sippy/pkg/bigquery"
 	"github.com/openshift/sippy/pkg/bigquery/bqlabel"
@@ -61,6 +62,12 @@ type ReEvaluationResult struct {
 	Links               map[string]string `json:"links,omitempty"`
 }
 
+// ReEvaluationRequest is the body of a symptom re-evaluation request.
+type ReEvaluationRequest struct {
+	ProwJobBuildIDs []string `json:"prow_job_build_ids"`
+	DryRun          bool     `json:"dry_run"`
+}
+
 // ReEvaluationResponse wraps the results with HATEOAS links.
 type ReEvaluationResponse struct {
 	Results []ReEvaluationResult `json:"results"`
@@ -146,7 +153,7 @@ func (r *ReEvaluator) loadActiveSymptoms() ([]jobrunscan.Symptom, error) {
 	return filterRelevantSymptoms(all), nil
 }
 
-// filterRelevantSymptoms returns only symptoms with labels and implemented matcher types (string, regex, none).
+// filterRelevantSymptoms returns only symptoms with labels and implemented matcher types (string, regex, jq, xpath, none, cel).
 func filterRelevantSymptoms(symptoms []jobrunscan.Symptom) []jobrunscan.Symptom {
 	var filtered []jobrunscan.Symptom
 	for _, s := range symptoms {
@@ -154,10 +161,9 @@ func filterRelevantSymptoms(symptoms []jobrunscan.Symptom) []jobrunscan.Symptom
 			continue // no value in matching symptoms that don't apply labels
 		}
 		switch s.MatcherType {
-		case jobrunscan.MatcherTypeString, jobrunscan.MatcherTypeRegex, jobrunscan.MatcherTypeFile:
+		case jobrunscan.MatcherTypeString, jobrunscan.MatcherTypeRegex, jobrunscan.MatcherTypeJQ, jobrunscan.MatcherTypeXPath,
+			jobrunscan.MatcherTypeFile, jobrunscan.MatcherTypeCEL:
 			filtered = append(filtered, s)
-		case jobrunscan.MatcherTypeCEL:
-			log.WithFields(log.Fields{"symptom": s.ID, "matcherType": s.MatcherType}).Warn("symptom reEval: skipping symptom with unimplemented matcher_type (TRT-2466)")
 		default:
 			log.WithFields(log.Fields{"symptom": s.ID, "matcherType": s.MatcherType}).Warn("symptom reEval: skipping symptom with unknown matcher_type")
 		}
@@ -206,6 +212,11 @@ func (r *ReEvaluator) reEvaluateOne(ctx context.Context, buildID string, symptom
 		result.Error = err.Error()
 		return result
 	}
+
+	// Compound (CEL) symptoms are evaluated in a second pass against the labels
+	// applied by the first pass plus the manually applied labels.
+	manualLabels := r.manualLabels(ctx, buildID, jobRunModel)
+	matches = append(matches, evaluateCompoundSymptoms(symptoms, manualLabels, matches)...)
 	result.SymptomsMatched = uniqueSymptomsMatched(matches)
 
 	result.Links = map[string]string{"job_run": jobRunModel.URL}
@@ -244,7 +255,7 @@ func (r *ReEvaluator) reEvaluateOne(ctx context.Context, buildID string, symptom
 	result.GCSArtifactsWritten = len(bucketLabels)
 
 	// Update PostgreSQL labels
-	if err := r.updatePostgresLabels(ctx, buildID, jobRunModel, bqLabels); err != nil {
+	if err := r.updatePostgresLabels(ctx, buildID, jobRunModel, manualLabels, bqLabels); err != nil {
 		result.Status = ReEvalRewriteError
 		result.Error = fmt.Sprintf("postgres update failed: %v", err)
 		return result
@@ -255,12 +266,15 @@ func (r *ReEvaluator) reEvaluateOne(ctx context.Context, buildID string, symptom
 	return result
 }
 
-// evaluateSymptoms runs one artifact query per symptom for the given job run.
+// evaluateSymptoms runs one artifact query per simple (non-CEL) symptom for the given job run.
 func (r *ReEvaluator) evaluateSymptoms(ctx context.Context, jobRunID int64, symptoms []jobrunscan.Symptom) ([]symptomMatch, error) {
 	var matches []symptomMatch
 	mgr := r.artifactMgr
 
 	for _, symptom := range symptoms {
+		if symptom.MatcherType == jobrunscan.MatcherTypeCEL {
+			continue // evaluated by evaluateCompoundSymptoms once the simple matches are known
+		}
 		contentMatcher, err := ContentMatcherForSymptom(symptom.SymptomContent)
 		if err != nil {
 			log.WithError(err).WithField("symptom", symptom.ID).Warn("symptom reEval: skipping symptom due to matcher error")
@@ -312,8 +326,35 @@ func (r *ReEvaluator) evaluateSymptoms(ctx context.Context, jobRunID int64, symp
 	return matches, nil
 }
 
+// evaluateCompoundSymptoms checks CEL symptoms against the manual labels and the labels
+// produced by the simple symptom matches, returning a match for each CEL symptom that holds.
+func evaluateCompoundSymptoms(symptoms []jobrunscan.Symptom, manualLabels []string, simpleMatches []symptomMatch) []symptomMatch {
+	compound, errs := celmatcher.CompileSymptoms(symptoms)
+	for id, err := range errs {
+		log.WithError(err).WithField("symptom", id).Warn("symptom reEval: skipping symptom due to matcher error")
+	}
+	if len(compound) == 0 {
+		return nil
+	}
+
+	applied := slices.Clone(manualLabels)
+	for _, m := range simpleMatches {
+		applied = append(applied, m.symptom.LabelIDs...)
+	}
+
+	var matches []symptomMatch
+	for _, c := range celmatcher.MatchSymptoms(compound, applied) {
+		matches = append(matches, symptomMatch{
+			symptom:   c.Symptom,
+			textMatch: c.Expression.String(),
+		})
+	}
+	return matches
+}
+
 // ContentMatcherForSymptom creates the appropriate ContentMatcher for a symptom definition.
 // Returns nil for file-existence-only matcher types (no content matching needed).
+// CEL symptoms do not read artifacts and are evaluated by evaluateCompoundSymptoms instead.
 func ContentMatcherForSymptom(symptom jobrunscan.SymptomContent) (jobartifacts.ContentMatcher, error) {
 	switch symptom.MatcherType {
 	case jobrunscan.MatcherTypeString:
@@ -324,8 +365,22 @@ func ContentMatcherForSymptom(symptom jobrunscan.SymptomContent) (jobartifacts.C
 			return nil, fmt.Errorf("invalid regex for symptom %q: %w", symptom.ID, err)
 		}
 		return jobartifacts.NewRegexMatcher(re, 0, 0, 1), nil
+	case jobrunscan.MatcherTypeJQ:
+		matcher, err := jobartifacts.NewJQMatcher(symptom.MatchString, 1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jq expression for symptom %q: %w", symptom.ID, err)
+		}
+		return matcher, nil
+	case jobrunscan.MatcherTypeXPath:
+		matcher, err := jobartifacts.NewXPathMatcher(symptom.MatchString, 1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xpath expression for symptom %q: %w", symptom.ID, err)
+		}
+		return matcher, nil
 	case jobrunscan.MatcherTypeFile:
 		return nil, nil
+	case jobrunscan.MatcherTypeCEL:
+		return nil, fmt.Errorf("symptom %q is a compound (cel) symptom and does not match artifact content", symptom.ID)
 	default:
 		return nil, fmt.Errorf("unsupported matcher type %q for symptom %q", symptom.MatcherType, symptom.ID)
 	}
@@ -504,16 +559,7 @@ func (r *ReEvaluator) clearGCSLabels(ctx context.Context, jobRunPath string) err
 // clobber it and break the "InfraFailure label in PostgreSQL == subtraction
 // done" invariant. release_job_runs is not coupled to the subtraction, so it
 // receives the merged set as-is.
-func (r *ReEvaluator) updatePostgresLabels(ctx context.Context, buildID string, jobRun *models.ProwJobRun, newBQLabels []models.JobRunLabel) error {
-	// Query BQ for existing non-symptom labels for this build ID
-	manualLabels, err := r.queryNonSymptomLabels(ctx, buildID, jobRun.Timestamp)
-	if err != nil {
-		log.WithError(err).WithField("buildID", buildID).Warn("symptom reEval: could not query non-symptom labels from BQ, using existing PG labels as fallback")
-		// Fallback: keep existing labels that aren't from symptoms
-		// We can't distinguish these in PG alone, so keep all existing labels
-		manualLabels = jobRun.Labels
-	}
-
+func (r *ReEvaluator) updatePostgresLabels(ctx context.Context, buildID string, jobRun *models.ProwJobRun, manualLabels []string, newBQLabels []models.JobRunLabel) error {
 	// Merge manual labels with new symptom labels
 	merged := mergeLabels(manualLabels, newBQLabels)
 
@@ -582,6 +628,19 @@ func (r *ReEvaluator) updatePostgresLabels(ctx context.Context, buildID string,
 	return nil
 }
 
+// manualLabels returns the labels on the job run that were not applied by symptom detection.
+func (r *ReEvaluator) manualLabels(ctx context.Context, buildID string, jobRun *models.ProwJobRun) []string {
+	// Query BQ for existing non-symptom labels for this build ID
+	manualLabels, err := r.queryNonSymptomLabels(ctx, buildID, jobRun.Timestamp)
+	if err != nil {
+		log.WithError(err).WithField("buildID", buildID).Warn("symptom reEval: could not query non-symptom labels from BQ, using existing PG labels as fallback")
+		// Fallback: keep existing labels that aren't from symptoms
+		// We can't distinguish these in PG alone, so keep all existing labels
+		return jobRun.Labels
+	}
+	return manualLabels
+}
+
 // queryNonSymptomLabels queries BQ for labels that were NOT applied by symptom detection.
 func (r *ReEvaluator) queryNonSymptomLabels(ctx context.Context, buildID string, startTime time.Time) ([]string, error) {
 	table := fmt.Sprintf("`%s.job_labels`", r.bqClient.Dataset)
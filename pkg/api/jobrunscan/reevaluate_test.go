@@ -88,26 +88,55 @@ func TestFilterImplementedSymptoms(t *testing.T) {
 	}
 
 	filtered := filterRelevantSymptoms(symptoms)
-	if len(filtered) != 3 {
-		t.Fatalf("expected 3 relevant symptoms, got %d", len(filtered))
+	if len(filtered) != 4 {
+		t.Fatalf("expected 4 relevant symptoms, got %d", len(filtered))
 	}
 
 	ids := map[string]bool{}
 	for _, s := range filtered {
 		ids[s.ID] = true
 	}
-	for _, id := range []string{"s1", "s2", "s3"} {
+	for _, id := range []string{"s1", "s2", "s3", "s4"} {
 		if !ids[id] {
 			t.Errorf("expected symptom %q to be included", id)
 		}
 	}
-	for _, id := range []string{"s4", "s5", "s6"} {
+	for _, id := range []string{"s5", "s6"} {
 		if ids[id] {
 			t.Errorf("expected symptom %q to be excluded", id)
 		}
 	}
 }
 
+func TestEvaluateCompoundSymptoms(t *testing.T) {
+	dnsSymptom := jobrunscan.Symptom{SymptomContent: jobrunscan.SymptomContent{
+		ID: "DNS", MatcherType: jobrunscan.MatcherTypeString, MatchString: "dns timeout", LabelIDs: pq.StringArray{"DNSTimeout"}}}
+	symptoms := []jobrunscan.Symptom{
+		dnsSymptom,
+		{SymptomContent: jobrunscan.SymptomContent{
+			ID: "DNSOnly", MatcherType: jobrunscan.MatcherTypeCEL, MatchString: "DNSTimeout && !OperatorError", LabelIDs: pq.StringArray{"ClusterDNSFlake"}}},
+		{SymptomContent: jobrunscan.SymptomContent{
+			ID: "Broken", MatcherType: jobrunscan.MatcherTypeCEL, MatchString: "DNSTimeout &&", LabelIDs: pq.StringArray{"Never"}}},
+	}
+	simpleMatches := []symptomMatch{{symptom: dnsSymptom, fileMatch: "logs/job/123/build-log.txt", textMatch: "dns timeout"}}
+
+	matches := evaluateCompoundSymptoms(symptoms, nil, simpleMatches)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 compound match, got %d", len(matches))
+	}
+	if matches[0].symptom.ID != "DNSOnly" {
+		t.Errorf("expected DNSOnly to match, got %q", matches[0].symptom.ID)
+	}
+	if matches[0].textMatch != "DNSTimeout && !OperatorError" {
+		t.Errorf("expected the expression as text match, got %q", matches[0].textMatch)
+	}
+
+	// A manually applied OperatorError label suppresses the compound symptom
+	if matches := evaluateCompoundSymptoms(symptoms, []string{"OperatorError"}, simpleMatches); len(matches) != 0 {
+		t.Errorf("expected no compound matches with OperatorError applied, got %d", len(matches))
+	}
+}
+
 func TestMergeLabels(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -266,6 +295,40 @@ func TestContentMatcherForSymptom(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "jq matcher",
+			symptom: jobrunscan.SymptomContent{
+				MatcherType: jobrunscan.MatcherTypeJQ,
+				MatchString: `.items[] | select(.level == "Error")`,
+			},
+			wantNil: false,
+		},
+		{
+			name: "invalid jq",
+			symptom: jobrunscan.SymptomContent{
+				ID:          "bad",
+				MatcherType: jobrunscan.MatcherTypeJQ,
+				MatchString: ".items[",
+			},
+			wantErr: true,
+		},
+		{
+			name: "xpath matcher",
+			symptom: jobrunscan.SymptomContent{
+				MatcherType: jobrunscan.MatcherTypeXPath,
+				MatchString: "//testcase[failure]",
+			},
+			wantNil: false,
+		},
+		{
+			name: "invalid xpath",
+			symptom: jobrunscan.SymptomContent{
+				ID:          "bad",
+				MatcherType: jobrunscan.MatcherTypeXPath,
+				MatchString: "//testcase[",
+			},
+			wantErr: true,
+		},
 		{
 			name: "file existence matcher",
 			symptom: jobrunscan.SymptomContent{
@@ -273,11 +336,20 @@ func TestContentMatcherForSymptom(t *testing.T) {
 			},
 			wantNil: true,
 		},
+		{
+			name: "cel matcher has no content matcher",
+			symptom: jobrunscan.SymptomContent{
+				ID:          "compound",
+				MatcherType: jobrunscan.MatcherTypeCEL,
+				MatchString: "DNSTimeout && !OperatorError",
+			},
+			wantErr: true,
+		},
 		{
 			name: "unsupported matcher type",
 			symptom: jobrunscan.SymptomContent{
 				ID:          "test",
-				MatcherType: "jq",
+				MatcherType: "yaml",
 			},
 			wantErr: true,
 		},
@@ -4,8 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	sippyapi "github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/api/jobrunscan/celmatcher"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
 	log "github.com/sirupsen/logrus"
@@ -30,11 +35,13 @@ func validateSymptom(dbc *gorm.DB, symptom jobrunscan.Symptom) error {
 	validMatcherTypes := map[string]bool{
 		jobrunscan.MatcherTypeString: true,
 		jobrunscan.MatcherTypeRegex:  true,
+		jobrunscan.MatcherTypeJQ:     true,
+		jobrunscan.MatcherTypeXPath:  true,
 		jobrunscan.MatcherTypeFile:   true,
 		jobrunscan.MatcherTypeCEL:    true,
 	}
 	if !validMatcherTypes[symptom.MatcherType] {
-		return fmt.Errorf("invalid matcher_type: %s (must be one of: string, regex, none, cel)", symptom.MatcherType)
+		return fmt.Errorf("invalid matcher_type: %s (must be one of: string, regex, jq, xpath, none, cel)", symptom.MatcherType)
 	}
 
 	// Validate required fields based on matcher_type
@@ -43,11 +50,18 @@ func validateSymptom(dbc *gorm.DB, symptom jobrunscan.Symptom) error {
 		if symptom.FilePattern == "" {
 			return fmt.Errorf("file_pattern is required for matcher_type: %s", symptom.MatcherType)
 		}
+		// Ensure the match string compiles for the matcher type
+		if _, err := ContentMatcherForSymptom(symptom.SymptomContent); err != nil {
+			return err
+		}
 	} else {
 		// CEL matchers require match_string
 		if symptom.MatchString == "" {
 			return fmt.Errorf("match_string is required for matcher_type: cel")
 		}
+		if err := validateCELExpression(dbc, symptom.MatchString); err != nil {
+			return err
+		}
 	}
 
 	// Validate that referenced label IDs exist
@@ -65,6 +79,28 @@ func validateSymptom(dbc *gorm.DB, symptom jobrunscan.Symptom) error {
 	return nil
 }
 
+// validateCELExpression ensures a compound symptom expression parses and only references existing labels.
+func validateCELExpression(dbc *gorm.DB, expression string) error {
+	expr, err := celmatcher.Compile(expression)
+	if err != nil {
+		return fmt.Errorf("invalid cel expression in match_string: %v", err)
+	}
+
+	referenced := expr.LabelIDs()
+	if len(referenced) == 0 {
+		return fmt.Errorf("cel expression in match_string must reference at least one label")
+	}
+	var existing []string
+	res := dbc.Model(&jobrunscan.Label{}).Where("id = ANY(?)", pq.StringArray(referenced)).Pluck("id", &existing)
+	if res.Error != nil {
+		return fmt.Errorf("error validating cel expression labels: %v", res.Error)
+	}
+	if missing := sets.List(sets.New(referenced...).Delete(existing...)); len(missing) > 0 {
+		return fmt.Errorf("cel expression references unknown labels: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // GetSymptom retrieves a single symptom by ID
 func GetSymptom(dbc *db.DB, id string, req *http.Request) (*jobrunscan.Symptom, error) {
 	var symptom jobrunscan.Symptom
@@ -237,13 +237,14 @@ type jobDetail struct {
 	Results []v1sippyprocessing.JobRunResult `json:"results"`
 }
 
-type jobDetailAPIResult struct {
+// JobDetailAPIResult is the response of the job details report.
+type JobDetailAPIResult struct {
 	Jobs  []jobDetail `json:"jobs"`
 	Start civil.Date  `json:"start"`
 	End   civil.Date  `json:"end"`
 }
 
-func (jobs jobDetailAPIResult) limit(req *http.Request) jobDetailAPIResult {
+func (jobs JobDetailAPIResult) limit(req *http.Request) JobDetailAPIResult {
 	limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
 	ret := jobs
 	if limit > 0 && len(jobs.Jobs) >= limit {
@@ -319,7 +320,7 @@ func PrintJobDetailsReportFromDB(w http.ResponseWriter, req *http.Request, dbc *
 		jobs = append(jobs, *jobDetail)
 	}
 
-	RespondWithJSON(http.StatusOK, w, jobDetailAPIResult{
+	RespondWithJSON(http.StatusOK, w, JobDetailAPIResult{
 		Jobs:  jobs,
 		Start: start,
 		End:   end,
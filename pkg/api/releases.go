@@ -355,12 +355,13 @@ func GetPayloadEvents(dbClient *db.DB, release string, filterOpts *filter.Filter
 	return releases, nil
 }
 
-func PrintReleasesReport(w http.ResponseWriter, req *http.Request, dbClient *db.DB) {
-	type apiReleaseTag struct {
-		models.ReleaseTag
-		FailedJobNames pq.StringArray `gorm:"type:text[];column:failed_job_names" json:"failed_job_names,omitempty"`
-	}
+// APIReleaseTag is a release tag as reported by PrintReleasesReport, with the names of its failed jobs.
+type APIReleaseTag struct {
+	models.ReleaseTag
+	FailedJobNames pq.StringArray `gorm:"type:text[];column:failed_job_names" json:"failed_job_names,omitempty"`
+}
 
+func PrintReleasesReport(w http.ResponseWriter, req *http.Request, dbClient *db.DB) {
 	if dbClient == nil || dbClient.DB == nil {
 		RespondWithJSON(http.StatusOK, w, []struct{}{})
 	}
@@ -379,7 +380,7 @@ func PrintReleasesReport(w http.ResponseWriter, req *http.Request, dbClient *db.
 		return
 	}
 
-	releases := make([]apiReleaseTag, 0)
+	releases := make([]APIReleaseTag, 0)
 
 	// This join looks up the names of failed jobs, if any, and returns them as
 	// a JSON aggregation (i.e. failedJobNames will contain a JSON array).
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
@@ -134,6 +135,30 @@ func VariantListToMapWithWarnings(allJobVariants crtest.JobVariants, variants []
 	return variantsMap, warnings, nil
 }
 
+// GetUserForRequest returns the user identified by the auth proxy in front of sippy, or "developer"
+// in DEV_MODE when there is no proxy. Empty means the user is unknown.
+func GetUserForRequest(req *http.Request) string {
+	user := req.Header.Get("X-Forwarded-User")
+	if user == "" && os.Getenv("DEV_MODE") == "1" {
+		user = "developer"
+	}
+	return user
+}
+
+// GetGroupsForRequest returns the groups the auth proxy in front of sippy reports the user belongs to,
+// from a comma separated X-Forwarded-Groups header.
+func GetGroupsForRequest(req *http.Request) []string {
+	var groups []string
+	for _, header := range req.Header.Values("X-Forwarded-Groups") {
+		for _, group := range strings.Split(header, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
+
 // GetBaseURL returns the base URL (protocol + host) from the request.
 // Otherwise it uses the request host and handles TLS and X-Forwarded-Proto for the protocol.
 func GetBaseURL(req *http.Request) string {
@@ -0,0 +1,149 @@
+package crsnapshot
+
+import (
+	"sort"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+)
+
+// DateFormat is the format of snapshot dates, both stored and in the API.
+const DateFormat = "2006-01-02"
+
+// FromReport builds the compact snapshot of a component report.
+func FromReport(view, release, date string, report *crtype.ComponentReport) Snapshot {
+	snapshot := Snapshot{
+		View:        view,
+		Release:     release,
+		Date:        date,
+		GeneratedAt: report.GeneratedAt,
+		Cells:       []Cell{},
+	}
+	for _, row := range report.Rows {
+		for _, col := range row.Columns {
+			cell := Cell{
+				Component:  row.Component,
+				Capability: row.Capability,
+				Variants:   col.Variants,
+				Status:     col.Status,
+			}
+			for _, rt := range col.RegressedTests {
+				cell.RegressedTests = append(cell.RegressedTests, Test{
+					TestID:     rt.TestID,
+					TestName:   rt.TestName,
+					Component:  rt.Component,
+					Capability: rt.Capability,
+					Variants:   rt.Variants,
+					Status:     rt.ReportStatus,
+				})
+			}
+			snapshot.Cells = append(snapshot.Cells, cell)
+		}
+	}
+	return snapshot
+}
+
+// Compare returns the differences between an older and a newer snapshot of the same view.
+// Tests are matched by test ID and variants, cells by component, capability and column variants.
+func Compare(from, to Snapshot) Diff {
+	diff := Diff{
+		View:               to.View,
+		From:               from.Date,
+		To:                 to.Date,
+		NewRegressions:     []Test{},
+		ClearedRegressions: []Test{},
+		TestStatusChanges:  []TestStatusChange{},
+		CellStatusChanges:  []CellStatusChange{},
+	}
+
+	fromTests, toTests := from.tests(), to.tests()
+	for key, t := range toTests {
+		old, ok := fromTests[key]
+		switch {
+		case !ok:
+			diff.NewRegressions = append(diff.NewRegressions, t)
+		case old.Status != t.Status:
+			diff.TestStatusChanges = append(diff.TestStatusChanges, TestStatusChange{Test: t, FromStatus: old.Status})
+		}
+	}
+	for key, t := range fromTests {
+		if _, ok := toTests[key]; !ok {
+			diff.ClearedRegressions = append(diff.ClearedRegressions, t)
+		}
+	}
+
+	fromCells, toCells := from.cells(), to.cells()
+	for key, c := range toCells {
+		toStatus := c.Status
+		change := CellStatusChange{Component: c.Component, Capability: c.Capability, Variants: c.Variants, ToStatus: &toStatus}
+		if old, ok := fromCells[key]; ok {
+			if old.Status == c.Status {
+				continue
+			}
+			fromStatus := old.Status
+			change.FromStatus = &fromStatus
+		}
+		diff.CellStatusChanges = append(diff.CellStatusChanges, change)
+	}
+	for key, c := range fromCells {
+		if _, ok := toCells[key]; !ok {
+			fromStatus := c.Status
+			diff.CellStatusChanges = append(diff.CellStatusChanges,
+				CellStatusChange{Component: c.Component, Capability: c.Capability, Variants: c.Variants, FromStatus: &fromStatus})
+		}
+	}
+
+	sortTests(diff.NewRegressions)
+	sortTests(diff.ClearedRegressions)
+	sort.Slice(diff.TestStatusChanges, func(i, j int) bool {
+		return testLess(diff.TestStatusChanges[i].Test, diff.TestStatusChanges[j].Test)
+	})
+	sort.Slice(diff.CellStatusChanges, func(i, j int) bool {
+		a, b := diff.CellStatusChanges[i], diff.CellStatusChanges[j]
+		return cellKey(a.Component, a.Capability, a.Variants) < cellKey(b.Component, b.Capability, b.Variants)
+	})
+	return diff
+}
+
+// tests returns all regressed tests in the snapshot, keyed by test ID and variants. A test can be listed in
+// several cells when a report is grouped by capability, in which case the first is kept.
+func (s Snapshot) tests() map[string]Test {
+	tests := map[string]Test{}
+	for _, c := range s.Cells {
+		for _, t := range c.RegressedTests {
+			key := crtest.KeyWithVariants{TestID: t.TestID, Variants: t.Variants}.Encode()
+			if _, ok := tests[key]; !ok {
+				tests[key] = t
+			}
+		}
+	}
+	return tests
+}
+
+func (s Snapshot) cells() map[string]Cell {
+	cells := make(map[string]Cell, len(s.Cells))
+	for _, c := range s.Cells {
+		cells[cellKey(c.Component, c.Capability, c.Variants)] = c
+	}
+	return cells
+}
+
+func cellKey(component, capability string, variants map[string]string) string {
+	return component + "\x00" + capability + "\x00" + crtest.EncodeVariants(variants)
+}
+
+func sortTests(tests []Test) {
+	sort.Slice(tests, func(i, j int) bool {
+		return testLess(tests[i], tests[j])
+	})
+}
+
+func testLess(a, b Test) bool {
+	if a.Component != b.Component {
+		return a.Component < b.Component
+	}
+	if a.TestName != b.TestName {
+		return a.TestName < b.TestName
+	}
+	return crtest.EncodeVariants(a.Variants) < crtest.EncodeVariants(b.Variants)
+}
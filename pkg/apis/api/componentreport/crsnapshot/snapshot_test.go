@@ -0,0 +1,104 @@
+package crsnapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	crtype "github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+)
+
+var (
+	aws = map[string]string{"Platform": "aws", "Network": "ovn"}
+	gcp = map[string]string{"Platform": "gcp", "Network": "ovn"}
+)
+
+func regressedTest(testID, name string, variants map[string]string, status crtest.Status) crtype.ReportTestSummary {
+	return crtype.ReportTestSummary{
+		Identification: crtest.Identification{
+			RowIdentification:    crtest.RowIdentification{Component: "Storage", TestID: testID, TestName: name},
+			ColumnIdentification: crtest.ColumnIdentification{Variants: variants},
+		},
+		TestComparison: testdetails.TestComparison{ReportStatus: status},
+	}
+}
+
+func report(columns ...crtype.ReportColumn) *crtype.ComponentReport {
+	return &crtype.ComponentReport{
+		Rows: []crtype.ReportRow{{RowIdentification: crtest.RowIdentification{Component: "Storage"}, Columns: columns}},
+	}
+}
+
+func column(variants map[string]string, status crtest.Status, tests ...crtype.ReportTestSummary) crtype.ReportColumn {
+	return crtype.ReportColumn{
+		ColumnIdentification: crtest.ColumnIdentification{Variants: variants},
+		Status:               status,
+		RegressedTests:       tests,
+	}
+}
+
+func TestFromReport(t *testing.T) {
+	s := FromReport("4.21-main", "4.21", "2026-10-01", report(
+		column(aws, crtest.SignificantRegression, regressedTest("t1", "test one", aws, crtest.SignificantRegression)),
+		column(gcp, crtest.NotSignificant),
+	))
+	require.Len(t, s.Cells, 2)
+	assert.Equal(t, "Storage", s.Cells[0].Component)
+	assert.Equal(t, []Test{{TestID: "t1", TestName: "test one", Component: "Storage", Variants: aws,
+		Status: crtest.SignificantRegression}}, s.Cells[0].RegressedTests)
+	assert.Empty(t, s.Cells[1].RegressedTests)
+}
+
+func TestCompare(t *testing.T) {
+	from := FromReport("4.21-main", "4.21", "2026-10-01", report(
+		column(aws, crtest.ExtremeRegression,
+			regressedTest("t1", "test one", aws, crtest.ExtremeRegression),
+			regressedTest("t2", "test two", aws, crtest.SignificantRegression)),
+		column(gcp, crtest.NotSignificant),
+	))
+	to := FromReport("4.21-main", "4.21", "2026-10-02", report(
+		column(aws, crtest.ExtremeTriagedRegression,
+			regressedTest("t1", "test one", aws, crtest.ExtremeTriagedRegression)),
+		column(gcp, crtest.SignificantRegression,
+			regressedTest("t1", "test one", gcp, crtest.SignificantRegression)),
+	))
+
+	diff := Compare(from, to)
+	assert.Equal(t, "2026-10-01", diff.From)
+	assert.Equal(t, "2026-10-02", diff.To)
+
+	require.Len(t, diff.NewRegressions, 1)
+	assert.Equal(t, "t1", diff.NewRegressions[0].TestID)
+	assert.Equal(t, gcp, diff.NewRegressions[0].Variants)
+
+	require.Len(t, diff.ClearedRegressions, 1)
+	assert.Equal(t, "t2", diff.ClearedRegressions[0].TestID)
+
+	require.Len(t, diff.TestStatusChanges, 1)
+	assert.Equal(t, crtest.ExtremeRegression, diff.TestStatusChanges[0].FromStatus)
+	assert.Equal(t, crtest.ExtremeTriagedRegression, diff.TestStatusChanges[0].Status)
+
+	require.Len(t, diff.CellStatusChanges, 2)
+	for _, c := range diff.CellStatusChanges {
+		require.NotNil(t, c.FromStatus)
+		require.NotNil(t, c.ToStatus)
+	}
+}
+
+func TestCompareMissingCells(t *testing.T) {
+	from := FromReport("4.21-main", "4.21", "2026-10-01", report(column(aws, crtest.NotSignificant)))
+	to := FromReport("4.21-main", "4.21", "2026-10-02", report(column(gcp, crtest.MissingSample)))
+
+	diff := Compare(from, to)
+	require.Len(t, diff.CellStatusChanges, 2)
+	// sorted by variants, so aws (removed) comes before gcp (added)
+	assert.Nil(t, diff.CellStatusChanges[0].ToStatus)
+	assert.Equal(t, crtest.NotSignificant, *diff.CellStatusChanges[0].FromStatus)
+	assert.Nil(t, diff.CellStatusChanges[1].FromStatus)
+	assert.Equal(t, crtest.MissingSample, *diff.CellStatusChanges[1].ToStatus)
+
+	assert.Empty(t, Compare(to, to).CellStatusChanges)
+}
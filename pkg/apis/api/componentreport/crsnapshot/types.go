@@ -0,0 +1,67 @@
+package crsnapshot
+
+import (
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+)
+
+// Package crsnapshot contains the compact form of a component report that is stored daily for each view,
+// so that past reports can be looked up and compared without regenerating them.
+
+// Snapshot is a compact copy of a component report for a view on a given day.
+type Snapshot struct {
+	View        string     `json:"view"`
+	Release     string     `json:"release"`
+	Date        string     `json:"date"`
+	GeneratedAt *time.Time `json:"generated_at,omitempty"`
+	Cells       []Cell     `json:"cells"`
+}
+
+// Cell is a single component (and capability, if any) by variant column of the report.
+type Cell struct {
+	Component      string            `json:"component"`
+	Capability     string            `json:"capability,omitempty"`
+	Variants       map[string]string `json:"variants"`
+	Status         crtest.Status     `json:"status"`
+	RegressedTests []Test            `json:"regressed_tests,omitempty"`
+}
+
+// Test is a test listed as regressed (or triaged, or fixed) in a cell.
+type Test struct {
+	TestID     string            `json:"test_id"`
+	TestName   string            `json:"test_name"`
+	Component  string            `json:"component"`
+	Capability string            `json:"capability,omitempty"`
+	Variants   map[string]string `json:"variants"`
+	Status     crtest.Status     `json:"status"`
+}
+
+// Diff describes what changed in a view's report between two snapshot dates.
+type Diff struct {
+	View string `json:"view"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// NewRegressions are tests regressed on the To date that were not regressed on the From date.
+	NewRegressions []Test `json:"new_regressions"`
+	// ClearedRegressions are tests regressed on the From date that are no longer regressed on the To date.
+	ClearedRegressions []Test `json:"cleared_regressions"`
+	// TestStatusChanges are tests regressed on both dates whose status changed, e.g. when triaged.
+	TestStatusChanges []TestStatusChange `json:"test_status_changes"`
+	// CellStatusChanges are cells whose overall status changed.
+	CellStatusChanges []CellStatusChange `json:"cell_status_changes"`
+}
+
+type TestStatusChange struct {
+	Test
+	FromStatus crtest.Status `json:"from_status"`
+}
+
+type CellStatusChange struct {
+	Component  string            `json:"component"`
+	Capability string            `json:"capability,omitempty"`
+	Variants   map[string]string `json:"variants"`
+	// FromStatus and ToStatus are nil when the cell is absent from that snapshot.
+	FromStatus *crtest.Status `json:"from_status"`
+	ToStatus   *crtest.Status `json:"to_status"`
+}
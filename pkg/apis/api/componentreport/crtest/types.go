@@ -36,6 +36,7 @@ type Comparison string
 const (
 	PassRate    Comparison = "pass_rate"
 	FisherExact Comparison = "fisher_exact"
+	Bayesian    Comparison = "bayesian"
 )
 
 const (
@@ -1,6 +1,7 @@
 package reqopts
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/openshift/sippy/pkg/apis/cache"
@@ -125,4 +126,23 @@ type Advanced struct {
 	// caused by fundamental infrastructure issues (e.g., install failures, upgrade failures).
 	// When multiple key tests fail in the same job, only the highest priority (earliest in list) test is included.
 	KeyTestNames []string `json:"key_test_names,omitempty" yaml:"key_test_names,omitempty"`
+	// Analyzer selects the statistical analysis used to decide if a test has regressed. Empty means
+	// the default Fisher's Exact test; see the Analyzer* constants for valid values.
+	Analyzer string `json:"analyzer,omitempty" yaml:"analyzer,omitempty"`
+}
+
+const (
+	// AnalyzerFisherExact compares basis and sample with Fisher's Exact test (the default).
+	AnalyzerFisherExact = "fisher_exact"
+	// AnalyzerBayesian compares Beta-Binomial posteriors of the basis and sample pass rates.
+	AnalyzerBayesian = "bayesian"
+)
+
+// ValidateAnalyzer checks that the requested analyzer is one we know how to run; empty selects the default.
+func (a Advanced) ValidateAnalyzer() error {
+	switch a.Analyzer {
+	case "", AnalyzerFisherExact, AnalyzerBayesian:
+		return nil
+	}
+	return fmt.Errorf("analyzer must be one of %q or %q", AnalyzerFisherExact, AnalyzerBayesian)
 }
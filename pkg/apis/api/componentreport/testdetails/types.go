@@ -73,6 +73,9 @@ type TestComparison struct {
 	// FisherExact indicates the confidence of a regression after applying Fisher's Exact Test.
 	FisherExact *float64 `json:"fisher_exact,omitempty"`
 
+	// Bayesian holds the posterior summary when the Bayesian analyzer was used.
+	Bayesian *BayesianStats `json:"bayesian,omitempty"`
+
 	// BaseStats may not be present in the response, i.e. new tests regressed because of their pass rate.
 	BaseStats *ReleaseStats `json:"base_stats,omitempty"`
 
@@ -84,6 +87,26 @@ type TestComparison struct {
 	Regression *models.TestRegression `json:"regression,omitempty"`
 }
 
+// BayesianStats summarizes a Beta-Binomial comparison of the basis and sample pass rates.
+type BayesianStats struct {
+	// RegressionProbability is the posterior probability (0-1) that the sample pass rate dropped
+	// below the basis pass rate by more than the pity factor.
+	RegressionProbability float64 `json:"regression_probability"`
+	// ImprovementProbability is the posterior probability (0-1) that the sample pass rate is higher.
+	ImprovementProbability float64 `json:"improvement_probability"`
+	// PassRateDrop is the posterior mean of the basis pass rate minus the sample pass rate (0-1).
+	PassRateDrop float64 `json:"pass_rate_drop"`
+	// CredibleInterval bounds the pass rate drop, only computed when a regression is reported.
+	CredibleInterval *CredibleInterval `json:"credible_interval,omitempty"`
+}
+
+// CredibleInterval is an equal-tailed interval holding Level (0-1) of the posterior mass.
+type CredibleInterval struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Level float64 `json:"level"`
+}
+
 type ReleaseStats struct {
 	Release string `json:"release"`
 	Start   *time.Time
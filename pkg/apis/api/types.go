@@ -1129,6 +1129,7 @@ type FeatureGateCapabilityTestRegression struct {
 // FeatureGateVariantResult represents the promotion readiness for a single variant combination.
 type FeatureGateVariantResult struct {
 	Variants    map[string]string       `json:"variants"`
+	JobTiers    []string                `json:"job_tiers"`
 	Optional    bool                    `json:"optional"`
 	Sufficient  bool                    `json:"sufficient"`
 	TestResults []FeatureGateTestResult `json:"test_results"`
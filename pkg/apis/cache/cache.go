@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 )
@@ -11,6 +12,63 @@ type Cache interface {
 	Set(ctx context.Context, key string, content []byte, duration time.Duration) error
 }
 
+// ErrNotFound is returned by Inspector.TTL for keys that are not cached.
+var ErrNotFound = errors.New("key not found in cache")
+
+// ErrNotInspectable is returned by caches that wrap another cache which cannot be inspected.
+var ErrNotInspectable = errors.New("cache does not support inspection")
+
+// ErrReadOnly is returned by Inspector.Delete for keys held by a read only cache, which keeps
+// serving them until they expire.
+var ErrReadOnly = errors.New("cache is read only")
+
+// Inspector is implemented by caches whose entries can be listed and removed, e.g. to purge a bad
+// report rather than waiting for it to expire.
+type Inspector interface {
+	// Keys lists the cached keys starting with prefix.
+	Keys(ctx context.Context, prefix string) ([]string, error)
+	// TTL returns how long until key expires, or zero if it does not expire.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// Delete removes key from the cache. Deleting a key that is not cached is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Entry is a cached key and how long until it expires, zero if it does not.
+type Entry struct {
+	Key string
+	TTL time.Duration
+}
+
+// EntryLister is implemented by inspectors that list keys together with their TTL more cheaply
+// than calling TTL for each key.
+type EntryLister interface {
+	// Entries lists the cached keys starting with prefix, with their TTL.
+	Entries(ctx context.Context, prefix string) ([]Entry, error)
+}
+
+// Invalidator is implemented by caches shared by all sippy replicas that can tell every replica to
+// drop its own copy of a key, e.g. one kept in memory, when the key is deleted.
+type Invalidator interface {
+	// Invalidate tells the subscribers on every replica that key was deleted.
+	Invalidate(ctx context.Context, key string) error
+	// SubscribeInvalidations calls drop with each invalidated key until ctx is done.
+	SubscribeInvalidations(ctx context.Context, drop func(key string)) error
+}
+
+// RateLimiter is implemented by caches that can count requests across all sippy replicas.
+type RateLimiter interface {
+	// RateLimit records a request against key if fewer than limit were allowed in the last period.
+	RateLimit(ctx context.Context, key string, limit int, period time.Duration) (RateLimitResult, error)
+}
+
+// RateLimitResult describes the rate limit window after a request.
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// Reset is how long until the oldest request in the window expires, freeing up a request.
+	Reset time.Duration
+}
+
 type APIResponse struct {
 	Headers  http.Header
 	Response []byte
@@ -2,7 +2,9 @@ package bigquerycache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -16,6 +18,7 @@ import (
 	"github.com/openshift/sippy/pkg/cache/compressed"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/api/iterator"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // https://cloud.google.com/bigquery/quotas#streaming_inserts
@@ -316,6 +319,195 @@ func (c Cache) Set(ctx context.Context, key string, content []byte, duration tim
 	return nil
 }
 
+// liveEntry is the latest version of a key that has not expired or been deleted.
+type liveEntry struct {
+	Key        string    `bigquery:"key"`
+	Expiration time.Time `bigquery:"expiration"`
+}
+
+// findLiveEntries returns the latest unexpired version of each key matching condition. Deleted
+// keys are tombstoned with an entry without data, see Delete.
+func (c Cache) findLiveEntries(ctx context.Context, condition string, params ...bigquery.QueryParameter) ([]liveEntry, error) {
+	query := c.client.Query(ctx, bqlabel.CacheLookup, fmt.Sprintf(
+		`SELECT key, expiration FROM (
+			SELECT key, expiration, IFNULL(LENGTH(data), 0) = 0 AS deleted,
+				ROW_NUMBER() OVER (PARTITION BY key ORDER BY %s DESC) AS row_num
+			FROM `+"`%s.%s`"+`
+			WHERE %s > @expByNowTime
+			  AND expiration > @expTime
+			  AND chunk_index = 0
+			  AND %s
+		) WHERE row_num = 1 AND NOT deleted
+		ORDER BY key`,
+		partitionColumn, c.client.Dataset, cachedTable, partitionColumn, condition))
+	query.Parameters = append([]bigquery.QueryParameter{
+		{
+			Name:  "expByNowTime",
+			Value: time.Now().Add(-1 * c.maxExpiration),
+		},
+		{
+			Name:  "expTime",
+			Value: time.Now(),
+		},
+	}, params...)
+
+	it, err := sippybq.LoggedRead(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var entries []liveEntry
+	for {
+		entry := liveEntry{}
+		err := it.Next(&entry)
+		if err == iterator.Done {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// Keys implements cache.Inspector, listing keys in both the warm cache and bigquery.
+func (c Cache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	seen := sets.New[string]()
+	if warm, ok := c.client.Cache.(cache.Inspector); ok {
+		keys, err := warm.Keys(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		seen.Insert(keys...)
+	}
+
+	entries, err := c.findLiveEntries(ctx, "STARTS_WITH(key, @keyPrefix)",
+		bigquery.QueryParameter{Name: "keyPrefix", Value: prefix})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		seen.Insert(entry.Key)
+	}
+	return sets.List(seen), nil
+}
+
+// Entries implements cache.EntryLister, reading the expiration of every key in bigquery with a
+// single query rather than one per key.
+func (c Cache) Entries(ctx context.Context, prefix string) ([]cache.Entry, error) {
+	ttls := map[string]time.Duration{}
+	entries, err := c.findLiveEntries(ctx, "STARTS_WITH(key, @keyPrefix)",
+		bigquery.QueryParameter{Name: "keyPrefix", Value: prefix})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ttls[entry.Key] = time.Until(entry.Expiration)
+	}
+	// the warm cache takes precedence, as that is what Get serves from
+	if warm, ok := c.client.Cache.(cache.Inspector); ok {
+		keys, err := warm.Keys(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			ttl, err := warm.TTL(ctx, key)
+			if errors.Is(err, cache.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			ttls[key] = ttl
+		}
+	}
+
+	listed := make([]cache.Entry, 0, len(ttls))
+	for key, ttl := range ttls {
+		listed = append(listed, cache.Entry{Key: key, TTL: ttl})
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].Key < listed[j].Key })
+	return listed, nil
+}
+
+// TTL implements cache.Inspector, preferring the warm cache as that is what Get serves from.
+func (c Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	if warm, ok := c.client.Cache.(cache.Inspector); ok {
+		ttl, err := warm.TTL(ctx, key)
+		if !errors.Is(err, cache.ErrNotFound) {
+			return ttl, err
+		}
+	}
+
+	entries, err := c.findLiveEntries(ctx, "key = @keyParam", bigquery.QueryParameter{Name: "keyParam", Value: key})
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, cache.ErrNotFound
+	}
+	return time.Until(entries[0].Expiration), nil
+}
+
+// Delete implements cache.Inspector. Rows still in the streaming buffer cannot be deleted, so
+// instead a tombstone without data is written as the latest version of the key, which Get treats
+// as a miss. A read only cache can only delete keys bigquery does not hold, and returns
+// cache.ErrReadOnly for the others.
+func (c Cache) Delete(ctx context.Context, key string) error {
+	if warm, ok := c.client.Cache.(cache.Inspector); ok {
+		if err := warm.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	expiration, err := c.lastExpiration(ctx, key)
+	if err != nil || expiration.IsZero() {
+		return err
+	}
+	if c.readOnly {
+		return fmt.Errorf("cannot delete %s from persistent cache: %w", key, cache.ErrReadOnly)
+	}
+
+	i := c.client.BQ.Dataset(c.client.Dataset).Table(cachedTable).Inserter()
+	// the tombstone must outlive every version of the key, or an older one would be served again
+	record := CacheRecord{Key: key, Modified: time.Now(), Expiration: expiration, UUID: uuid2.New().String()}
+	return i.Put(ctx, bigquery.ValueSaver(&record))
+}
+
+// lastExpiration returns when the last unexpired version of key expires, or zero if there is none.
+func (c Cache) lastExpiration(ctx context.Context, key string) (time.Time, error) {
+	query := c.client.Query(ctx, bqlabel.CacheLookup, fmt.Sprintf(
+		"SELECT MAX(expiration) AS expiration FROM `%s.%s` "+
+			`WHERE %s > @expByNowTime
+		  AND expiration > @expTime
+		  AND key = @keyParam`,
+		c.client.Dataset, cachedTable, partitionColumn))
+	query.Parameters = []bigquery.QueryParameter{
+		{
+			Name:  "expByNowTime",
+			Value: time.Now().Add(-1 * c.maxExpiration),
+		},
+		{
+			Name:  "expTime",
+			Value: time.Now(),
+		},
+		{
+			Name:  "keyParam",
+			Value: key,
+		},
+	}
+
+	it, err := sippybq.LoggedRead(ctx, query)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var row struct {
+		Expiration bigquery.NullTimestamp `bigquery:"expiration"`
+	}
+	if err := it.Next(&row); err != nil {
+		return time.Time{}, err
+	}
+	return row.Expiration.Timestamp, nil
+}
+
 // Save implements the ValueSaver interface.
 // Can just use the struct as well
 func (c *CacheRecord) Save() (row map[string]bigquery.Value, insertID string, err error) {
@@ -10,6 +10,7 @@ import (
 	// simple checksum usage for validation
 	"crypto/md5" // nolint:gosec
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/openshift/sippy/pkg/apis/cache"
@@ -72,6 +73,40 @@ func (c Cache) Set(ctx context.Context, key string, content []byte, duration tim
 	return c.Cache.Set(ctx, cachePrefix+key, data, duration)
 }
 
+// Keys implements cache.Inspector if the wrapped cache does, listing keys without our prefix.
+func (c Cache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	inspector, ok := c.Cache.(cache.Inspector)
+	if !ok {
+		return nil, cache.ErrNotInspectable
+	}
+	keys, err := inspector.Keys(ctx, cachePrefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		keys[i] = strings.TrimPrefix(keys[i], cachePrefix)
+	}
+	return keys, nil
+}
+
+// TTL implements cache.Inspector if the wrapped cache does.
+func (c Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	inspector, ok := c.Cache.(cache.Inspector)
+	if !ok {
+		return 0, cache.ErrNotInspectable
+	}
+	return inspector.TTL(ctx, cachePrefix+key)
+}
+
+// Delete implements cache.Inspector if the wrapped cache does.
+func (c Cache) Delete(ctx context.Context, key string) error {
+	inspector, ok := c.Cache.(cache.Inspector)
+	if !ok {
+		return cache.ErrNotInspectable
+	}
+	return inspector.Delete(ctx, cachePrefix+key)
+}
+
 func compress(value []byte) ([]byte, [16]byte, error) {
 	var buf bytes.Buffer
 	sum := md5.Sum(value) // nolint:gosec
@@ -0,0 +1,200 @@
+package lru
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+)
+
+var (
+	memoryCacheGetMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sippy_memory_cache_get",
+		Help: "Number of in-memory cache gets, by whether they hit.",
+	}, []string{"result"})
+	memoryCacheEvictionMetric = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sippy_memory_cache_evictions",
+		Help: "Number of items evicted from the in-memory cache to stay within its size.",
+	})
+	memoryCacheBytesMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sippy_memory_cache_bytes",
+		Help: "Size of the items in the in-memory cache.",
+	})
+)
+
+// Cache keeps recently used items of a slower cache, such as redis, in memory. It is bounded by
+// the total size of the items, evicting the least recently used first.
+//
+// Items are kept for at most maxAge, even if cached for longer, as the memory of each replica is
+// not updated when another process replaces an item in the backing cache. Deleted items are dropped
+// from the memory of every replica if the backing cache is a cache.Invalidator. Content returned by
+// Get is shared with the cache and must not be modified.
+type Cache struct {
+	backing  cache.Cache
+	maxBytes int
+	maxAge   time.Duration
+
+	mu    sync.Mutex
+	size  int
+	order *list.List // front is the most recently used
+	items map[string]*list.Element
+}
+
+type entry struct {
+	key     string
+	content []byte
+	expires time.Time
+}
+
+// rateLimitingCache passes rate limiting through to a backing cache that supports it.
+type rateLimitingCache struct {
+	*Cache
+	limiter cache.RateLimiter
+}
+
+func (c rateLimitingCache) RateLimit(ctx context.Context, key string, limit int, period time.Duration) (cache.RateLimitResult, error) {
+	return c.limiter.RateLimit(ctx, key, limit, period)
+}
+
+// NewCache puts an in-memory tier of at most maxBytes in front of backing.
+func NewCache(backing cache.Cache, maxBytes int, maxAge time.Duration) cache.Cache {
+	c := &Cache{
+		backing:  backing,
+		maxBytes: maxBytes,
+		maxAge:   maxAge,
+		order:    list.New(),
+		items:    map[string]*list.Element{},
+	}
+	if invalidator, ok := backing.(cache.Invalidator); ok {
+		if err := invalidator.SubscribeInvalidations(context.Background(), c.remove); err != nil {
+			logrus.WithError(err).Warn("could not subscribe to cache invalidations, items deleted by other replicas will be served from memory until they are older than the maximum age")
+		}
+	}
+	if limiter, ok := backing.(cache.RateLimiter); ok {
+		return rateLimitingCache{Cache: c, limiter: limiter}
+	}
+	return c
+}
+
+func (c *Cache) Get(ctx context.Context, key string, duration time.Duration) ([]byte, error) {
+	if content, ok := c.get(key); ok {
+		memoryCacheGetMetric.WithLabelValues("hit").Inc()
+		return content, nil
+	}
+	memoryCacheGetMetric.WithLabelValues("miss").Inc()
+
+	content, err := c.backing.Get(ctx, key, duration)
+	if err == nil && content != nil {
+		c.add(key, content, duration)
+	}
+	return content, err
+}
+
+func (c *Cache) Set(ctx context.Context, key string, content []byte, duration time.Duration) error {
+	if err := c.backing.Set(ctx, key, content, duration); err != nil {
+		// the backing cache may hold an older item, so do not serve a newer one from memory
+		c.remove(key)
+		return err
+	}
+	c.add(key, content, duration)
+	return nil
+}
+
+func (c *Cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expires) {
+		c.removeElement(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return e.content, true
+}
+
+func (c *Cache) add(key string, content []byte, duration time.Duration) {
+	if len(content) > c.maxBytes/8 {
+		// a few huge items would evict everything else
+		return
+	}
+	expires := time.Now().Add(min(duration, c.maxAge))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+	c.items[key] = c.order.PushFront(&entry{key: key, content: content, expires: expires})
+	c.size += len(content)
+	for c.size > c.maxBytes {
+		c.removeElement(c.order.Back())
+		memoryCacheEvictionMetric.Inc()
+	}
+	memoryCacheBytesMetric.Set(float64(c.size))
+}
+
+func (c *Cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+		memoryCacheBytesMetric.Set(float64(c.size))
+	}
+}
+
+// removeElement drops an item. The caller must hold c.mu.
+func (c *Cache) removeElement(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry)
+	delete(c.items, e.key)
+	c.size -= len(e.content)
+}
+
+// Keys implements cache.Inspector if the backing cache does. Items are only kept in memory while
+// the backing cache holds them, so its keys are all there is.
+func (c *Cache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	inspector, ok := c.backing.(cache.Inspector)
+	if !ok {
+		return nil, cache.ErrNotInspectable
+	}
+	return inspector.Keys(ctx, prefix)
+}
+
+// TTL implements cache.Inspector if the backing cache does.
+func (c *Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	inspector, ok := c.backing.(cache.Inspector)
+	if !ok {
+		return 0, cache.ErrNotInspectable
+	}
+	return inspector.TTL(ctx, key)
+}
+
+// Delete implements cache.Inspector if the backing cache does. If the backing cache is also a
+// cache.Invalidator, the item is dropped from the memory of other replicas too; otherwise they keep
+// serving it until it is older than maxAge.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	inspector, ok := c.backing.(cache.Inspector)
+	if !ok {
+		return cache.ErrNotInspectable
+	}
+	c.remove(key)
+	if err := inspector.Delete(ctx, key); err != nil {
+		return err
+	}
+	if invalidator, ok := c.backing.(cache.Invalidator); ok {
+		if err := invalidator.Invalidate(ctx, key); err != nil {
+			return fmt.Errorf("deleted %s but could not clear it from the memory of other replicas: %w", key, err)
+		}
+	}
+	return nil
+}
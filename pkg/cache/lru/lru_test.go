@@ -0,0 +1,178 @@
+package lru
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+)
+
+type mapCache struct {
+	store map[string][]byte
+	gets  int
+}
+
+func (m *mapCache) Get(_ context.Context, key string, _ time.Duration) ([]byte, error) {
+	m.gets++
+	if content, ok := m.store[key]; ok {
+		return content, nil
+	}
+	return nil, fmt.Errorf("cache miss")
+}
+
+func (m *mapCache) Set(_ context.Context, key string, content []byte, _ time.Duration) error {
+	m.store[key] = content
+	return nil
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	backing := &mapCache{store: map[string][]byte{"a": []byte("aaaa")}}
+	c := NewCache(backing, 80, time.Hour)
+
+	content, err := c.Get(ctx, "a", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa", string(content))
+	_, err = c.Get(ctx, "a", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backing.gets, "second get should be served from memory")
+
+	_, err = c.Get(ctx, "missing", time.Hour)
+	assert.Error(t, err)
+
+	// items are evicted least recently used first once over the size limit
+	for i := 0; i < 10; i++ {
+		require.NoError(t, c.Set(ctx, fmt.Sprintf("k%d", i), []byte("0123456789"), time.Hour))
+	}
+	mem := c.(*Cache)
+	assert.LessOrEqual(t, mem.size, 80)
+	_, ok := mem.get("k0")
+	assert.False(t, ok)
+	_, ok = mem.get("k9")
+	assert.True(t, ok)
+
+	// items too large for the memory tier only go to the backing cache
+	require.NoError(t, c.Set(ctx, "big", make([]byte, 20), time.Hour))
+	_, ok = mem.get("big")
+	assert.False(t, ok)
+	assert.Len(t, backing.store["big"], 20)
+}
+
+func TestCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	backing := &mapCache{store: map[string][]byte{}}
+	c := NewCache(backing, 1000, 10*time.Millisecond).(*Cache)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("first"), time.Hour))
+	backing.store["a"] = []byte("second")
+	content, _ := c.Get(ctx, "a", time.Hour)
+	assert.Equal(t, "first", string(content))
+
+	time.Sleep(20 * time.Millisecond)
+	content, _ = c.Get(ctx, "a", time.Hour)
+	assert.Equal(t, "second", string(content), "items should be refreshed from the backing cache after maxAge")
+}
+
+func (m *mapCache) Keys(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for key := range m.store {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *mapCache) TTL(_ context.Context, key string) (time.Duration, error) {
+	if _, ok := m.store[key]; !ok {
+		return 0, cache.ErrNotFound
+	}
+	return time.Hour, nil
+}
+
+func (m *mapCache) Delete(_ context.Context, key string) error {
+	delete(m.store, key)
+	return nil
+}
+
+type rateLimitingMapCache struct {
+	mapCache
+}
+
+func (r *rateLimitingMapCache) RateLimit(context.Context, string, int, time.Duration) (cache.RateLimitResult, error) {
+	return cache.RateLimitResult{Allowed: true}, nil
+}
+
+func TestCachePassesThroughRateLimiting(t *testing.T) {
+	_, ok := NewCache(&mapCache{}, 100, time.Minute).(cache.RateLimiter)
+	assert.False(t, ok)
+	_, ok = NewCache(&rateLimitingMapCache{}, 100, time.Minute).(cache.RateLimiter)
+	assert.True(t, ok)
+}
+
+func TestCacheDelete(t *testing.T) {
+	ctx := context.Background()
+	backing := &mapCache{store: map[string][]byte{}}
+	c := NewCache(backing, 1000, time.Hour)
+	inspector := c.(cache.Inspector)
+
+	require.NoError(t, c.Set(ctx, "report~a", []byte("a"), time.Hour))
+	require.NoError(t, c.Set(ctx, "other", []byte("b"), time.Hour))
+	keys, err := inspector.Keys(ctx, "report~")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report~a"}, keys)
+
+	require.NoError(t, inspector.Delete(ctx, "report~a"))
+	_, ok := c.(*Cache).get("report~a")
+	assert.False(t, ok, "deleted items should not be served from memory")
+	_, err = inspector.TTL(ctx, "report~a")
+	assert.ErrorIs(t, err, cache.ErrNotFound)
+
+	// only Get and Set are promoted from the embedded interface
+	plain := struct{ cache.Cache }{backing}
+	_, err = NewCache(plain, 100, time.Minute).(cache.Inspector).Keys(ctx, "")
+	assert.ErrorIs(t, err, cache.ErrNotInspectable)
+}
+
+// invalidatingMapCache delivers invalidations to every subscriber, as redis does to each replica.
+type invalidatingMapCache struct {
+	mapCache
+	subscribers []func(key string)
+}
+
+func (m *invalidatingMapCache) Invalidate(_ context.Context, key string) error {
+	for _, drop := range m.subscribers {
+		drop(key)
+	}
+	return nil
+}
+
+func (m *invalidatingMapCache) SubscribeInvalidations(_ context.Context, drop func(key string)) error {
+	m.subscribers = append(m.subscribers, drop)
+	return nil
+}
+
+func TestCacheDeleteInvalidatesOtherReplicas(t *testing.T) {
+	ctx := context.Background()
+	backing := &invalidatingMapCache{mapCache: mapCache{store: map[string][]byte{}}}
+	replica1 := NewCache(backing, 1000, time.Hour)
+	replica2 := NewCache(backing, 1000, time.Hour)
+
+	require.NoError(t, replica1.Set(ctx, "a", []byte("a"), time.Hour))
+	_, err := replica2.Get(ctx, "a", time.Hour)
+	require.NoError(t, err)
+	_, ok := replica2.(*Cache).get("a")
+	require.True(t, ok)
+
+	require.NoError(t, replica1.(cache.Inspector).Delete(ctx, "a"))
+	_, ok = replica2.(*Cache).get("a")
+	assert.False(t, ok, "items deleted on one replica should be dropped from the memory of the others")
+	_, err = replica2.Get(ctx, "a", time.Hour)
+	assert.Error(t, err)
+}
@@ -2,15 +2,23 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	r "gopkg.in/redis.v5"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
 )
 
 const prefix = "_SIPPY_"
 
+// invalidationChannel carries keys deleted by one replica so that the others drop them from memory.
+const invalidationChannel = prefix + "invalidate"
+
 type Cache struct {
 	client *r.Client
 }
@@ -47,3 +55,134 @@ func (c Cache) Set(_ context.Context, key string, content []byte, duration time.
 	}(key, before)
 	return c.client.Set(prefix+key, content, duration).Err()
 }
+
+// rateLimitScript keeps a sorted set of request times per key, scored in milliseconds, so the window
+// slides rather than resetting at fixed intervals. It returns whether the request was allowed, the
+// number of requests in the window and the milliseconds until the oldest of them expires.
+var rateLimitScript = r.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// RateLimit implements cache.RateLimiter with a sliding window shared by every client of the redis server.
+func (c Cache) RateLimit(_ context.Context, key string, limit int, period time.Duration) (cache.RateLimitResult, error) {
+	now := time.Now().UnixMilli()
+	// members must be unique, or concurrent requests in the same millisecond would count once
+	member := fmt.Sprintf("%d-%d", now, rand.Int63()) //nolint:gosec // not used for security
+	res, err := rateLimitScript.Run(c.client, []string{prefix + "ratelimit:" + key}, now, period.Milliseconds(), limit, member).Result()
+	if err != nil {
+		return cache.RateLimitResult{}, err
+	}
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 3 {
+		return cache.RateLimitResult{}, fmt.Errorf("unexpected rate limit script result %v", res)
+	}
+	allowed, _ := values[0].(int64)
+	count, _ := values[1].(int64)
+	reset, _ := values[2].(int64)
+	return cache.RateLimitResult{
+		Allowed:   allowed == 1,
+		Remaining: max(limit-int(count), 0),
+		Reset:     time.Duration(reset) * time.Millisecond,
+	}, nil
+}
+
+// Keys implements cache.Inspector, scanning rather than using KEYS so as not to block redis.
+func (c Cache) Keys(_ context.Context, keyPrefix string) ([]string, error) {
+	match := prefix + escapeGlob(keyPrefix) + "*"
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := c.client.Scan(cursor, match, 1000).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range batch {
+			keys = append(keys, strings.TrimPrefix(key, prefix))
+		}
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// TTL implements cache.Inspector.
+func (c Cache) TTL(_ context.Context, key string) (time.Duration, error) {
+	ttl, err := c.client.TTL(prefix + key).Result()
+	switch {
+	case err != nil:
+		return 0, err
+	case ttl == -2*time.Second:
+		return 0, cache.ErrNotFound
+	case ttl < 0: // no expiry
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// Delete implements cache.Inspector.
+func (c Cache) Delete(_ context.Context, key string) error {
+	return c.client.Del(prefix + key).Err()
+}
+
+// Invalidate implements cache.Invalidator by publishing key to every replica's subscription.
+func (c Cache) Invalidate(_ context.Context, key string) error {
+	return c.client.Publish(invalidationChannel, key).Err()
+}
+
+// SubscribeInvalidations implements cache.Invalidator. Keys are received in the background, the
+// subscription reconnecting if redis goes away, until ctx is done.
+func (c Cache) SubscribeInvalidations(ctx context.Context, drop func(key string)) error {
+	pubsub, err := c.client.Subscribe(invalidationChannel)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		pubsub.Close()
+	}()
+	go func() {
+		for {
+			msg, err := pubsub.ReceiveMessage()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logrus.WithError(err).Warn("error receiving cache invalidation")
+				time.Sleep(time.Second)
+				continue
+			}
+			drop(msg.Payload)
+		}
+	}()
+	return nil
+}
+
+// escapeGlob escapes the characters redis treats specially in MATCH patterns.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -165,6 +165,10 @@ func (j JiraAutomator) getComponentReportForView(view crview.View) (crtype.Compo
 // (c) were reported by the CR JIRA service account
 // Issues will be ordered by creation time
 func (j JiraAutomator) getExistingIssuesForComponent(view crview.View, component JiraComponent) ([]jira.Issue, error) {
+	return j.searchExistingIssues(component, view.SampleRelease.Name)
+}
+
+func (j JiraAutomator) searchExistingIssues(component JiraComponent, affectedVersion string) ([]jira.Issue, error) {
 	searchOptions := jira.SearchOptions{
 		MaxResults: 1,
 		Fields: []string{
@@ -176,7 +180,7 @@ func (j JiraAutomator) getExistingIssuesForComponent(view crview.View, component
 		},
 	}
 	jqlQuery := fmt.Sprintf("project=%s&&component='%s'&&creator='%s'&&affectedVersion=%s&&labels in (%s) ORDER BY createdDate",
-		component.Project, component.Component, j.jiraAccount, view.SampleRelease.Name, jiratype.LabelJiraAutomator)
+		component.Project, component.Component, j.jiraAccount, affectedVersion, jiratype.LabelJiraAutomator)
 	issues, _, err := j.jiraClient.Issue.SearchWithContext(context.Background(), jqlQuery, &searchOptions)
 	return issues, err
 }
@@ -211,34 +215,31 @@ func isReleaseBlockerApproved(existing *jira.Issue) bool {
 	return false
 }
 
-// updateExistingJiraIssue updates existing issue by
+// updateExistingJiraIssue plans the update of an existing issue:
 // a. adding a new comment containing a CR link with the most recently analyzed time window where the regression is still manifesting.
 // b. if pre-release, label the ticket as a Release Blocker if someone removed it
-func (j JiraAutomator) updateExistingJiraIssue(view crview.View, existing *jira.Issue) error {
+func (j JiraAutomator) updateExistingJiraIssue(view crview.View, existing *jira.Issue) ([]Action, error) {
 	absURL, _, err := j.getComponentReadinessURLsForView(view)
 	if err != nil {
-		return err
-	}
-	comment := fmt.Sprintf(`This bug is still seen in component readiness. Here is [the current link|%s] for your convenience`, absURL)
-
-	if j.dryRun {
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
-		fmt.Fprintf(os.Stdout, "\nRunning in DRY RUN mode!\n")
-		fmt.Fprintf(os.Stdout, "\nUpdating issue %s with comment\n%s", existing.ID, comment)
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
-	} else {
-		_, _, err = j.jiraClient.Issue.AddComment(existing.ID, &jira.Comment{Body: comment})
-		if err != nil {
-			return err
-		}
+		return nil, err
 	}
+	actions := []Action{{
+		Type:    ActionComment,
+		Issue:   existing.Key,
+		Comment: fmt.Sprintf(`This bug is still seen in component readiness. Here is [the current link|%s] for your convenience`, absURL),
+		Reason:  "regressions are still seen",
+	}}
 
 	// Set Release Blocker
-	if !isReleaseBlockerApproved(existing) {
-		return j.updateReleaseBlocker(existing, view.SampleRelease.Name)
+	if !isReleaseBlockerApproved(existing) && j.isPreRelease(view.SampleRelease.Name) {
+		actions = append(actions, Action{
+			Type:   ActionReleaseBlocker,
+			Issue:  existing.Key,
+			Reason: "release blocker is not approved for a pre-release",
+		})
 	}
 
-	return nil
+	return actions, nil
 }
 
 // getComponentReadinessURLsForView generates two URL, one with absolute timing params at this moment this is called
@@ -306,12 +307,12 @@ func (j JiraAutomator) getComponentReadinessURLsForView(view crview.View) (strin
 	return absURL, viewURL, nil
 }
 
-// createNewJiraIssueForRegressions creates new issue for components by
+// createNewJiraIssueForRegressions plans a new issue for components by
 // a. setting the ticket's Affects Version/s= sample version.
 // b  adding the Regression label defined by LabelJiraAutomator.
 // c. setting a description with links to CR
 // d. for pre-release, setting "Release Blocker" label to Approved
-func (j JiraAutomator) createNewJiraIssueForRegressions(view crview.View, component JiraComponent, tests []crtype.ReportTestSummary, linkedIssue *jira.Issue) error {
+func (j JiraAutomator) createNewJiraIssueForRegressions(view crview.View, component JiraComponent, tests []crtype.ReportTestSummary, linkedIssue *jira.Issue, reason string) ([]Action, error) {
 	if len(tests) > 0 {
 		description := `Component Readiness has found a potential regression in the following tests:`
 		for _, test := range tests {
@@ -325,7 +326,7 @@ func (j JiraAutomator) createNewJiraIssueForRegressions(view crview.View, compon
 
 		absURL, viewURL, err := j.getComponentReadinessURLsForView(view)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		description += "\n h4. Useful Component Readiness Links:\n"
 		description += "\nWe are proving the following two links for your convenience:\n"
@@ -342,48 +343,32 @@ func (j JiraAutomator) createNewJiraIssueForRegressions(view crview.View, compon
 		fileBugRequest := util.FileBugRequest{Description: description, Summary: summary, Components: []string{component.Component}, AffectsVersions: []string{view.SampleRelease.Name}, Labels: []string{jiratype.LabelJiraAutomator}, Project: component.Project}
 		issue, err := util.PopulateJiraIssue(j.jiraClient, fileBugRequest, "")
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		if !j.dryRun {
-			created, _, err := j.jiraClient.Issue.Create(&issue)
-			if err != nil {
-				return err
-			}
-			// Set Release Blocker field. Jira does not allow setting those during creation. So do it in separate step.
-			return j.updateReleaseBlocker(created, view.SampleRelease.Name)
-		}
-		issueStr, err := json.MarshalIndent(issue, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
-		if j.dryRun {
-			fmt.Fprintf(os.Stdout, "\nRunning in DRY RUN mode!\n")
-		}
-		fmt.Fprintf(os.Stdout, "Creating the following jira issue\n%s", issueStr)
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
+		return []Action{{
+			Type:     ActionCreate,
+			NewIssue: &issue,
+			// Jira does not allow setting the Release Blocker field during creation, so it is set in a separate step.
+			ReleaseBlocker: j.isPreRelease(view.SampleRelease.Name),
+			Reason:         reason,
+		}}, nil
 	}
-	return nil
+	return nil, nil
 }
 
-func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crview.View, component JiraComponent, tests []crtype.ReportTestSummary) error {
+func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crview.View, component JiraComponent, tests []crtype.ReportTestSummary) ([]Action, error) {
 	switch issue.Fields.Status.Name {
 	case jiratype.StatusNew, jiratype.StatusInProgress, jiratype.StatusAssigned, jiratype.StatusModified:
 		// New/Assigned/In Progress/Modified
-		err := j.updateExistingJiraIssue(view, &issue)
-		if err != nil {
-			return err
-		}
+		return j.updateExistingJiraIssue(view, &issue)
 	case jiratype.StatusOnQA, jiratype.StatusVerified, jiratype.StatusClosed:
 		// QA/Verified/Closed
 		resolutionDate := time.Time(issue.Fields.Resolutiondate)
 		if view.SampleRelease.Start.After(resolutionDate) {
 			// Existing issue does not cover current regression
-			err := j.createNewJiraIssueForRegressions(view, component, tests, nil)
-			if err != nil {
-				return err
-			}
+			return j.createNewJiraIssueForRegressions(view, component, tests, nil,
+				fmt.Sprintf("%s was resolved before the sample started", issue.Key))
 		} else {
 			// Overlap between current analysis and jira card fix. Do two more analysis:
 			// a. Scope Check: Run with a sample start date of resolutionDate-2 weeks and end resolutionDate.
@@ -396,7 +381,7 @@ func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crvi
 			scopeView.SampleRelease.RelativeEnd = resolutionDate.Format(time.RFC3339)
 			scopeReport, err := j.getComponentReportForView(scopeView)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Identify tests only appearing in current report, not scope report
@@ -409,7 +394,7 @@ func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crvi
 						}
 						columnKeyBytes, err := json.Marshal(test.ColumnIdentification)
 						if err != nil {
-							return err
+							return nil, err
 						}
 						scopeRegressedTests[test.RowIdentification][crtest.ColumnID(columnKeyBytes)] = test
 					}
@@ -419,7 +404,7 @@ func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crvi
 			for _, test := range tests {
 				columnKeyBytes, err := json.Marshal(test.ColumnIdentification)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				_, ok := scopeRegressedTests[test.RowIdentification]
 				if !ok {
@@ -430,10 +415,8 @@ func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crvi
 			}
 			if len(newTests) > 0 {
 				// Any tests not covered by scope check is considered new
-				err := j.createNewJiraIssueForRegressions(view, component, newTests, &issue)
-				if err != nil {
-					return err
-				}
+				return j.createNewJiraIssueForRegressions(view, component, newTests, &issue,
+					fmt.Sprintf("tests regressed that %s did not cover", issue.Key))
 			} else if resolutionDate.Add(fixCheckWaitPeriod).Before(view.SampleRelease.End) {
 				// This means scope report contains all tests from current report, verify fix
 				fixView := view
@@ -441,46 +424,92 @@ func (j JiraAutomator) updateJiraIssueForRegressions(issue jira.Issue, view crvi
 				fixView.SampleRelease.RelativeStart = resolutionDate.Format(time.RFC3339)
 				fixReport, err := j.getComponentReportForView(fixView)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				regressedTests, err := j.groupRegressedTestsByComponents(fixReport)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				if tests, ok := regressedTests[component]; ok {
-					err := j.createNewJiraIssueForRegressions(fixView, component, tests, nil)
-					if err != nil {
-						return err
-					}
+					return j.createNewJiraIssueForRegressions(fixView, component, tests, nil,
+						fmt.Sprintf("tests are still regressed since %s was resolved", issue.Key))
 				}
 			}
 		}
 	}
-	return nil
+	return nil, nil
 }
 
-func (j JiraAutomator) updateReleaseBlocker(issue *jira.Issue, release string) error {
+func (j JiraAutomator) updateReleaseBlocker(key string) error {
 	// CustomFieldReleaseBlockerName will need to be updated when migrating to atlassian cloud
-	if j.isPreRelease(release) {
-		unknowns := tcontainer.NewMarshalMap()
-		unknowns[jiratype.CustomFieldReleaseBlockerName] = map[string]string{"value": jiratype.CustomFieldReleaseBlockerValueApproved}
-		issue := jira.Issue{
-			Key: issue.Key,
-			Fields: &jira.IssueFields{
-				Unknowns: unknowns,
-			},
+	unknowns := tcontainer.NewMarshalMap()
+	unknowns[jiratype.CustomFieldReleaseBlockerName] = map[string]string{"value": jiratype.CustomFieldReleaseBlockerValueApproved}
+	issue := jira.Issue{
+		Key: key,
+		Fields: &jira.IssueFields{
+			Unknowns: unknowns,
+		},
+	}
+	_, _, err := j.jiraClient.Issue.Update(&issue)
+	return err
+}
+
+// execute performs the actions in Jira, stopping at the first that fails. In dry run mode, the
+// actions are printed instead.
+func (j JiraAutomator) execute(actions []Action) error {
+	for _, action := range actions {
+		if j.dryRun {
+			if err := printAction(action); err != nil {
+				return err
+			}
+			continue
+		}
+		switch action.Type {
+		case ActionCreate:
+			created, _, err := j.jiraClient.Issue.Create(action.NewIssue)
+			if err != nil {
+				return err
+			}
+			log.WithField("issue", created.Key).Info("created jira issue")
+			if action.ReleaseBlocker {
+				if err := j.updateReleaseBlocker(created.Key); err != nil {
+					return err
+				}
+			}
+		case ActionComment:
+			if _, _, err := j.jiraClient.Issue.AddComment(action.Issue, &jira.Comment{Body: action.Comment}); err != nil {
+				return err
+			}
+		case ActionReleaseBlocker:
+			if err := j.updateReleaseBlocker(action.Issue); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown jira action %q", action.Type)
 		}
-		if !j.dryRun {
-			_, _, err := j.jiraClient.Issue.Update(&issue)
+	}
+	return nil
+}
+
+func printAction(action Action) error {
+	fmt.Fprintf(os.Stdout, "\n====================================================================\n")
+	fmt.Fprintf(os.Stdout, "\nRunning in DRY RUN mode!\n")
+	switch action.Type {
+	case ActionCreate:
+		issueStr, err := json.MarshalIndent(action.NewIssue, "", "  ")
+		if err != nil {
 			return err
 		}
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
-		if j.dryRun {
-			fmt.Fprintf(os.Stdout, "\nRunning in DRY RUN mode!\n")
+		fmt.Fprintf(os.Stdout, "Creating the following jira issue\n%s", issueStr)
+		if action.ReleaseBlocker {
+			fmt.Fprintf(os.Stdout, "\nUpdating Release Blocker for the created issue")
 		}
-		fmt.Fprintf(os.Stdout, "Updating Release Blocker for %s", issue.ID)
-		fmt.Fprintf(os.Stdout, "\n====================================================================\n")
+	case ActionComment:
+		fmt.Fprintf(os.Stdout, "\nUpdating issue %s with comment\n%s", action.Issue, action.Comment)
+	case ActionReleaseBlocker:
+		fmt.Fprintf(os.Stdout, "Updating Release Blocker for %s", action.Issue)
 	}
+	fmt.Fprintf(os.Stdout, "\n====================================================================\n")
 	return nil
 }
 
@@ -550,7 +579,9 @@ func (j JiraAutomator) groupRegressedTestsByComponents(report crtype.ComponentRe
 	return componentRegressedTests, nil
 }
 
-func (j JiraAutomator) automateJirasForView(view crview.View) error {
+// planView computes the actions for each component with regressed tests in the view.
+func (j JiraAutomator) planView(view crview.View) (ViewPlan, error) {
+	viewPlan := ViewPlan{View: view.Name, SampleRelease: view.SampleRelease.Name, Components: []ComponentPlan{}}
 
 	logger := log.WithField("view", view.Name)
 	logger.Info("automate jiras for view")
@@ -558,48 +589,89 @@ func (j JiraAutomator) automateJirasForView(view crview.View) error {
 	report, err := j.getComponentReportForView(view)
 	if err != nil {
 		logger.WithError(err).Error("error getting report for view")
-		return err
+		return viewPlan, err
 	}
 
 	componentRegressedTests, err := j.groupRegressedTestsByComponents(report)
 	if err != nil {
 		logger.WithError(err).Error("error getting regressed tests from report")
-		return err
+		return viewPlan, err
 	}
 	for component, tests := range componentRegressedTests {
 		// fetch jira bugs
 		if j.includeComponents.Len() > 0 && !j.includeComponents.Has(component.Project+":"+component.Component) {
 			continue
 		}
+		componentPlan := ComponentPlan{Project: component.Project, Component: component.Component, Actions: []Action{}}
+		for _, test := range tests {
+			componentPlan.RegressedTests = append(componentPlan.RegressedTests, test.TestName)
+		}
 		issues, err := j.getExistingIssuesForComponent(view, component)
 		if err != nil {
 			log.WithError(err).Error("error getting existing jira issues")
 		}
 
+		var actions []Action
 		// No existing issues, create new one
 		if len(issues) == 0 {
-			err := j.createNewJiraIssueForRegressions(view, component, tests, nil)
+			actions, err = j.createNewJiraIssueForRegressions(view, component, tests, nil, "no automator issue exists")
 			if err != nil {
 				log.WithError(err).Error("error creating jira issue")
 			}
 		} else {
 			selected := issues[0]
-			err := j.updateJiraIssueForRegressions(selected, view, component, tests)
+			componentPlan.ExistingIssue = selected.Key
+			componentPlan.ExistingStatus = issueStatus(selected)
+			actions, err = j.updateJiraIssueForRegressions(selected, view, component, tests)
 			if err != nil {
 				log.WithError(err).Error("error updating jira issue")
 			}
 		}
+		if err != nil {
+			componentPlan.Error = err.Error()
+		}
+		componentPlan.Actions = append(componentPlan.Actions, actions...)
+		viewPlan.Components = append(viewPlan.Components, componentPlan)
 	}
-	return nil
+	sort.Slice(viewPlan.Components, func(a, b int) bool {
+		if viewPlan.Components[a].Project != viewPlan.Components[b].Project {
+			return viewPlan.Components[a].Project < viewPlan.Components[b].Project
+		}
+		return viewPlan.Components[a].Component < viewPlan.Components[b].Component
+	})
+	return viewPlan, nil
+}
+
+// Plan computes every action Run would take for each view, without changing anything in Jira.
+func (j JiraAutomator) Plan() (Plan, error) {
+	log.Infof("Start planning jiras for component readiness regressions")
+	plan := Plan{CreatedAt: time.Now().UTC(), JiraAccount: j.jiraAccount, Views: []ViewPlan{}}
+	for _, view := range j.views {
+		viewPlan, err := j.planView(view)
+		if err != nil {
+			return plan, err
+		}
+		plan.Views = append(plan.Views, viewPlan)
+	}
+	log.Infof("Done planning jiras for component readiness regressions")
+	return plan, nil
 }
 
 func (j JiraAutomator) Run() error {
 	log.Infof("Start automating jiras for component readiness regressions")
 	for _, view := range j.views {
-		err := j.automateJirasForView(view)
+		viewPlan, err := j.planView(view)
 		if err != nil {
 			return err
 		}
+		for _, component := range viewPlan.Components {
+			if err := j.execute(component.Actions); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"view":      view.Name,
+					"component": component.Component,
+				}).Error("error automating jira issue")
+			}
+		}
 	}
 	log.Infof("Done automating jiras for component readiness regressions")
 	return nil
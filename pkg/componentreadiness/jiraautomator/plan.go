@@ -0,0 +1,236 @@
+package jiraautomator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness/export"
+)
+
+// ActionType is a kind of change the automator makes in Jira.
+type ActionType string
+
+const (
+	// ActionCreate files a new issue for regressed tests of a component.
+	ActionCreate ActionType = "create"
+	// ActionComment links an open issue to the current report, which is how the automator updates
+	// issues whose regressions are still seen.
+	ActionComment ActionType = "comment"
+	// ActionReleaseBlocker approves an existing issue as a release blocker.
+	ActionReleaseBlocker ActionType = "release_blocker"
+)
+
+// Action is a change the automator intends to make in Jira.
+type Action struct {
+	Type ActionType `json:"type"`
+	// Issue is the key of the issue acted on, empty for ActionCreate.
+	Issue string `json:"issue,omitempty"`
+	// NewIssue is the issue filed by ActionCreate.
+	NewIssue *jira.Issue `json:"new_issue,omitempty"`
+	// ReleaseBlocker is set if the issue filed by ActionCreate is to be approved as a release
+	// blocker, which Jira does not allow during creation.
+	ReleaseBlocker bool   `json:"release_blocker,omitempty"`
+	Comment        string `json:"comment,omitempty"`
+	// Reason is why the automator intends the action.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Plan is every action the automator intends to take for its views, computed without changing
+// anything in Jira so that it can be reviewed before it is applied.
+type Plan struct {
+	CreatedAt time.Time `json:"created_at"`
+	// JiraAccount is the account that files automator issues, which are looked up again on apply.
+	JiraAccount string     `json:"jira_account"`
+	Views       []ViewPlan `json:"views"`
+}
+
+// ViewPlan is the intended actions for the components regressed in a view.
+type ViewPlan struct {
+	View string `json:"view"`
+	// SampleRelease is the Affects Version of the automator issues of the view.
+	SampleRelease string          `json:"sample_release"`
+	Components    []ComponentPlan `json:"components"`
+}
+
+// ComponentPlan is the intended actions for the regressed tests of a Jira component.
+type ComponentPlan struct {
+	Project        string   `json:"project"`
+	Component      string   `json:"component"`
+	RegressedTests []string `json:"regressed_tests"`
+	// ExistingIssue and ExistingStatus are the automator issue found for the component when
+	// planning, empty if there was none. Apply skips the component if they have since changed.
+	ExistingIssue  string   `json:"existing_issue,omitempty"`
+	ExistingStatus string   `json:"existing_status,omitempty"`
+	Actions        []Action `json:"actions"`
+	// Error is why actions could not be planned for the component, which is otherwise left out.
+	Error string `json:"error,omitempty"`
+}
+
+func issueStatus(issue jira.Issue) string {
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return ""
+	}
+	return issue.Fields.Status.Name
+}
+
+// changedSince returns why the automator issues now found for a component differ from those found
+// when it was planned, or an empty string if they do not.
+func changedSince(planned ComponentPlan, current []jira.Issue) string {
+	switch {
+	case len(current) == 0 && planned.ExistingIssue == "":
+		return ""
+	case len(current) == 0:
+		return fmt.Sprintf("%s is no longer an automator issue for the component", planned.ExistingIssue)
+	case planned.ExistingIssue == "":
+		return fmt.Sprintf("%s has been filed for the component", current[0].Key)
+	case current[0].Key != planned.ExistingIssue:
+		return fmt.Sprintf("%s is the automator issue for the component instead of %s", current[0].Key, planned.ExistingIssue)
+	case issueStatus(current[0]) != planned.ExistingStatus:
+		return fmt.Sprintf("%s changed status from %s to %s", planned.ExistingIssue, planned.ExistingStatus, issueStatus(current[0]))
+	}
+	return ""
+}
+
+// ApplyResult is the outcome of applying the actions planned for a component.
+type ApplyResult struct {
+	View      string `json:"view"`
+	Project   string `json:"project"`
+	Component string `json:"component"`
+	Applied   int    `json:"applied"`
+	// Skipped is why planned actions were not applied.
+	Skipped []string `json:"skipped,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// NewJiraPlanApplier creates an automator that only applies saved plans, which does not need the
+// component reports the plans were computed from.
+func NewJiraPlanApplier(jiraClient *jira.Client, dryRun bool) JiraAutomator {
+	return JiraAutomator{jiraClient: jiraClient, dryRun: dryRun}
+}
+
+// Apply executes a previously saved plan. The automator issue of each component is looked up again
+// first, and the component's actions are skipped if it has changed since the plan was made. A
+// release blocker change is also skipped if the issue has been approved in the meantime.
+func (j JiraAutomator) Apply(plan Plan) []ApplyResult {
+	j.jiraAccount = plan.JiraAccount
+	results := []ApplyResult{}
+	for _, view := range plan.Views {
+		for _, component := range view.Components {
+			if len(component.Actions) == 0 {
+				continue
+			}
+			result := j.applyComponent(view, component)
+			logger := log.WithFields(log.Fields{
+				"view":      result.View,
+				"project":   result.Project,
+				"component": result.Component,
+				"applied":   result.Applied,
+			})
+			switch {
+			case result.Error != "":
+				logger.WithField("error", result.Error).Error("error applying jira plan")
+			case len(result.Skipped) > 0:
+				logger.WithField("skipped", result.Skipped).Warn("skipped jira plan actions")
+			default:
+				logger.Info("applied jira plan")
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func (j JiraAutomator) applyComponent(view ViewPlan, component ComponentPlan) ApplyResult {
+	result := ApplyResult{View: view.View, Project: component.Project, Component: component.Component}
+	current, err := j.searchExistingIssues(JiraComponent{Project: component.Project, Component: component.Component}, view.SampleRelease)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if reason := changedSince(component, current); reason != "" {
+		result.Skipped = append(result.Skipped, reason)
+		return result
+	}
+
+	actions := make([]Action, 0, len(component.Actions))
+	for _, action := range component.Actions {
+		if action.Type == ActionReleaseBlocker && len(current) > 0 && isReleaseBlockerApproved(&current[0]) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s has already been approved as a release blocker", action.Issue))
+			continue
+		}
+		actions = append(actions, action)
+	}
+	if err := j.execute(actions); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Applied = len(actions)
+	return result
+}
+
+// WriteMarkdown writes a summary of the plan for review, with a table of the intended actions for
+// each view.
+func (p Plan) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Jira automator plan\n\nCreated %s for Jira account `%s`.\n",
+		p.CreatedAt.UTC().Format(time.RFC3339), p.JiraAccount)
+	for _, view := range p.Views {
+		fmt.Fprintf(&sb, "\n## %s\n\n", view.View)
+		if len(view.Components) == 0 {
+			sb.WriteString("No regressed components.\n")
+			continue
+		}
+		sb.WriteString("| Project | Component | Existing issue | Regressed tests | Actions |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, component := range view.Components {
+			existing := "none"
+			if component.ExistingIssue != "" {
+				existing = fmt.Sprintf("%s (%s)", component.ExistingIssue, component.ExistingStatus)
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %d | %s |\n",
+				export.MarkdownCell(component.Project), export.MarkdownCell(component.Component), export.MarkdownCell(existing),
+				len(component.RegressedTests), export.MarkdownCell(describeActions(component)))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func describeActions(component ComponentPlan) string {
+	if component.Error != "" {
+		return "error: " + component.Error
+	}
+	if len(component.Actions) == 0 {
+		return "none"
+	}
+	descriptions := make([]string, 0, len(component.Actions))
+	for _, action := range component.Actions {
+		var description string
+		switch action.Type {
+		case ActionCreate:
+			description = "create issue"
+			if action.NewIssue != nil && action.NewIssue.Fields != nil {
+				description = fmt.Sprintf("create %q", action.NewIssue.Fields.Summary)
+			}
+			if action.ReleaseBlocker {
+				description += " as release blocker"
+			}
+		case ActionComment:
+			description = "comment on " + action.Issue
+		case ActionReleaseBlocker:
+			description = "approve " + action.Issue + " as release blocker"
+		default:
+			description = string(action.Type)
+		}
+		if action.Reason != "" {
+			description += " (" + action.Reason + ")"
+		}
+		descriptions = append(descriptions, description)
+	}
+	return strings.Join(descriptions, "; ")
+}
@@ -0,0 +1,148 @@
+package jiraautomator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueWithStatus(key, status string) jira.Issue {
+	return jira.Issue{Key: key, Fields: &jira.IssueFields{Status: &jira.Status{Name: status}}}
+}
+
+func TestChangedSince(t *testing.T) {
+	tests := []struct {
+		name    string
+		planned ComponentPlan
+		current []jira.Issue
+		changed string
+	}{
+		{
+			name:    "still no issue",
+			planned: ComponentPlan{},
+		},
+		{
+			name:    "same issue and status",
+			planned: ComponentPlan{ExistingIssue: "OCPBUGS-1", ExistingStatus: "New"},
+			current: []jira.Issue{issueWithStatus("OCPBUGS-1", "New")},
+		},
+		{
+			name:    "issue filed since",
+			planned: ComponentPlan{},
+			current: []jira.Issue{issueWithStatus("OCPBUGS-2", "New")},
+			changed: "OCPBUGS-2 has been filed for the component",
+		},
+		{
+			name:    "issue gone",
+			planned: ComponentPlan{ExistingIssue: "OCPBUGS-1", ExistingStatus: "New"},
+			changed: "OCPBUGS-1 is no longer an automator issue for the component",
+		},
+		{
+			name:    "different issue",
+			planned: ComponentPlan{ExistingIssue: "OCPBUGS-1", ExistingStatus: "New"},
+			current: []jira.Issue{issueWithStatus("OCPBUGS-2", "New")},
+			changed: "OCPBUGS-2 is the automator issue for the component instead of OCPBUGS-1",
+		},
+		{
+			name:    "status changed",
+			planned: ComponentPlan{ExistingIssue: "OCPBUGS-1", ExistingStatus: "New"},
+			current: []jira.Issue{issueWithStatus("OCPBUGS-1", "Closed")},
+			changed: "OCPBUGS-1 changed status from New to Closed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.changed, changedSince(tc.planned, tc.current))
+		})
+	}
+}
+
+func testPlan() Plan {
+	return Plan{
+		CreatedAt:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		JiraAccount: "sippy-bot",
+		Views: []ViewPlan{
+			{
+				View:          "4.19-main",
+				SampleRelease: "4.19",
+				Components: []ComponentPlan{
+					{
+						Project:        "OCPBUGS",
+						Component:      "Networking / ovn-kubernetes",
+						RegressedTests: []string{"test a", "test b"},
+						Actions: []Action{{
+							Type: ActionCreate,
+							NewIssue: &jira.Issue{Fields: &jira.IssueFields{
+								Summary: "Component Readiness: Networking / ovn-kubernetes regressed",
+								Labels:  []string{"component-regression"},
+							}},
+							ReleaseBlocker: true,
+							Reason:         "no automator issue exists",
+						}},
+					},
+					{
+						Project:        "OCPBUGS",
+						Component:      "Storage",
+						RegressedTests: []string{"test c"},
+						ExistingIssue:  "OCPBUGS-1",
+						ExistingStatus: "New",
+						Actions: []Action{
+							{Type: ActionComment, Issue: "OCPBUGS-1", Comment: "still seen", Reason: "regressions are still seen"},
+							{Type: ActionReleaseBlocker, Issue: "OCPBUGS-1"},
+						},
+					},
+					{
+						Project:        "OCPBUGS",
+						Component:      "etcd",
+						RegressedTests: []string{"test d"},
+						ExistingIssue:  "OCPBUGS-2",
+						ExistingStatus: "ON_QA",
+						Actions:        []Action{},
+					},
+				},
+			},
+			{View: "4.18-main", SampleRelease: "4.18", Components: []ComponentPlan{}},
+		},
+	}
+}
+
+func TestPlanWriteMarkdown(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, testPlan().WriteMarkdown(&sb))
+	md := sb.String()
+
+	assert.Contains(t, md, "Created 2025-03-01T12:00:00Z for Jira account `sippy-bot`.")
+	assert.Contains(t, md, "## 4.19-main")
+	assert.Contains(t, md, `| OCPBUGS | Networking / ovn-kubernetes | none | 2 | create "Component Readiness: Networking / ovn-kubernetes regressed" as release blocker (no automator issue exists) |`)
+	assert.Contains(t, md, "| OCPBUGS | Storage | OCPBUGS-1 (New) | 1 | comment on OCPBUGS-1 (regressions are still seen); approve OCPBUGS-1 as release blocker |")
+	assert.Contains(t, md, "| OCPBUGS | etcd | OCPBUGS-2 (ON_QA) | 1 | none |")
+	assert.Contains(t, md, "## 4.18-main\n\nNo regressed components.")
+}
+
+func TestPlanRoundTrip(t *testing.T) {
+	data, err := json.Marshal(testPlan())
+	require.NoError(t, err)
+
+	var plan Plan
+	require.NoError(t, json.Unmarshal(data, &plan))
+	assert.Equal(t, "sippy-bot", plan.JiraAccount)
+	require.Len(t, plan.Views, 2)
+	created := plan.Views[0].Components[0].Actions[0]
+	assert.Equal(t, ActionCreate, created.Type)
+	assert.True(t, created.ReleaseBlocker)
+	require.NotNil(t, created.NewIssue)
+	assert.Equal(t, "Component Readiness: Networking / ovn-kubernetes regressed", created.NewIssue.Fields.Summary)
+	assert.Equal(t, []string{"component-regression"}, created.NewIssue.Fields.Labels)
+
+	// the issue created from a loaded plan is the one that was planned
+	planned, err := json.Marshal(testPlan().Views[0].Components[0].Actions[0].NewIssue)
+	require.NoError(t, err)
+	loaded, err := json.Marshal(created.NewIssue)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(planned), string(loaded))
+}
@@ -0,0 +1,213 @@
+package jobrunannotator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crstatus"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/apis/cache"
+	bqclient "github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+)
+
+// DefaultCampaignSettleTime is how long after a job run starts before campaigns scan it, so that
+// it has finished and its results have been loaded into BigQuery.
+const DefaultCampaignSettleTime = 12 * time.Hour
+
+// ParseVariants parses key:value variant strings, e.g. Platform:metal, checking that each is one
+// of allVariants.
+func ParseVariants(variantStrs []string, allVariants crtest.JobVariants) ([]crstatus.Variant, error) {
+	variants := make([]crstatus.Variant, 0, len(variantStrs))
+	for _, variantStr := range variantStrs {
+		vt := strings.Split(variantStr, ":")
+		if len(vt) != 2 {
+			return nil, fmt.Errorf("variant %s is in wrong format", variantStr)
+		}
+		vs, ok := allVariants.Variants[vt[0]]
+		if !ok {
+			return nil, fmt.Errorf("variant %s has wrong variant name %s", variantStr, vt[0])
+		}
+		found := false
+		for _, v := range vs {
+			if v == vt[1] {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("variant %s has wrong variant value %s", variantStr, vt[1])
+		}
+		variants = append(variants, crstatus.Variant{Key: vt[0], Value: vt[1]})
+	}
+	return variants, nil
+}
+
+// CampaignRunner runs annotation campaigns over the job runs started since they last ran.
+type CampaignRunner struct {
+	bqClient     *bqclient.Client
+	cacheOptions cache.RequestOptions
+	gcsClient    *storage.Client
+	dbClient     *db.DB
+	cache        cache.Cache
+	execute      bool
+	settleTime   time.Duration
+}
+
+// NewCampaignRunner creates a campaign runner. Unless execute is set, campaigns are run in dry
+// run mode, which only logs the labels that would be written and does not record progress.
+func NewCampaignRunner(
+	bqClient *bqclient.Client,
+	cacheOptions cache.RequestOptions,
+	gcsClient *storage.Client,
+	dbClient *db.DB,
+	cacheClient cache.Cache,
+	execute bool,
+	settleTime time.Duration,
+) *CampaignRunner {
+	return &CampaignRunner{
+		bqClient:     bqClient,
+		cacheOptions: cacheOptions,
+		gcsClient:    gcsClient,
+		dbClient:     dbClient,
+		cache:        cacheClient,
+		execute:      execute,
+		settleTime:   settleTime,
+	}
+}
+
+// ListActiveCampaigns returns the campaigns that are unpaused and have not reached their end time.
+func ListActiveCampaigns(dbc *gorm.DB) ([]jobrunscan.AnnotationCampaign, error) {
+	var campaigns []jobrunscan.AnnotationCampaign
+	res := dbc.Where("paused = ?", false).
+		Where("end_time IS NULL OR scanned_through < end_time").
+		Order("id").Find(&campaigns)
+	if res.Error != nil {
+		return nil, fmt.Errorf("error listing active annotation campaigns: %w", res.Error)
+	}
+	return campaigns, nil
+}
+
+// campaignWindow returns the start times of the job runs the campaign has yet to scan, up to
+// settleTime before now. ok is false if there are none.
+func campaignWindow(campaign jobrunscan.AnnotationCampaign, now time.Time, settleTime time.Duration) (start, end time.Time, ok bool) {
+	start = campaign.ScannedThrough
+	if start.Before(campaign.StartTime) {
+		start = campaign.StartTime
+	}
+	end = now.Add(-settleTime).Truncate(time.Second)
+	if campaign.EndTime != nil && campaign.EndTime.Before(end) {
+		end = *campaign.EndTime
+	}
+	return start, end, end.After(start)
+}
+
+// Run labels the job runs of the campaign started since it last ran, and records its progress.
+// allVariants are the variants the campaign's variants are checked against.
+func (r *CampaignRunner) Run(ctx context.Context, campaign jobrunscan.AnnotationCampaign, allVariants crtest.JobVariants) (Result, error) {
+	logger := log.WithFields(log.Fields{"campaign": campaign.ID, "name": campaign.Name})
+	now := time.Now()
+	start, end, ok := campaignWindow(campaign, now, r.settleTime)
+	if !ok {
+		logger.Debug("no new job runs to scan for annotation campaign")
+		return Result{}, nil
+	}
+	logger.Infof("running annotation campaign over job runs started from %s to %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+
+	result, err := r.run(ctx, campaign, allVariants, start, end)
+	if !r.execute {
+		return result, err
+	}
+	if recordErr := r.recordProgress(ctx, campaign, result, err, end, now); recordErr != nil {
+		logger.WithError(recordErr).Error("error recording annotation campaign progress")
+		if err == nil {
+			err = recordErr
+		}
+	}
+	return result, err
+}
+
+func (r *CampaignRunner) run(ctx context.Context, campaign jobrunscan.AnnotationCampaign, allVariants crtest.JobVariants, start, end time.Time) (Result, error) {
+	variants, err := ParseVariants(campaign.Variants, allVariants)
+	if err != nil {
+		return Result{}, err
+	}
+	j, err := NewJobRunAnnotator(
+		r.bqClient,
+		r.cacheOptions,
+		r.gcsClient,
+		r.dbClient,
+		r.cache,
+		r.execute,
+		campaign.Release,
+		allVariants,
+		variants,
+		campaign.Label,
+		campaign.BuildClusters,
+		start,
+		end.Sub(start),
+		campaign.MinFailures,
+		campaign.FlakeAsFailure,
+		campaign.TextContains,
+		campaign.TextRegex,
+		campaign.PathGlob,
+		nil,
+		campaign.Comment,
+		campaign.CreatedBy)
+	if err != nil {
+		return Result{}, err
+	}
+	// results of runs started near the end of the window are loaded up to settleTime later
+	j.junitLookahead = r.settleTime
+	j.campaignID = campaign.ID
+	return j.annotate(ctx)
+}
+
+// recordProgress adds the result of a successful run to the campaign's counts and moves it on to
+// the end of the window, or records why the run failed. The update is conditional on the campaign
+// not having been moved on by another runner in the meantime, so that job runs are counted once.
+func (r *CampaignRunner) recordProgress(ctx context.Context, campaign jobrunscan.AnnotationCampaign, result Result, runErr error, end, now time.Time) error {
+	q := r.dbClient.DB.WithContext(ctx).Model(&jobrunscan.AnnotationCampaign{}).
+		Where("id = ? AND scanned_through = ?", campaign.ID, campaign.ScannedThrough)
+	var res *gorm.DB
+	if runErr != nil {
+		res = q.Updates(map[string]interface{}{
+			"last_run_at": now,
+			"last_error":  runErr.Error(),
+		})
+	} else {
+		res = q.Updates(map[string]interface{}{
+			"scanned_through": end,
+			"runs_scanned":    gorm.Expr("runs_scanned + ?", result.Scanned),
+			"runs_matched":    gorm.Expr("runs_matched + ?", result.Matched),
+			"runs_labeled":    gorm.Expr("runs_labeled + ?", result.Labeled),
+			"last_run_at":     now,
+			"last_error":      "",
+		})
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		log.WithField("campaign", campaign.ID).Warn("annotation campaign was run concurrently, not recording progress")
+	}
+	return nil
+}
+
+// GetCampaign returns the campaign with the given ID, or nil if there is none.
+func GetCampaign(dbc *gorm.DB, id uint) (*jobrunscan.AnnotationCampaign, error) {
+	var campaigns []jobrunscan.AnnotationCampaign
+	if err := dbc.Where("id = ?", id).Limit(1).Find(&campaigns).Error; err != nil {
+		return nil, fmt.Errorf("error looking up annotation campaign %d: %w", id, err)
+	}
+	if len(campaigns) == 0 {
+		return nil, nil
+	}
+	return &campaigns[0], nil
+}
@@ -0,0 +1,59 @@
+package jobrunannotator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crstatus"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
+)
+
+func TestCampaignWindow(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 10, 12, 30, 15, 500, time.UTC)
+	settle := 12 * time.Hour
+	campaign := func(scannedThrough time.Time, end *time.Time) jobrunscan.AnnotationCampaign {
+		return jobrunscan.AnnotationCampaign{
+			AnnotationCampaignSearch:   jobrunscan.AnnotationCampaignSearch{StartTime: start, EndTime: end},
+			AnnotationCampaignProgress: jobrunscan.AnnotationCampaignProgress{ScannedThrough: scannedThrough},
+		}
+	}
+
+	from, to, ok := campaignWindow(campaign(time.Time{}, nil), now, settle)
+	require.True(t, ok)
+	assert.Equal(t, start, from, "a new campaign scans from its start time")
+	assert.Equal(t, time.Date(2026, 10, 10, 0, 30, 15, 0, time.UTC), to, "runs are scanned once settled")
+
+	scanned := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+	from, _, ok = campaignWindow(campaign(scanned, nil), now, settle)
+	require.True(t, ok)
+	assert.Equal(t, scanned, from, "a campaign continues from where it last scanned through")
+
+	end := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	_, to, ok = campaignWindow(campaign(scanned.AddDate(0, 0, -5), &end), now, settle)
+	require.True(t, ok)
+	assert.Equal(t, end, to, "a campaign does not scan past its end time")
+
+	_, _, ok = campaignWindow(campaign(end, &end), now, settle)
+	assert.False(t, ok, "a campaign that scanned through its end time is done")
+
+	_, _, ok = campaignWindow(campaign(now.Add(-time.Hour), nil), now, settle)
+	assert.False(t, ok, "runs started within the settle time are left for later")
+}
+
+func TestParseVariants(t *testing.T) {
+	allVariants := crtest.JobVariants{Variants: map[string][]string{"Platform": {"aws", "metal"}}}
+
+	variants, err := ParseVariants([]string{"Platform:metal"}, allVariants)
+	require.NoError(t, err)
+	assert.Equal(t, []crstatus.Variant{{Key: "Platform", Value: "metal"}}, variants)
+
+	for _, bad := range []string{"Platform", "Arch:amd64", "Platform:gcp"} {
+		_, err := ParseVariants([]string{bad}, allVariants)
+		assert.Error(t, err, bad)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/storage"
 	"github.com/openshift/sippy/pkg/api/jobartifacts"
+	"github.com/openshift/sippy/pkg/api/jobrunscan/celmatcher"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crstatus"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
 	"github.com/openshift/sippy/pkg/apis/cache"
@@ -20,14 +21,17 @@ import (
 	"github.com/openshift/sippy/pkg/bigquery/bqlabel"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
 	"github.com/openshift/sippy/pkg/util"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	"google.golang.org/api/iterator"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
+	annotatorSourceTool  = "sippy annotate-job-runs"
 	jobAnnotationTable   = "job_labels"
 	dedupedJunitTableFmt = `
 		WITH deduped_testcases AS (
@@ -78,6 +82,20 @@ type JobRunAnnotator struct {
 	JobRunIDs        []int64            `json:"job_run_ids"`
 	comment          string
 	user             string
+	// junitLookahead extends the window in which test results are read past the end of the job
+	// run window, so that runs started near its end are not dropped for having no results yet.
+	junitLookahead time.Duration
+	// campaignID is the annotation campaign being run, if any.
+	campaignID uint
+}
+
+// Result counts the job runs an annotator run found. Scanned runs passed the release, variant,
+// cluster and failure filters, Matched runs also matched the artifact search, and Labeled runs
+// were newly labeled (or would have been, in dry run mode).
+type Result struct {
+	Scanned int `json:"scanned"`
+	Matched int `json:"matched"`
+	Labeled int `json:"labeled"`
 }
 
 func NewJobRunAnnotator(
@@ -139,34 +157,42 @@ func NewJobRunAnnotator(
 }
 
 func (j JobRunAnnotator) Run(ctx context.Context) error {
+	_, err := j.annotate(ctx)
+	return err
+}
+
+func (j JobRunAnnotator) annotate(ctx context.Context) (Result, error) {
+	var result Result
 	var err error
 	log.Infof("Start annotating job runs")
 
 	jobRuns, err := j.getJobRunsFromBigQuery(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get job run IDs from BigQuery: %v", err)
+		return result, fmt.Errorf("could not get job run IDs from BigQuery: %v", err)
 	}
 	log.Infof("Found %d job runs from BigQuery", len(jobRuns))
+	result.Scanned = len(jobRuns)
 
 	jobRunIDs := maps.Keys(jobRuns)
 	if len(j.PathGlob) != 0 {
 		jobRunIDs, err = j.filterJobRunByArtifact(ctx, jobRunIDs)
 		if err != nil {
-			return fmt.Errorf("could not perform artifact search: %v", err)
+			return result, fmt.Errorf("could not perform artifact search: %v", err)
 		}
 		log.Infof("Limit to %d job runs based on artifact search", len(jobRunIDs))
 	}
+	result.Matched = len(jobRunIDs)
 
 	if len(jobRunIDs) != 0 {
 		log.Infof("Attempting to annotate %d job runs", len(jobRunIDs))
-		err = j.annotateJobRuns(ctx, jobRunIDs, jobRuns)
+		result.Labeled, err = j.annotateJobRuns(ctx, jobRunIDs, jobRuns)
 		if err != nil {
-			return fmt.Errorf("error annotating job runs: %v", err)
+			return result, fmt.Errorf("error annotating job runs: %v", err)
 		}
 	}
 
 	log.Infof("Done annotating job runs")
-	return nil
+	return result, nil
 }
 
 type jobRun struct {
@@ -188,12 +214,10 @@ func (j JobRunAnnotator) getJobRunsFromBigQuery(ctx context.Context) (map[int64]
 		jobRunWhereStr += fmt.Sprintf(" AND prowjob_build_id IN UNNEST([%s])\n", strings.Join(strIDs, ", "))
 	}
 
+	var params []bigquery.QueryParameter
 	if len(j.BuildClusters) != 0 {
-		clusterStrs := []string{}
-		for _, cluster := range j.BuildClusters {
-			clusterStrs = append(clusterStrs, fmt.Sprintf(" prowjob_cluster='%s' ", cluster))
-		}
-		jobRunWhereStr += fmt.Sprintf(" AND (%s)\n", strings.Join(clusterStrs, " OR "))
+		jobRunWhereStr += " AND prowjob_cluster IN UNNEST(@BuildClusters)\n"
+		params = append(params, bigquery.QueryParameter{Name: "BuildClusters", Value: j.BuildClusters})
 	}
 	joinVariantsStr := ""
 	filterVariantsStr := ""
@@ -219,11 +243,12 @@ func (j JobRunAnnotator) getJobRunsFromBigQuery(ctx context.Context) (map[int64]
 			minimumFailureStr += fmt.Sprintf("WHERE %d < total_count - success_count - flake_count\n", j.MinFailures)
 		}
 	}
-	dedupedJunitTable := fmt.Sprintf(dedupedJunitTableFmt, j.bqClient.Dataset, j.StartTime.UTC().Format(time.RFC3339), j.StartTime.Add(j.Duration).UTC().Format(time.RFC3339))
+	dedupedJunitTable := fmt.Sprintf(dedupedJunitTableFmt, j.bqClient.Dataset, j.StartTime.UTC().Format(time.RFC3339), j.StartTime.Add(j.Duration+j.junitLookahead).UTC().Format(time.RFC3339))
 
 	junitWhereStr := ""
 	if len(j.Release) != 0 {
-		junitWhereStr += fmt.Sprintf("WHERE branch='%s'\n", j.Release)
+		junitWhereStr += "WHERE branch = @Release\n"
+		params = append(params, bigquery.QueryParameter{Name: "Release", Value: j.Release})
 	}
 	// The query is built with different level of filters:
 	// 1. Filters that exist in jobs table like prowjob_cluster
@@ -277,6 +302,7 @@ func (j JobRunAnnotator) getJobRunsFromBigQuery(ctx context.Context) (map[int64]
 	`, j.bqClient.Dataset, jobRunWhereStr, selectVariants, joinVariantsStr, filterVariantsStr, dedupedJunitTable, junitWhereStr, minimumFailureStr)
 
 	q := j.bqClient.Query(ctx, bqlabel.JobRuns, queryStr)
+	q.Parameters = params
 	jobRuns, errs := fetchJobRunsFromBQ(ctx, q)
 	if len(errs) > 0 {
 		return jobRuns, errs[0]
@@ -370,7 +396,7 @@ func (j JobRunAnnotator) filterJobRunByArtifact(ctx context.Context, jobRunIDs [
 	}
 	for _, jobRun := range result.JobRuns {
 		for _, a := range jobRun.Artifacts {
-			if a.ContentLineMatches != nil {
+			if _, matched := a.Matched(); matched {
 				id, err := strconv.ParseInt(a.JobRunID, 10, 64)
 				if err != nil {
 					log.WithError(err).Errorf("error parsing job run ID %s", a.JobRunID)
@@ -424,12 +450,15 @@ func BulkInsertJobRunLabels(ctx context.Context, bqClient *bigquery.Client, data
 type LabelComment struct {
 	Comment string          `json:"comment"`
 	V1      JobRunAnnotator `json:"job_run_annotator_v1"`
+	// CampaignID is the annotation campaign that applied the label, if any.
+	CampaignID uint `json:"campaign_id,omitempty"`
 }
 
 func (j JobRunAnnotator) generateComment() string {
 	comment := LabelComment{
-		Comment: j.comment, // apparently separated out in order to stand out from the object
-		V1:      j,
+		Comment:    j.comment, // apparently separated out in order to stand out from the object
+		V1:         j,
+		CampaignID: j.campaignID,
 	}
 	str, err := json.MarshalIndent(comment, "", "    ")
 	if err != nil {
@@ -440,7 +469,7 @@ func (j JobRunAnnotator) generateComment() string {
 	return string(str)
 }
 
-func (j JobRunAnnotator) getJobRunAnnotationsFromBigQuery(ctx context.Context) (map[int64]models.JobRunLabel, error) {
+func (j JobRunAnnotator) getJobRunAnnotationsFromBigQuery(ctx context.Context) (map[int64][]models.JobRunLabel, error) {
 	now := time.Now()
 	queryStr := fmt.Sprintf(`
 		SELECT
@@ -453,7 +482,7 @@ func (j JobRunAnnotator) getJobRunAnnotationsFromBigQuery(ctx context.Context) (
 	q := j.bqClient.Query(ctx, bqlabel.JobRunLabels, queryStr)
 
 	errs := []error{}
-	result := make(map[int64]models.JobRunLabel)
+	result := make(map[int64][]models.JobRunLabel)
 	log.Debugf("Fetching job run annotations with:\n%s\n", q.Q)
 
 	it, err := q.Read(ctx)
@@ -478,7 +507,7 @@ func (j JobRunAnnotator) getJobRunAnnotationsFromBigQuery(ctx context.Context) (
 			log.WithError(err).Errorf("error parsing job run ID %s from bigquery", row.ID)
 			errs = append(errs, errors.Wrap(err, "error parsing job run IDs from bigquery"))
 		} else {
-			result[id] = row
+			result[id] = append(result[id], row)
 		}
 	}
 
@@ -495,20 +524,31 @@ func (j JobRunAnnotator) getJobRunAnnotationsFromBigQuery(ctx context.Context) (
 	return result, nil
 }
 
-func (j JobRunAnnotator) annotateJobRuns(ctx context.Context, jobRunIDs []int64, jobRuns map[int64]jobRun) error {
+// annotateJobRuns labels the job runs that do not have the label yet, and returns how many.
+func (j JobRunAnnotator) annotateJobRuns(ctx context.Context, jobRunIDs []int64, jobRuns map[int64]jobRun) (int, error) {
 	jobRunAnnotations := make([]models.JobRunLabel, 0, len(jobRunIDs))
 	existingAnnotations, err := j.getJobRunAnnotationsFromBigQuery(ctx)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	log.Infof("Found existing annotations for %d job runs.", len(existingAnnotations))
+	compoundSymptoms, err := j.loadCompoundSymptoms()
+	if err != nil {
+		return 0, err
 	}
-	log.Infof("Found %d existing job run annotations.", len(existingAnnotations))
+	labeled := 0
 	now := civil.DateTimeOf(time.Now())
 	for _, jobRunID := range jobRunIDs {
 		if jobRun, ok := jobRuns[jobRunID]; ok {
+			existingLabels := sets.New[string]()
+			for _, annotation := range existingAnnotations[jobRunID] {
+				existingLabels.Insert(annotation.Label)
+			}
 			// Skip if the same label already exists
-			if annotation, existing := existingAnnotations[jobRunID]; existing && annotation.Label == j.Label {
+			if existingLabels.Has(j.Label) {
 				continue
 			}
+			labeled++
 			jobRunAnnotations = append(jobRunAnnotations, models.JobRunLabel{
 				ID:         jobRun.ID,
 				StartTime:  jobRun.StartTime,
@@ -517,12 +557,56 @@ func (j JobRunAnnotator) annotateJobRuns(ctx context.Context, jobRunIDs []int64,
 				User:       j.user,
 				CreatedAt:  now,
 				UpdatedAt:  now,
-				SourceTool: "sippy annotate-job-runs",
-				SymptomID:  "", // Empty for manual annotations; will be populated when symptom detection is implemented
+				SourceTool: annotatorSourceTool,
+				SymptomID:  "", // Empty for manual annotations
 				URL:        jobRun.URL,
 			})
+
+			// The new label may complete compound (CEL) symptoms for this job run
+			applied := append(sets.List(existingLabels), j.Label)
+			for _, matched := range celmatcher.MatchSymptoms(compoundSymptoms, applied) {
+				for _, labelID := range matched.Symptom.LabelIDs {
+					if existingLabels.Has(labelID) || labelID == j.Label {
+						continue
+					}
+					existingLabels.Insert(labelID)
+					jobRunAnnotations = append(jobRunAnnotations, models.JobRunLabel{
+						ID:         jobRun.ID,
+						StartTime:  jobRun.StartTime,
+						Label:      labelID,
+						Comment:    j.generateComment(),
+						User:       j.user,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+						SourceTool: annotatorSourceTool,
+						SymptomID:  matched.Symptom.ID,
+						URL:        jobRun.URL,
+					})
+				}
+			}
 		}
 	}
-	log.Infof("Going to annotate %d new job runs", len(jobRunAnnotations))
-	return j.bulkInsertJobRunAnnotations(ctx, jobRunAnnotations)
+	log.Infof("Going to write %d new job run annotations", len(jobRunAnnotations))
+	if err := j.bulkInsertJobRunAnnotations(ctx, jobRunAnnotations); err != nil {
+		return 0, err
+	}
+	return labeled, nil
+}
+
+// loadCompoundSymptoms fetches the CEL symptoms that apply labels, so labels written by the
+// annotator can trigger them. Returns nothing when no database is configured.
+func (j JobRunAnnotator) loadCompoundSymptoms() ([]celmatcher.CompoundSymptom, error) {
+	if j.dbClient == nil || j.dbClient.DB == nil {
+		return nil, nil
+	}
+	var symptoms []jobrunscan.Symptom
+	res := j.dbClient.DB.Where("matcher_type = ? AND cardinality(label_ids) > 0", jobrunscan.MatcherTypeCEL).Order("id").Find(&symptoms)
+	if res.Error != nil {
+		return nil, fmt.Errorf("error loading compound symptoms: %w", res.Error)
+	}
+	compound, errs := celmatcher.CompileSymptoms(symptoms)
+	for id, err := range errs {
+		log.WithError(err).WithField("symptom", id).Warn("skipping compound symptom")
+	}
+	return compound, nil
 }
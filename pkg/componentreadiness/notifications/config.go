@@ -0,0 +1,158 @@
+package notifications
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatJSON posts the Event as generic JSON.
+	FormatJSON = "json"
+	// FormatSlack posts a Slack incoming webhook compatible message.
+	FormatSlack = "slack"
+
+	defaultSippyURL = "https://sippy-auth.dptools.openshift.org"
+	defaultTimeout  = 10 * time.Second
+)
+
+// Config defines where regression lifecycle events are sent.
+type Config struct {
+	// SippyURL is the base URL used for links in notifications.
+	SippyURL string `yaml:"sippy_url,omitempty"`
+	// Timeout limits each webhook request, defaulting to 10s.
+	Timeout       time.Duration  `yaml:"timeout,omitempty"`
+	Subscriptions []Subscription `yaml:"subscriptions"`
+}
+
+// Subscription sends matching events to a single webhook. All filters that are set must match,
+// and an empty filter matches everything.
+type Subscription struct {
+	Name string `yaml:"name"`
+	// URL is the webhook endpoint. URLEnv names an environment variable to read it from instead,
+	// so that secret webhook URLs need not be committed alongside the config.
+	URL    string `yaml:"url,omitempty"`
+	URLEnv string `yaml:"url_env,omitempty"`
+	// Format is json (the default) or slack.
+	Format string `yaml:"format,omitempty"`
+	// Headers are added to each request, e.g. for authorization.
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	Events     []EventType `yaml:"events,omitempty"`
+	Views      []string    `yaml:"views,omitempty"`
+	Components []string    `yaml:"components,omitempty"`
+	// Variants must all be present on a regression, e.g. {Platform: aws}.
+	Variants map[string]string `yaml:"variants,omitempty"`
+}
+
+// LoadConfig reads and validates a notifications config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read notifications config from %s: %w", path, err)
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse notifications config from %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid notifications config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.SippyURL == "" {
+		c.SippyURL = defaultSippyURL
+	}
+	c.SippyURL = strings.TrimSuffix(c.SippyURL, "/")
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+
+	names := map[string]bool{}
+	for i := range c.Subscriptions {
+		s := &c.Subscriptions[i]
+		if s.Name == "" {
+			return fmt.Errorf("subscription %d has no name", i)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("duplicate subscription %s", s.Name)
+		}
+		names[s.Name] = true
+
+		if s.URLEnv != "" {
+			if s.URL != "" {
+				return fmt.Errorf("subscription %s: only one of url and url_env may be set", s.Name)
+			}
+			s.URL = os.Getenv(s.URLEnv)
+			if s.URL == "" {
+				return fmt.Errorf("subscription %s: environment variable %s is not set", s.Name, s.URLEnv)
+			}
+		}
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("subscription %s: url must be an http(s) URL", s.Name)
+		}
+
+		switch s.Format {
+		case "":
+			s.Format = FormatJSON
+		case FormatJSON, FormatSlack:
+		default:
+			return fmt.Errorf("subscription %s: unknown format %q, must be %s or %s", s.Name, s.Format, FormatJSON, FormatSlack)
+		}
+
+		for _, e := range s.Events {
+			if !e.valid() {
+				return fmt.Errorf("subscription %s: unknown event %q", s.Name, e)
+			}
+		}
+	}
+	return nil
+}
+
+// matches reports whether the event passes all of the subscription's filters.
+func (s *Subscription) matches(e Event) bool {
+	if len(s.Events) > 0 && !contains(s.Events, e.Type) {
+		return false
+	}
+	if len(s.Views) > 0 && !containsAny(s.Views, e.Views) {
+		return false
+	}
+	if len(s.Components) == 0 && len(s.Variants) == 0 {
+		return true
+	}
+	// component and variant filters must both match the same regression
+	for _, r := range e.regressions() {
+		if len(s.Components) > 0 && !contains(s.Components, r.Component) {
+			continue
+		}
+		if r.hasVariants(s.Variants) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny[T comparable](list, values []T) bool {
+	for _, v := range values {
+		if contains(list, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// EventType identifies a regression lifecycle event.
+type EventType string
+
+const (
+	// RegressionOpened is sent when a test first regresses, or regresses again shortly after closing.
+	RegressionOpened EventType = "regression-opened"
+	// RegressionClosed is sent when a regression no longer appears in any component report.
+	RegressionClosed EventType = "regression-closed"
+	// TriageResolved is sent when all regressions of a triage have closed.
+	TriageResolved EventType = "triage-resolved"
+	// FailedFix is sent when a regression claimed fixed by a triage resolution is still failing.
+	FailedFix EventType = "failed-fix"
+)
+
+func (e EventType) valid() bool {
+	switch e {
+	case RegressionOpened, RegressionClosed, TriageResolved, FailedFix:
+		return true
+	}
+	return false
+}
+
+// Event is the generic JSON notification payload.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	// Views are the component readiness views the regression(s) were observed in.
+	Views      []string    `json:"views,omitempty"`
+	Regression *Regression `json:"regression,omitempty"`
+	Triage     *Triage     `json:"triage,omitempty"`
+}
+
+// Regression summarizes a test regression.
+type Regression struct {
+	ID         uint              `json:"id"`
+	Release    string            `json:"release"`
+	TestID     string            `json:"test_id"`
+	TestName   string            `json:"test_name"`
+	Component  string            `json:"component"`
+	Capability string            `json:"capability"`
+	Variants   map[string]string `json:"variants"`
+	Opened     time.Time         `json:"opened"`
+	Closed     *time.Time        `json:"closed,omitempty"`
+	// Reopened is set when a recently closed regression was reused rather than a new one opened.
+	Reopened bool   `json:"reopened,omitempty"`
+	URL      string `json:"url"`
+}
+
+// Triage summarizes a triage and the regressions it covers.
+type Triage struct {
+	ID               uint         `json:"id"`
+	URL              string       `json:"url"`
+	Description      string       `json:"description,omitempty"`
+	Type             string       `json:"type"`
+	Resolved         *time.Time   `json:"resolved,omitempty"`
+	ResolutionReason string       `json:"resolution_reason,omitempty"`
+	SippyURL         string       `json:"sippy_url"`
+	Regressions      []Regression `json:"regressions,omitempty"`
+}
+
+// RegressionEvent builds an event for a single regression observed in the given views.
+func RegressionEvent(eventType EventType, reg *models.TestRegression, views ...string) Event {
+	r := newRegression(reg)
+	return Event{
+		Type:       eventType,
+		Time:       time.Now(),
+		Views:      views,
+		Regression: &r,
+	}
+}
+
+// TriageEvent builds an event for a triage, including any of its preloaded regressions.
+func TriageEvent(eventType EventType, triage *models.Triage) Event {
+	t := &Triage{
+		ID:               triage.ID,
+		URL:              triage.URL,
+		Description:      triage.Description,
+		Type:             string(triage.Type),
+		ResolutionReason: string(triage.ResolutionReason),
+	}
+	if triage.Resolved.Valid {
+		resolved := triage.Resolved.Time
+		t.Resolved = &resolved
+	}
+	var views []string
+	for i := range triage.Regressions {
+		t.Regressions = append(t.Regressions, newRegression(&triage.Regressions[i]))
+		for _, v := range triage.Regressions[i].Views {
+			if !contains(views, v.ViewName) {
+				views = append(views, v.ViewName)
+			}
+		}
+	}
+	return Event{
+		Type:   eventType,
+		Time:   time.Now(),
+		Views:  views,
+		Triage: t,
+	}
+}
+
+func newRegression(reg *models.TestRegression) Regression {
+	variants := make(map[string]string, len(reg.Variants))
+	for _, v := range reg.Variants {
+		if k, val := crtest.VariantStringToKeyValue(v); k != "" {
+			variants[k] = val
+		}
+	}
+	r := Regression{
+		ID:         reg.ID,
+		Release:    reg.Release,
+		TestID:     reg.TestID,
+		TestName:   reg.TestName,
+		Component:  reg.Component,
+		Capability: reg.Capability,
+		Variants:   variants,
+		Opened:     reg.Opened,
+	}
+	if reg.Closed.Valid {
+		closed := reg.Closed.Time
+		r.Closed = &closed
+	}
+	return r
+}
+
+// regressions returns the regressions the event is about.
+func (e Event) regressions() []Regression {
+	if e.Regression != nil {
+		return []Regression{*e.Regression}
+	}
+	if e.Triage != nil {
+		return e.Triage.Regressions
+	}
+	return nil
+}
+
+func (r Regression) hasVariants(variants map[string]string) bool {
+	for k, v := range variants {
+		if r.Variants[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// withLinks returns a copy of the event with sippy URLs filled in.
+func (e Event) withLinks(sippyURL string) Event {
+	if e.Regression != nil {
+		r := *e.Regression
+		r.URL = regressionURL(sippyURL, r.ID)
+		e.Regression = &r
+	}
+	if e.Triage != nil {
+		t := *e.Triage
+		t.SippyURL = fmt.Sprintf("%s/sippy-ng/component_readiness/triages/%d", sippyURL, t.ID)
+		t.Regressions = make([]Regression, len(e.Triage.Regressions))
+		for i, r := range e.Triage.Regressions {
+			r.URL = regressionURL(sippyURL, r.ID)
+			t.Regressions[i] = r
+		}
+		e.Triage = &t
+	}
+	return e
+}
+
+func regressionURL(sippyURL string, id uint) string {
+	return fmt.Sprintf("%s/sippy-ng/component_readiness/regressions/%d", sippyURL, id)
+}
@@ -0,0 +1,141 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Notifier delivers regression lifecycle events. Delivery failures are logged rather than returned,
+// as they should never fail regression tracking.
+type Notifier interface {
+	Notify(ctx context.Context, event Event)
+}
+
+// Notify sends the event with the notifier, if there is one.
+func Notify(ctx context.Context, n Notifier, event Event) {
+	if n != nil {
+		n.Notify(ctx, event)
+	}
+}
+
+// WebhookNotifier posts events to the webhooks of all matching subscriptions.
+type WebhookNotifier struct {
+	config *Config
+	client *http.Client
+}
+
+func NewWebhookNotifier(config *Config) *WebhookNotifier {
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) {
+	event = event.withLinks(w.config.SippyURL)
+	for i := range w.config.Subscriptions {
+		sub := &w.config.Subscriptions[i]
+		if !sub.matches(event) {
+			continue
+		}
+		logger := log.WithFields(log.Fields{"subscription": sub.Name, "event": event.Type})
+		if err := w.send(ctx, sub, event); err != nil {
+			logger.WithError(err).Error("error sending notification")
+			continue
+		}
+		logger.Debug("sent notification")
+	}
+}
+
+func (w *WebhookNotifier) send(ctx context.Context, sub *Subscription, event Event) error {
+	var payload any = event
+	if sub.Format == FormatSlack {
+		payload = slackMessage{Text: slackText(event)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range sub.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// slackMessage is the payload accepted by Slack incoming webhooks, and compatible services.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func slackText(e Event) string {
+	var sb strings.Builder
+	switch e.Type {
+	case RegressionOpened:
+		if e.Regression.Reopened {
+			sb.WriteString(":red_circle: *Regression reopened*")
+		} else {
+			sb.WriteString(":red_circle: *Regression opened*")
+		}
+	case RegressionClosed:
+		sb.WriteString(":large_green_circle: *Regression closed*")
+	case FailedFix:
+		sb.WriteString(":warning: *Regression still failing after claimed fix*")
+	case TriageResolved:
+		sb.WriteString(":white_check_mark: *Triage resolved*")
+	}
+	if len(e.Views) > 0 {
+		fmt.Fprintf(&sb, " in %s", strings.Join(e.Views, ", "))
+	}
+	sb.WriteString("\n")
+
+	if r := e.Regression; r != nil {
+		fmt.Fprintf(&sb, "<%s|%s>\n", r.URL, r.TestName)
+		fmt.Fprintf(&sb, "Component: %s, Capability: %s, Release: %s\n", r.Component, r.Capability, r.Release)
+		fmt.Fprintf(&sb, "Variants: %s", variantsText(r.Variants))
+	}
+	if t := e.Triage; t != nil {
+		fmt.Fprintf(&sb, "<%s|Triage %d>: <%s|%s>", t.SippyURL, t.ID, t.URL, triageTitle(t))
+		fmt.Fprintf(&sb, "\n%d regression(s)", len(t.Regressions))
+	}
+	return sb.String()
+}
+
+func triageTitle(t *Triage) string {
+	if t.Description != "" {
+		return t.Description
+	}
+	return t.URL
+}
+
+func variantsText(variants map[string]string) string {
+	parts := make([]string, 0, len(variants))
+	for k, v := range variants {
+		parts = append(parts, k+":"+v)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
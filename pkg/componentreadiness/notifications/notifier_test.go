@@ -0,0 +1,220 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func testRegression(id uint, component string, variants ...string) *models.TestRegression {
+	return &models.TestRegression{
+		ID:        id,
+		Release:   "4.21",
+		TestID:    "test-id",
+		TestName:  "[sig-storage] some test",
+		Component: component,
+		Variants:  pq.StringArray(variants),
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	opened := RegressionEvent(RegressionOpened, testRegression(1, "Storage", "Platform:aws", "Network:ovn"), "4.21-main")
+	triage := TriageEvent(TriageResolved, &models.Triage{
+		ID: 2,
+		Regressions: []models.TestRegression{
+			*testRegression(3, "Networking", "Platform:gcp"),
+			{ID: 4, Component: "Storage", Variants: pq.StringArray{"Platform:metal"},
+				Views: []models.RegressionView{{ViewName: "4.21-main"}}},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		sub      Subscription
+		event    Event
+		expected bool
+	}{
+		{
+			name:     "no filters",
+			event:    opened,
+			expected: true,
+		},
+		{
+			name:     "event filter",
+			sub:      Subscription{Events: []EventType{RegressionClosed}},
+			event:    opened,
+			expected: false,
+		},
+		{
+			name:     "view filter",
+			sub:      Subscription{Views: []string{"4.21-main"}},
+			event:    opened,
+			expected: true,
+		},
+		{
+			name:     "other view",
+			sub:      Subscription{Views: []string{"4.20-main"}},
+			event:    opened,
+			expected: false,
+		},
+		{
+			name:     "component and variants",
+			sub:      Subscription{Components: []string{"Storage"}, Variants: map[string]string{"Platform": "aws"}},
+			event:    opened,
+			expected: true,
+		},
+		{
+			name:     "variant mismatch",
+			sub:      Subscription{Variants: map[string]string{"Platform": "gcp"}},
+			event:    opened,
+			expected: false,
+		},
+		{
+			name:     "triage with a matching regression",
+			sub:      Subscription{Components: []string{"Storage"}, Views: []string{"4.21-main"}},
+			event:    triage,
+			expected: true,
+		},
+		{
+			name:     "triage component and variant must match the same regression",
+			sub:      Subscription{Components: []string{"Storage"}, Variants: map[string]string{"Platform": "gcp"}},
+			event:    triage,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sub.matches(tt.event))
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("TEST_WEBHOOK_URL", "https://hooks.example.com/abc")
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			config: `
+subscriptions:
+  - name: storage
+    url_env: TEST_WEBHOOK_URL
+    format: slack
+    events: [regression-opened, failed-fix]
+    components: [Storage]
+`,
+		},
+		{
+			name: "unknown event",
+			config: `
+subscriptions:
+  - name: storage
+    url: https://hooks.example.com/abc
+    events: [regression-reopened]
+`,
+			expectedErr: "unknown event",
+		},
+		{
+			name: "unknown format",
+			config: `
+subscriptions:
+  - name: storage
+    url: https://hooks.example.com/abc
+    format: teams
+`,
+			expectedErr: "unknown format",
+		},
+		{
+			name: "missing url",
+			config: `
+subscriptions:
+  - name: storage
+`,
+			expectedErr: "url must be an http(s) URL",
+		},
+		{
+			name: "missing env",
+			config: `
+subscriptions:
+  - name: storage
+    url_env: TEST_WEBHOOK_URL_UNSET
+`,
+			expectedErr: "is not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notifications.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.config), 0o600))
+			cfg, err := LoadConfig(path)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultSippyURL, cfg.SippyURL)
+			assert.Equal(t, "https://hooks.example.com/abc", cfg.Subscriptions[0].URL)
+		})
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var lock sync.Mutex
+	received := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		received[r.URL.Path] = body
+		lock.Unlock()
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		SippyURL: "https://sippy.example.com",
+		Subscriptions: []Subscription{
+			{Name: "json", URL: server.URL + "/json", Headers: map[string]string{"X-Token": "secret"}},
+			{Name: "slack", URL: server.URL + "/slack", Format: FormatSlack, Headers: map[string]string{"X-Token": "secret"}},
+			{Name: "networking", URL: server.URL + "/networking", Components: []string{"Networking"}},
+			{Name: "fail", URL: server.URL + "/fail", Headers: map[string]string{"X-Token": "secret"}},
+		},
+	}
+	require.NoError(t, cfg.validate())
+
+	NewWebhookNotifier(cfg).Notify(context.Background(),
+		RegressionEvent(RegressionOpened, testRegression(42, "Storage", "Platform:aws"), "4.21-main"))
+
+	assert.Contains(t, received, "/fail")
+	assert.NotContains(t, received, "/networking")
+
+	var event Event
+	require.NoError(t, json.Unmarshal(received["/json"], &event))
+	assert.Equal(t, RegressionOpened, event.Type)
+	assert.Equal(t, []string{"4.21-main"}, event.Views)
+	assert.Equal(t, map[string]string{"Platform": "aws"}, event.Regression.Variants)
+	assert.Equal(t, "https://sippy.example.com/sippy-ng/component_readiness/regressions/42", event.Regression.URL)
+
+	var slack slackMessage
+	require.NoError(t, json.Unmarshal(received["/slack"], &slack))
+	assert.Contains(t, slack.Text, "*Regression opened* in 4.21-main")
+	assert.Contains(t, slack.Text, "<https://sippy.example.com/sippy-ng/component_readiness/regressions/42|[sig-storage] some test>")
+}
@@ -87,6 +87,9 @@ type BugLoader struct {
 	dbc    *db.DB
 	bqc    *bigquery.Client
 	errors []error
+	// uncommittedRows counts rows changed in the load transaction, and is added to rowsWritten on commit
+	uncommittedRows int64
+	rowsWritten     int64
 }
 
 type bugRow struct {
@@ -126,6 +129,17 @@ func (bl *BugLoader) Errors() []error {
 	return bl.errors
 }
 
+// DependsOn returns the loaders that must finish first. Bugs are associated with the jobs, tests and
+// regressions sippy already knows about, so those first seen by the prow loader are picked up in the
+// same load.
+func (bl *BugLoader) DependsOn() []string {
+	return []string{"prow"}
+}
+
+func (bl *BugLoader) RowsWritten() int64 {
+	return bl.rowsWritten
+}
+
 func (bl *BugLoader) addError(logger *log.Entry, err error, msg string) {
 	logger.WithError(err).Error(msg)
 	bl.errors = append(bl.errors, errors.Wrap(err, msg))
@@ -258,6 +272,7 @@ func (bl *BugLoader) loadIntoDB(bugs []bugRow, testAssocs, jobAssocs []assocRow)
 		}
 	}()
 
+	bl.uncommittedRows = 0
 	tx, err := conn.Begin(bl.ctx)
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -302,6 +317,7 @@ func (bl *BugLoader) loadIntoDB(bugs []bugRow, testAssocs, jobAssocs []assocRow)
 	if err := tx.Commit(bl.ctx); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
+	bl.rowsWritten += bl.uncommittedRows
 	return nil
 }
 
@@ -351,6 +367,7 @@ func (bl *BugLoader) upsertBugs(conn db.PgxSession) error {
 	if err != nil {
 		return fmt.Errorf("upserting bugs: %w", err)
 	}
+	bl.uncommittedRows += tag.RowsAffected()
 	log.WithFields(log.Fields{"rows": tag.RowsAffected(), "elapsed": time.Since(st)}).Info("upsert bugs complete")
 	return nil
 }
@@ -394,6 +411,7 @@ func (bl *BugLoader) syncTestAssociations(conn db.PgxSession) error {
 		return fmt.Errorf("inserting bug_tests: %w", err)
 	}
 
+	bl.uncommittedRows += deleteTag.RowsAffected() + insertTag.RowsAffected()
 	log.WithFields(log.Fields{
 		"deleted":  deleteTag.RowsAffected(),
 		"inserted": insertTag.RowsAffected(),
@@ -432,6 +450,7 @@ func (bl *BugLoader) syncJobAssociations(conn db.PgxSession) error {
 		return fmt.Errorf("inserting bug_jobs: %w", err)
 	}
 
+	bl.uncommittedRows += deleteTag.RowsAffected() + insertTag.RowsAffected()
 	log.WithFields(log.Fields{
 		"deleted":  deleteTag.RowsAffected(),
 		"inserted": insertTag.RowsAffected(),
@@ -494,6 +513,7 @@ func (bl *BugLoader) reconcileTriages(conn db.PgxSession) error {
 		return fmt.Errorf("auto-resolving triages: %w", err)
 	}
 
+	bl.uncommittedRows += descTag.RowsAffected() + linkTag.RowsAffected() + resolveTag.RowsAffected()
 	log.WithFields(log.Fields{
 		"descriptions_updated": descTag.RowsAffected(),
 		"bugs_linked":          linkTag.RowsAffected(),
@@ -10,3 +10,16 @@ type DataLoader interface {
 	// Errors returns a slice of errors that occurred during the data loading process.
 	Errors() []error
 }
+
+// Dependent is implemented by loaders that must not start until other loaders have finished.
+type Dependent interface {
+	// DependsOn returns the names of the loaders that must finish first. Loaders that are not
+	// part of the current load are ignored.
+	DependsOn() []string
+}
+
+// RowCounter is implemented by loaders that can report how much data they wrote.
+type RowCounter interface {
+	// RowsWritten returns the number of rows inserted, updated or deleted by the last Load.
+	RowsWritten() int64
+}
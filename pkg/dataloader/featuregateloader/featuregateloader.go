@@ -31,6 +31,7 @@ type FeatureGateLoader struct {
 	ghClient       *gh.Client
 	errs           []error
 	releaseConfigs []v1.Release
+	rowsWritten    int64
 }
 
 func New(ctx context.Context, dbc *db.DB, ghClient *gh.Client, configs []v1.Release) *FeatureGateLoader {
@@ -43,7 +44,7 @@ func New(ctx context.Context, dbc *db.DB, ghClient *gh.Client, configs []v1.Rele
 }
 
 func (l *FeatureGateLoader) Name() string {
-	return "feature_gates"
+	return "feature-gates"
 }
 
 func (l *FeatureGateLoader) Load() {
@@ -59,6 +60,15 @@ func (l *FeatureGateLoader) Errors() []error {
 	return l.errs
 }
 
+// DependsOn returns the loaders that must finish before feature gates are loaded.
+func (l *FeatureGateLoader) DependsOn() []string {
+	return []string{"release-definitions"}
+}
+
+func (l *FeatureGateLoader) RowsWritten() int64 {
+	return l.rowsWritten
+}
+
 func (l *FeatureGateLoader) getTargetReleases() []string {
 	var targetReleases []string
 	for _, release := range l.releaseConfigs {
@@ -212,6 +222,7 @@ func (l *FeatureGateLoader) upsertFeatureGates(featureGates []models.FeatureGate
 	if err != nil {
 		return fmt.Errorf("upserting feature_gates: %w", err)
 	}
+	l.rowsWritten += upsertTag.RowsAffected()
 	log.WithFields(log.Fields{
 		"rows":    upsertTag.RowsAffected(),
 		"elapsed": time.Since(st),
@@ -26,12 +26,13 @@ import (
 // the raw data is missing or the GA date changed, or when forced.
 // Aggregation happens at query time in the Component Readiness provider.
 type GATestStatusLoader struct {
-	ctx      context.Context
-	dbc      *db.DB
-	bqClient *bqcachedclient.Client
-	force    bool
-	releases []string
-	errs     []error
+	ctx         context.Context
+	dbc         *db.DB
+	bqClient    *bqcachedclient.Client
+	force       bool
+	releases    []string
+	errs        []error
+	rowsWritten int64
 }
 
 func New(ctx context.Context, dbc *db.DB, bqClient *bqcachedclient.Client, force bool, releases []string) *GATestStatusLoader {
@@ -44,8 +45,15 @@ func New(ctx context.Context, dbc *db.DB, bqClient *bqcachedclient.Client, force
 	}
 }
 
-func (l *GATestStatusLoader) Name() string    { return "ga-test-status" }
-func (l *GATestStatusLoader) Errors() []error { return l.errs }
+func (l *GATestStatusLoader) Name() string       { return "ga-test-status" }
+func (l *GATestStatusLoader) Errors() []error    { return l.errs }
+func (l *GATestStatusLoader) RowsWritten() int64 { return l.rowsWritten }
+
+// DependsOn returns the loaders that must finish first: GA dates come from the release definitions,
+// and raw results are only kept for tests and jobs already known from prow.
+func (l *GATestStatusLoader) DependsOn() []string {
+	return []string{"release-definitions", "prow"}
+}
 
 func (l *GATestStatusLoader) Load() {
 	start := time.Now()
@@ -159,6 +167,7 @@ func (l *GATestStatusLoader) persist(release string, gaDate civil.Date, rows []s
 		return fmt.Errorf("deleting existing raw rows: %w", err)
 	}
 
+	var inserted int64
 	if len(rows) > 0 {
 		insertStart := time.Now()
 		result, err := tx.Exec(l.ctx, `
@@ -175,7 +184,8 @@ func (l *GATestStatusLoader) persist(release string, gaDate civil.Date, rows []s
 		if err != nil {
 			return fmt.Errorf("INSERT...SELECT from temp table: %w", err)
 		}
-		log.WithField("rows", result.RowsAffected()).
+		inserted = result.RowsAffected()
+		log.WithField("rows", inserted).
 			WithField("elapsed", time.Since(insertStart)).
 			Info("ga-test-status: inserted from temp table")
 	}
@@ -190,6 +200,7 @@ func (l *GATestStatusLoader) persist(release string, gaDate civil.Date, rows []s
 	if err := tx.Commit(l.ctx); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
+	l.rowsWritten += inserted
 	log.WithField("elapsed", time.Since(commitStart)).Info("ga-test-status: committed")
 
 	return nil
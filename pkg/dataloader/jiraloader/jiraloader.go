@@ -1,6 +1,7 @@
 package jiraloader
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -23,12 +24,15 @@ import (
 
 // JiraLoader loads various data sources directly from the Jira API, such as TRT incidents and OCPBUGS components.
 type JiraLoader struct {
-	dbc    *db.DB
-	errors []error
+	ctx         context.Context
+	dbc         *db.DB
+	errors      []error
+	rowsWritten int64
 }
 
-func New(dbc *db.DB) *JiraLoader {
+func New(ctx context.Context, dbc *db.DB) *JiraLoader {
 	return &JiraLoader{
+		ctx: ctx,
 		dbc: dbc,
 	}
 }
@@ -84,6 +88,10 @@ func (jl *JiraLoader) Errors() []error {
 	return jl.errors
 }
 
+func (jl *JiraLoader) RowsWritten() int64 {
+	return jl.rowsWritten
+}
+
 func findResolutionTime(issue *v1jira.Issue) *time.Time {
 	var resolutionTime *time.Time
 
@@ -154,7 +162,7 @@ func issueContainsLabel(issue *v1jira.Issue, label string) bool {
 func (jl *JiraLoader) componentLoader(authorization string) {
 	start := time.Now()
 	log.Infof("loading jira ocpbugs component information...")
-	body, err := jiraRequest("https://redhat.atlassian.net/rest/api/2/project/OCPBUGS/components", authorization)
+	body, err := jiraRequest(jl.ctx, "https://redhat.atlassian.net/rest/api/2/project/OCPBUGS/components", authorization)
 	if err != nil {
 		jl.errors = append(jl.errors, err)
 		return
@@ -187,19 +195,22 @@ func (jl *JiraLoader) componentLoader(authorization string) {
 			LeadEmail:   c.Lead.Name,
 		}
 
-		if err := jl.dbc.DB.Clauses(clause.OnConflict{UpdateAll: true}).Save(&mc).Error; err != nil {
+		if err := jl.dbc.DB.WithContext(jl.ctx).Clauses(clause.OnConflict{UpdateAll: true}).Save(&mc).Error; err != nil {
 			jl.errors = append(jl.errors, err)
 			log.WithError(err).Warningf("failed to save component %q", c.Name)
 			continue
 		}
+		jl.rowsWritten++
 		ids = append(ids, mc.ID)
 	}
 
 	log.Infof("deleting old records...")
-	oldRecords := jl.dbc.DB.Where("id NOT IN ?", ids).Unscoped().Delete(&models.JiraComponent{})
+	oldRecords := jl.dbc.DB.WithContext(jl.ctx).Where("id NOT IN ?", ids).Unscoped().Delete(&models.JiraComponent{})
 	if oldRecords.Error != nil {
 		log.WithError(oldRecords.Error).Warningf("couldn't delete old records")
 		jl.errors = append(jl.errors, oldRecords.Error)
+	} else {
+		jl.rowsWritten += oldRecords.RowsAffected
 	}
 
 	log.WithFields(log.Fields{
@@ -212,7 +223,7 @@ func (jl *JiraLoader) incidentLoader(authorization string) {
 	start := time.Now()
 	log.Infof("populating unresolved jira incident cache...")
 	var dbIssues []string
-	jl.dbc.DB.Table("jira_incidents").Where("resolution_time IS NULL").Pluck("key", &dbIssues)
+	jl.dbc.DB.WithContext(jl.ctx).Table("jira_incidents").Where("resolution_time IS NULL").Pluck("key", &dbIssues)
 	// unseenUnresolvedIssues contains the set of unresolved issues we have in the DB, but didn't see yet from the jira API. At the end,
 	// we'll query to see what happened to the unseen issues. Most likely, we removed the trt-incident label, so we need
 	// to dig into the changelog and find that state transition and consider the incident closed then.
@@ -235,7 +246,7 @@ func (jl *JiraLoader) incidentLoader(authorization string) {
 		}
 
 		log.Infof("fetching page %d of incidents...", pageCount)
-		body, err := jiraRequest(apiURL, authorization)
+		body, err := jiraRequest(jl.ctx, apiURL, authorization)
 		if err != nil {
 			jl.errors = append(jl.errors, err)
 			return
@@ -257,11 +268,13 @@ func (jl *JiraLoader) incidentLoader(authorization string) {
 				log.WithError(err).Errorf("couldn't convert jira issue to db model")
 				continue
 			}
-			if res := jl.dbc.DB.Save(model); res.Error != nil {
+			res := jl.dbc.DB.WithContext(jl.ctx).Save(model)
+			if res.Error != nil {
 				log.WithError(res.Error).Errorf("couldn't save jira incident to DB")
 				jl.errors = append(jl.errors, res.Error)
 				return
 			}
+			jl.rowsWritten += res.RowsAffected
 		}
 
 		totalIssues += len(response.Issues)
@@ -283,7 +296,7 @@ func (jl *JiraLoader) incidentLoader(authorization string) {
 	log.Infof("we have %d unseen and unresolved jira incidents", unseenUnresolvedIssues.Len())
 	for _, unseen := range sets.List(unseenUnresolvedIssues) {
 		log.Infof("processing unseen, unresolved jira incidents (trt-incident label removed?)...")
-		issue, err := queryJiraAPI(unseen, authorization)
+		issue, err := queryJiraAPI(jl.ctx, unseen, authorization)
 		if err != nil {
 			log.WithError(err).Warnf("couldn't query details for %s. this is expected for cards that are restricted to 'Red Hat Only'", unseen)
 			continue
@@ -294,21 +307,23 @@ func (jl *JiraLoader) incidentLoader(authorization string) {
 			log.WithError(err).Errorf("couldn't convert jira issue to db model")
 			continue
 		}
-		if res := jl.dbc.DB.Save(model); res.Error != nil {
+		res := jl.dbc.DB.WithContext(jl.ctx).Save(model)
+		if res.Error != nil {
 			log.WithError(res.Error).Errorf("couldn't save jira incident to DB")
 			jl.errors = append(jl.errors, res.Error)
 			return
 		}
+		jl.rowsWritten += res.RowsAffected
 	}
 
 	log.Infof("jira incident fetch complete in %+v", time.Since(start))
 }
 
 // queryJiraAPI returns a singular jira issue
-func queryJiraAPI(issueID, authorization string) (*v1jira.Issue, error) {
+func queryJiraAPI(ctx context.Context, issueID, authorization string) (*v1jira.Issue, error) {
 	urlFmtStr := "https://redhat.atlassian.net/rest/api/2/issue/%s?expand=changelog"
 	client := &http.Client{}
-	req, err := http.NewRequest("GET", fmt.Sprintf(urlFmtStr, issueID), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(urlFmtStr, issueID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -366,9 +381,9 @@ func issueToDB(issue *v1jira.Issue) (*models.JiraIncident, error) {
 	}, nil
 }
 
-func jiraRequest(apiURL, authorization string) ([]byte, error) {
+func jiraRequest(ctx context.Context, apiURL, authorization string) ([]byte, error) {
 	client := &http.Client{}
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,11 @@
 package loaderwithmetrics
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -27,14 +29,55 @@ var errorMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Buckets: []float64{0, 1, 10, 100, 1000},
 }, []string{"loader"})
 
+// DefaultTimeout is how long a loader may run when no timeout is configured for it.
+const DefaultTimeout = 4 * time.Hour
+
+// Options control how loaders are run.
+type Options struct {
+	// MaxParallel limits how many loaders run at once, 0 means no limit.
+	MaxParallel int
+	// Timeout is how long each loader may run, defaulting to DefaultTimeout.
+	Timeout time.Duration
+	// Timeouts override Timeout for individual loaders, by loader name.
+	Timeouts map[string]time.Duration
+}
+
+// Result summarizes a single loader's run.
+type Result struct {
+	Name     string
+	Duration time.Duration
+	Errors   int
+	// RowsWritten is -1 if the loader does not report it.
+	RowsWritten int64
+	TimedOut    bool
+}
+
+// LoaderWithMetrics runs loaders concurrently once the loaders they depend on have finished,
+// recording prometheus metrics for each.
 type LoaderWithMetrics struct {
+	parent     context.Context
+	opts       Options
 	loaders    []dataloader.DataLoader
+	contexts   map[string]*loaderContext
 	promPusher *push.Pusher
+
+	lock    sync.Mutex
+	results []Result
+	errs    []error
 }
 
-func New(wrappedLoaders []dataloader.DataLoader) *LoaderWithMetrics {
+// loaderContext is the context a loader is constructed with. Its timeout only starts once the loader
+// does, so time spent waiting on dependencies does not count against it.
+type loaderContext struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func New(ctx context.Context, opts Options) *LoaderWithMetrics {
 	loader := &LoaderWithMetrics{
-		loaders: wrappedLoaders,
+		parent:   ctx,
+		opts:     opts,
+		contexts: map[string]*loaderContext{},
 	}
 
 	if pushgateway := os.Getenv("SIPPY_PROMETHEUS_PUSHGATEWAY"); pushgateway != "" {
@@ -46,45 +89,139 @@ func New(wrappedLoaders []dataloader.DataLoader) *LoaderWithMetrics {
 	return loader
 }
 
-var loaderOrder = []string{"release-definitions", "pr-merge-sync", "prow", "releases", "jira", "github", "bugs", "test-mapping", "feature-gates", "regression-cache", "ga-test-status"}
+// Context returns the context to construct the named loader with, which is cancelled once the
+// loader has run for its timeout.
+func (l *LoaderWithMetrics) Context(name string) context.Context {
+	if lc, ok := l.contexts[name]; ok {
+		return lc.ctx
+	}
+	ctx, cancel := context.WithCancelCause(l.parent)
+	l.contexts[name] = &loaderContext{ctx: ctx, cancel: cancel}
+	return ctx
+}
+
+// Add registers a loader to be run by Load.
+func (l *LoaderWithMetrics) Add(loaders ...dataloader.DataLoader) {
+	l.loaders = append(l.loaders, loaders...)
+}
 
-// sortLoaders guarantees that the loaders run in a predictable and proper order
-func (l *LoaderWithMetrics) sortLoaders() {
-	orderIndex := func() map[string]int {
-		m := make(map[string]int, len(loaderOrder))
-		for i, v := range loaderOrder {
-			m[v] = i
-		}
-		return m
-	}()
+func (l *LoaderWithMetrics) timeout(name string) time.Duration {
+	if t, ok := l.opts.Timeouts[name]; ok && t > 0 {
+		return t
+	}
+	if l.opts.Timeout > 0 {
+		return l.opts.Timeout
+	}
+	return DefaultTimeout
+}
 
-	getLoaderIndex := func(name string) int {
-		if index, exists := orderIndex[name]; exists {
-			return index
+// dependencies returns the names of the loaders each loader waits for, limited to those being run.
+func (l *LoaderWithMetrics) dependencies() map[string][]string {
+	present := map[string]bool{}
+	for _, loader := range l.loaders {
+		present[loader.Name()] = true
+	}
+	deps := map[string][]string{}
+	for _, loader := range l.loaders {
+		d, ok := loader.(dataloader.Dependent)
+		if !ok {
+			continue
+		}
+		for _, dep := range d.DependsOn() {
+			if present[dep] && dep != loader.Name() {
+				deps[loader.Name()] = append(deps[loader.Name()], dep)
+			}
 		}
-		// Unknown loaders get placed at the end
-		return len(loaderOrder)
 	}
+	return deps
+}
 
-	sort.Slice(l.loaders, func(i, j int) bool {
-		return getLoaderIndex(l.loaders[i].Name()) < getLoaderIndex(l.loaders[j].Name())
-	})
+// findCycles returns the names of loaders that can never start because they depend on themselves,
+// directly or through other loaders.
+func findCycles(deps map[string][]string) map[string]bool {
+	const (
+		_ = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	cyclic := map[string]bool{}
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case visiting:
+			return true
+		case done:
+			return cyclic[name]
+		}
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if visit(dep) {
+				cyclic[name] = true
+			}
+		}
+		state[name] = done
+		return cyclic[name]
+	}
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		visit(name)
+	}
+	return cyclic
 }
 
 func (l *LoaderWithMetrics) Load() {
-	l.sortLoaders()
 	overallStart := time.Now()
 	log.Infof("starting %d loaders...", len(l.loaders))
-	for _, loader := range l.loaders {
-		log.Infof("starting loader %q with metrics wrapper", loader.Name())
-		start := time.Now()
-		loader.Load()
-		totalTime := time.Since(start)
-		log.Infof("loader %q complete after %+v", loader.Name(), totalTime)
 
-		loadMetric.WithLabelValues(loader.Name()).Observe(float64(totalTime.Milliseconds()))
-		errorMetric.WithLabelValues(loader.Name()).Observe(float64(len(loader.Errors())))
+	deps := l.dependencies()
+	cyclic := findCycles(deps)
+
+	// a loader may be added more than once, so dependents wait for every instance of a name
+	finished := map[string][]chan struct{}{}
+	doneChans := make([]chan struct{}, len(l.loaders))
+	for i, loader := range l.loaders {
+		doneChans[i] = make(chan struct{})
+		finished[loader.Name()] = append(finished[loader.Name()], doneChans[i])
+	}
+
+	var sem chan struct{}
+	if l.opts.MaxParallel > 0 {
+		sem = make(chan struct{}, l.opts.MaxParallel)
+	}
+
+	var wg sync.WaitGroup
+	for i, loader := range l.loaders {
+		wg.Add(1)
+		go func(loader dataloader.DataLoader, done chan struct{}) {
+			defer wg.Done()
+			defer close(done)
+
+			name := loader.Name()
+			if cyclic[name] {
+				l.record(Result{Name: name, Errors: 1, RowsWritten: -1},
+					fmt.Errorf("loader %q was not run due to a dependency cycle: %v", name, deps[name]))
+				return
+			}
+			for _, dep := range deps[name] {
+				log.Infof("loader %q waiting on %q", name, dep)
+				for _, c := range finished[dep] {
+					<-c
+				}
+			}
+			if sem != nil {
+				sem <- struct{}{}
+				defer func() { <-sem }()
+			}
+			l.run(loader)
+		}(loader, doneChans[i])
 	}
+	wg.Wait()
+
 	overallDuration := time.Since(overallStart)
 	log.Infof("%d loaders finished in %+v...", len(l.loaders), overallDuration)
 	loadMetric.WithLabelValues("total").Observe(float64(overallDuration.Milliseconds()))
@@ -99,12 +236,63 @@ func (l *LoaderWithMetrics) Load() {
 	}
 }
 
-func (l *LoaderWithMetrics) Errors() []error {
+var errTimeout = errors.New("loader timed out")
+
+func (l *LoaderWithMetrics) run(loader dataloader.DataLoader) {
+	name := loader.Name()
+	timeout := l.timeout(name)
+	var timer *time.Timer
+	lc, hasContext := l.contexts[name]
+	if hasContext {
+		timer = time.AfterFunc(timeout, func() {
+			log.Warningf("loader %q has not finished after %+v, cancelling it", name, timeout)
+			lc.cancel(errTimeout)
+		})
+	} else {
+		log.Warningf("loader %q was not constructed with Context, its %+v timeout cannot be applied", name, timeout)
+	}
+
+	log.Infof("starting loader %q with metrics wrapper", name)
+	start := time.Now()
+	loader.Load()
+	totalTime := time.Since(start)
+	log.Infof("loader %q complete after %+v", name, totalTime)
+
+	loadMetric.WithLabelValues(name).Observe(float64(totalTime.Milliseconds()))
+	errorMetric.WithLabelValues(name).Observe(float64(len(loader.Errors())))
+
+	result := Result{Name: name, Duration: totalTime, Errors: len(loader.Errors()), RowsWritten: -1}
+	if rc, ok := loader.(dataloader.RowCounter); ok {
+		result.RowsWritten = rc.RowsWritten()
+	}
 	var errs []error
-	for _, loader := range l.loaders {
-		for _, err := range loader.Errors() {
-			errs = append(errs, errors.Wrap(err, fmt.Sprintf("loader %q returned error", loader.Name())))
-		}
+	for _, err := range loader.Errors() {
+		errs = append(errs, errors.Wrap(err, fmt.Sprintf("loader %q returned error", name)))
+	}
+	if hasContext && !timer.Stop() && errors.Is(context.Cause(lc.ctx), errTimeout) {
+		result.TimedOut = true
+		result.Errors++
+		errs = append(errs, fmt.Errorf("loader %q timed out after %+v", name, timeout))
 	}
-	return errs
+	l.record(result, errs...)
+}
+
+func (l *LoaderWithMetrics) record(result Result, errs ...error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.results = append(l.results, result)
+	l.errs = append(l.errs, errs...)
+}
+
+// Results returns a summary of each loader's run, in the order the loaders finished.
+func (l *LoaderWithMetrics) Results() []Result {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]Result(nil), l.results...)
+}
+
+func (l *LoaderWithMetrics) Errors() []error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]error(nil), l.errs...)
 }
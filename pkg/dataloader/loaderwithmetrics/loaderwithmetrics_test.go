@@ -1,7 +1,10 @@
 package loaderwithmetrics
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/openshift/sippy/pkg/dataloader"
@@ -9,7 +12,15 @@ import (
 
 // mockDataLoader implements the DataLoader interface for testing
 type mockDataLoader struct {
-	name string
+	name      string
+	dependsOn []string
+	ctx       context.Context
+	rows      int64
+	// record is called with the loader name when it starts and finishes
+	record func(event string)
+	// block waits for the context to be done when set
+	block bool
+	errs  []error
 }
 
 func (m *mockDataLoader) Name() string {
@@ -17,91 +28,154 @@ func (m *mockDataLoader) Name() string {
 }
 
 func (m *mockDataLoader) Load() {
-	// no-op for testing
+	m.record("start " + m.name)
+	if m.block {
+		<-m.ctx.Done()
+		m.errs = append(m.errs, m.ctx.Err())
+	}
+	m.record("end " + m.name)
 }
 
 func (m *mockDataLoader) Errors() []error {
-	return nil
+	return m.errs
+}
+
+func (m *mockDataLoader) DependsOn() []string {
+	return m.dependsOn
 }
 
-func TestSortLoaders(t *testing.T) {
+func (m *mockDataLoader) RowsWritten() int64 {
+	return m.rows
+}
+
+type eventLog struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (e *eventLog) record(event string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *eventLog) index(event string) int {
+	for i, ev := range e.events {
+		if ev == event {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestLoadRespectsDependencies(t *testing.T) {
 	tests := []struct {
-		name          string
-		inputLoaders  []string
-		expectedOrder []string
+		name         string
+		dependencies map[string][]string
+		// before lists pairs of loaders where the first must finish before the second starts
+		before [][2]string
 	}{
 		{
-			name:          "empty loaders",
-			inputLoaders:  []string{},
-			expectedOrder: []string{},
-		},
-		{
-			name:          "single loader",
-			inputLoaders:  []string{"prow"},
-			expectedOrder: []string{"prow"},
-		},
-		{
-			name:          "all loaders in correct order",
-			inputLoaders:  []string{"prow", "releases", "jira", "github", "bugs", "test-mapping", "feature-gates", "regression-cache"},
-			expectedOrder: []string{"prow", "releases", "jira", "github", "bugs", "test-mapping", "feature-gates", "regression-cache"},
+			name:         "no dependencies",
+			dependencies: map[string][]string{"prow": nil, "jira": nil, "bugs": nil},
 		},
 		{
-			name:          "all loaders in reverse order",
-			inputLoaders:  []string{"regression-cache", "feature-gates", "test-mapping", "bugs", "github", "jira", "releases", "prow"},
-			expectedOrder: []string{"prow", "releases", "jira", "github", "bugs", "test-mapping", "feature-gates", "regression-cache"},
+			name: "chain",
+			dependencies: map[string][]string{
+				"release-definitions": nil,
+				"prow":                {"release-definitions"},
+				"regression-cache":    {"prow"},
+			},
+			before: [][2]string{{"release-definitions", "prow"}, {"prow", "regression-cache"}},
 		},
 		{
-			name:          "random order subset",
-			inputLoaders:  []string{"bugs", "prow", "jira", "releases"},
-			expectedOrder: []string{"prow", "releases", "jira", "bugs"},
+			name: "fan in",
+			dependencies: map[string][]string{
+				"prow":             nil,
+				"test-mapping":     nil,
+				"regression-cache": {"prow", "test-mapping"},
+			},
+			before: [][2]string{{"prow", "regression-cache"}, {"test-mapping", "regression-cache"}},
 		},
 		{
-			name:          "regression-cache runs last among known loaders",
-			inputLoaders:  []string{"regression-cache", "prow"},
-			expectedOrder: []string{"prow", "regression-cache"},
-		},
-		{
-			name:          "unknown loaders with known ones",
-			inputLoaders:  []string{"unknown-loader", "prow", "another-unknown", "releases"},
-			expectedOrder: []string{"prow", "releases", "unknown-loader", "another-unknown"},
-		},
-		{
-			name:          "only unknown loaders",
-			inputLoaders:  []string{"unknown-1", "unknown-2", "unknown-3"},
-			expectedOrder: []string{"unknown-1", "unknown-2", "unknown-3"},
-		},
-		{
-			name:          "mixed known and unknown in random order",
-			inputLoaders:  []string{"unknown-z", "github", "unknown-a", "prow", "unknown-m"},
-			expectedOrder: []string{"prow", "github", "unknown-z", "unknown-a", "unknown-m"},
-		},
-		{
-			name:          "duplicate loaders",
-			inputLoaders:  []string{"prow", "prow", "releases", "releases"},
-			expectedOrder: []string{"prow", "prow", "releases", "releases"},
+			name: "missing dependencies are ignored",
+			dependencies: map[string][]string{
+				"regression-cache": {"prow"},
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockLoaders := make([]dataloader.DataLoader, len(tt.inputLoaders))
-			for i, name := range tt.inputLoaders {
-				mockLoaders[i] = &mockDataLoader{name: name}
+			events := &eventLog{}
+			runner := New(context.Background(), Options{})
+			for name, deps := range tt.dependencies {
+				runner.Add(&mockDataLoader{name: name, dependsOn: deps, record: events.record})
 			}
+			runner.Load()
 
-			loader := &LoaderWithMetrics{
-				loaders: mockLoaders,
+			if len(runner.Errors()) > 0 {
+				t.Fatalf("unexpected errors: %v", runner.Errors())
 			}
-
-			loader.sortLoaders()
-			names := make([]string, len(loader.loaders))
-			for i, l := range loader.loaders {
-				names[i] = l.Name()
+			if len(runner.Results()) != len(tt.dependencies) {
+				t.Fatalf("expected %d results, got %d", len(tt.dependencies), len(runner.Results()))
 			}
-
-			if diff := cmp.Diff(names, tt.expectedOrder); diff != "" {
-				t.Errorf("loaders mismatch (-want +got):\n%s", diff)
+			for _, pair := range tt.before {
+				end, start := events.index("end "+pair[0]), events.index("start "+pair[1])
+				if end < 0 || start < 0 || end > start {
+					t.Errorf("expected %s to finish before %s started: %v", pair[0], pair[1], events.events)
+				}
 			}
 		})
 	}
 }
+
+func TestLoadRunsIndependentLoadersConcurrently(t *testing.T) {
+	events := &eventLog{}
+	runner := New(context.Background(), Options{Timeouts: map[string]time.Duration{"prow": 100 * time.Millisecond}})
+	// prow blocks until its timeout, jira must be able to finish in the meantime
+	runner.Add(&mockDataLoader{name: "prow", ctx: runner.Context("prow"), block: true, record: events.record})
+	runner.Add(&mockDataLoader{name: "jira", rows: 5, record: events.record})
+	runner.Load()
+
+	if events.index("end jira") > events.index("end prow") {
+		t.Errorf("expected jira to finish while prow was running: %v", events.events)
+	}
+
+	results := map[string]Result{}
+	for _, r := range runner.Results() {
+		results[r.Name] = r
+	}
+	if !results["prow"].TimedOut {
+		t.Errorf("expected prow to time out")
+	}
+	if results["jira"].TimedOut || results["jira"].RowsWritten != 5 {
+		t.Errorf("unexpected jira result: %+v", results["jira"])
+	}
+	// the loader's own context error, plus the timeout
+	if len(runner.Errors()) != 2 {
+		t.Errorf("expected 2 errors, got %v", runner.Errors())
+	}
+}
+
+func TestLoadDependencyCycle(t *testing.T) {
+	events := &eventLog{}
+	runner := New(context.Background(), Options{MaxParallel: 1})
+	runner.Add(
+		&mockDataLoader{name: "a", dependsOn: []string{"b"}, record: events.record},
+		&mockDataLoader{name: "b", dependsOn: []string{"a"}, record: events.record},
+		&mockDataLoader{name: "c", dependsOn: []string{"a"}, record: events.record},
+		&mockDataLoader{name: "d", record: events.record},
+	)
+	runner.Load()
+
+	if diff := cmp.Diff([]string{"start d", "end d"}, events.events); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	if len(runner.Errors()) != 3 {
+		t.Errorf("expected 3 errors, got %v", runner.Errors())
+	}
+}
+
+var _ dataloader.Dependent = &mockDataLoader{}
+var _ dataloader.RowCounter = &mockDataLoader{}
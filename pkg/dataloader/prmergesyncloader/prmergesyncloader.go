@@ -26,10 +26,11 @@ type mergedPR struct {
 }
 
 type PRMergeSyncLoader struct {
-	ctx      context.Context
-	dbc      *db.DB
-	ghClient *github.Client
-	errors   []error
+	ctx         context.Context
+	dbc         *db.DB
+	ghClient    *github.Client
+	errors      []error
+	rowsWritten int64
 }
 
 func New(ctx context.Context, dbc *db.DB, ghClient *github.Client) *PRMergeSyncLoader {
@@ -48,6 +49,10 @@ func (l *PRMergeSyncLoader) Errors() []error {
 	return l.errors
 }
 
+func (l *PRMergeSyncLoader) RowsWritten() int64 {
+	return l.rowsWritten
+}
+
 func (l *PRMergeSyncLoader) Load() {
 	if l.ghClient == nil {
 		log.Info("No GitHub client, skipping PR merge sync")
@@ -133,6 +138,7 @@ func (l *PRMergeSyncLoader) sync() error {
 	if flushErr != nil {
 		return flushErr
 	}
+	l.rowsWritten += rowsUpdated + rowsDeleted
 	log.WithField("rows_updated", rowsUpdated).Info("pr-merge-sync: marked PR rows as merged")
 	log.WithField("rows_deleted", rowsDeleted).Info("pr-merge-sync: cleared pending risk analysis comments")
 
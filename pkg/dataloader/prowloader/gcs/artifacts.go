@@ -0,0 +1,163 @@
+package gcs
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// Bucket is a store of prow job artifacts, addressed by object name relative to the bucket.
+// GCS is the default, but prow can also upload to S3-compatible stores, and artifacts can be
+// copied to a local directory tree.
+type Bucket interface {
+	// NewReader opens the named object.
+	NewReader(ctx context.Context, name string) (io.ReadCloser, error)
+	// Exists reports whether the named object exists.
+	Exists(ctx context.Context, name string) bool
+	// List returns the names of objects under prefix, optionally filtered with a GCS style
+	// glob matched against the whole object name. An empty glob matches everything.
+	List(ctx context.Context, prefix, glob string) ([]string, error)
+}
+
+// ArtifactSource provides the bucket for a prow job's decoration config bucket name.
+type ArtifactSource interface {
+	Bucket(name string) Bucket
+}
+
+// bucketName strips the scheme prow allows on decoration config bucket names, i.e. s3://bucket.
+func bucketName(name string) string {
+	if _, after, ok := strings.Cut(name, "://"); ok {
+		return after
+	}
+	return name
+}
+
+// GCSSource serves artifacts from Google Cloud Storage.
+type GCSSource struct {
+	Client *storage.Client
+}
+
+func (s *GCSSource) Bucket(name string) Bucket {
+	return NewGCSBucket(s.Client.Bucket(bucketName(name)))
+}
+
+type gcsBucket struct {
+	bkt *storage.BucketHandle
+}
+
+// NewGCSBucket adapts a GCS bucket handle to a Bucket.
+func NewGCSBucket(bkt *storage.BucketHandle) Bucket {
+	return &gcsBucket{bkt: bkt}
+}
+
+func (b *gcsBucket) NewReader(ctx context.Context, name string) (io.ReadCloser, error) {
+	return b.bkt.Object(name).NewReader(ctx)
+}
+
+func (b *gcsBucket) Exists(ctx context.Context, name string) bool {
+	// if we can get the attrs then presume the object exists
+	// otherwise presume it doesn't
+	_, err := b.bkt.Object(name).Attrs(ctx)
+	return err == nil
+}
+
+// List uses server-side filtering via MatchGlob.
+func (b *gcsBucket) List(ctx context.Context, prefix, glob string) ([]string, error) {
+	q := &storage.Query{
+		Prefix:    prefix,
+		MatchGlob: glob,
+	}
+	if err := q.SetAttrSelection([]string{"Name"}); err != nil {
+		return nil, errors.Wrap(err, "error setting attribute selection")
+	}
+
+	var names []string
+	it := b.bkt.Objects(ctx, q)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading GCS attributes for job run")
+		}
+		names = append(names, attrs.Name)
+	}
+	return names, nil
+}
+
+// globToRegexp converts a GCS MatchGlob pattern to a regular expression matching whole object names,
+// for stores that have to filter client side. "**" matches across path separators, "*" and "?" do not,
+// and "[...]" and "{a,b}" behave as in GCS.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inBraces := false
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			i++
+			if i+1 < len(glob) && glob[i+1] == '/' {
+				// "**/" also matches no directories at all
+				i++
+				sb.WriteString("(?:.*/)?")
+			} else {
+				sb.WriteString(".*")
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return nil, errors.Errorf("unterminated character class in glob %q", glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '{' && !inBraces:
+			inBraces = true
+			sb.WriteString("(?:")
+		case c == ',' && inBraces:
+			sb.WriteString("|")
+		case c == '}' && inBraces:
+			inBraces = false
+			sb.WriteString(")")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if inBraces {
+		return nil, errors.Errorf("unterminated braces in glob %q", glob)
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// filterNames returns the names matching the glob, or all of them if it is empty.
+func filterNames(names []string, glob string) ([]string, error) {
+	if glob == "" {
+		return names, nil
+	}
+	re, err := globToRegexp(glob)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, name := range names {
+		if re.MatchString(name) {
+			matches = append(matches, name)
+		}
+	}
+	return matches, nil
+}
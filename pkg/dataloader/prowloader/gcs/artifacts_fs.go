@@ -0,0 +1,98 @@
+package gcs
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FileSystemSource serves artifacts from a local directory, with one sub-directory per bucket
+// laid out as in the bucket, i.e. <root>/test-platform-results/logs/<job>/<build id>/...
+type FileSystemSource struct {
+	Root string
+}
+
+func (s *FileSystemSource) Bucket(name string) Bucket {
+	return &fsBucket{root: filepath.Join(s.Root, bucketName(name))}
+}
+
+type fsBucket struct {
+	root string
+}
+
+// file returns the local path of an object, refusing names that would escape the bucket directory.
+func (b *fsBucket) file(name string) (string, error) {
+	clean := path.Clean("/" + name)
+	if clean == "/" {
+		return "", errors.Errorf("invalid object name %q", name)
+	}
+	return filepath.Join(b.root, filepath.FromSlash(strings.TrimPrefix(clean, "/"))), nil
+}
+
+func (b *fsBucket) NewReader(_ context.Context, name string) (io.ReadCloser, error) {
+	file, err := b.file(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(file)
+}
+
+func (b *fsBucket) Exists(_ context.Context, name string) bool {
+	file, err := b.file(name)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(file)
+	return err == nil && !info.IsDir()
+}
+
+// List walks the prefix if it is a directory, or otherwise the directory containing it, as object
+// stores match prefixes rather than directories. Job run paths are directories, so listing one
+// does not walk the runs of every other build of the job.
+func (b *fsBucket) List(ctx context.Context, prefix, glob string) ([]string, error) {
+	walkRoot := b.root
+	if prefix != "" {
+		if file, err := b.file(prefix); err == nil {
+			if info, err := os.Stat(file); err == nil && info.IsDir() {
+				walkRoot = file
+			} else if dir, err := b.file(path.Dir(prefix)); err == nil {
+				walkRoot = dir
+			}
+		}
+	}
+
+	var names []string
+	err := filepath.WalkDir(walkRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(b.root, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing %s", walkRoot)
+	}
+	return filterNames(names, glob)
+}
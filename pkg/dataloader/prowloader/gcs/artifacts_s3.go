@@ -0,0 +1,246 @@
+package gcs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Source serves artifacts from an S3-compatible object store such as AWS S3 or MinIO. Buckets
+// are addressed path-style, which all S3-compatible stores support. Requests are signed with AWS
+// signature version 4 when an access key is set, and are anonymous otherwise.
+type S3Source struct {
+	// Endpoint is the base URL of the store, e.g. https://s3.us-east-1.amazonaws.com or http://minio:9000.
+	Endpoint     string
+	Region       string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Client       *http.Client
+}
+
+// NewS3Source creates an S3 source using the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables for credentials.
+func NewS3Source(endpoint, region string) (*S3Source, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("S3 endpoint must be an http(s) URL, got %q", endpoint)
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Source{
+		Endpoint:     strings.TrimSuffix(endpoint, "/"),
+		Region:       region,
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		Client:       &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (s *S3Source) Bucket(name string) Bucket {
+	return &s3Bucket{source: s, name: bucketName(name)}
+}
+
+type s3Bucket struct {
+	source *S3Source
+	name   string
+}
+
+func (b *s3Bucket) NewReader(ctx context.Context, name string) (io.ReadCloser, error) {
+	resp, err := b.do(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error(resp, name)
+	}
+	return resp.Body, nil
+}
+
+func (b *s3Bucket) Exists(ctx context.Context, name string) bool {
+	resp, err := b.do(ctx, http.MethodHead, name, nil)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2 and filters by glob client side, as S3 has no server-side globbing.
+func (b *s3Bucket) List(ctx context.Context, prefix, glob string) ([]string, error) {
+	var names []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, http.MethodGet, "", query)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := s3Error(resp, prefix)
+			resp.Body.Close()
+			return nil, err
+		}
+		result := listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding S3 object listing")
+		}
+		for _, c := range result.Contents {
+			names = append(names, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	return filterNames(names, glob)
+}
+
+func (b *s3Bucket) do(ctx context.Context, method, key string, query url.Values) (*http.Response, error) {
+	rawURL := b.source.Endpoint + "/" + s3Escape(b.name, false)
+	if key != "" {
+		rawURL += "/" + s3Escape(key, false)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = canonicalQuery(query)
+	if b.source.AccessKey != "" {
+		b.source.sign(req, time.Now())
+	}
+	resp, err := b.source.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %s from S3", req.URL.Path)
+	}
+	return resp, nil
+}
+
+func s3Error(resp *http.Response, name string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("S3 returned %s for %s: %s", resp.Status, name, strings.TrimSpace(string(body)))
+}
+
+// sign adds AWS signature version 4 headers to the request, signing every header already set on it.
+func (s *S3Source) sign(req *http.Request, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	}
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	scope := day + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), day)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query parameters sorted by key, as required for signing.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything but unreserved characters, and slashes unless encodeSlash is set.
+func s3Escape(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
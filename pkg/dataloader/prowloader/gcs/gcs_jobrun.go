@@ -8,9 +8,7 @@ import (
 	"regexp"
 
 	"cloud.google.com/go/storage"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/api/iterator"
 
 	"github.com/openshift/sippy/pkg/apis/junit"
 )
@@ -34,9 +32,10 @@ func GetDefaultRiskAnalysisSummaryFile() *regexp.Regexp {
 	return defaultRiskAnalysisSummaryFileRegEx
 }
 
+// GCSJobRun reads the artifacts of a prow job run. Despite the name, the artifacts may be in any Bucket.
 type GCSJobRun struct {
 	// retrieval mechanisms
-	bkt *storage.BucketHandle
+	bkt Bucket
 
 	gcsProwJobPath string
 	gcsJunitPaths  []string
@@ -45,6 +44,11 @@ type GCSJobRun struct {
 }
 
 func NewGCSJobRun(bkt *storage.BucketHandle, path string) *GCSJobRun {
+	return NewJobRun(NewGCSBucket(bkt), path)
+}
+
+// NewJobRun creates a job run reading artifacts under path in the bucket.
+func NewJobRun(bkt Bucket, path string) *GCSJobRun {
 	return &GCSJobRun{
 		bkt:            bkt,
 		gcsProwJobPath: path,
@@ -111,8 +115,7 @@ func (j *GCSJobRun) GetContent(ctx context.Context, path string) ([]byte, error)
 		return content, nil
 	}
 
-	obj := j.bkt.Object(path)
-	gcsReader, err := obj.NewReader(ctx)
+	gcsReader, err := j.bkt.NewReader(ctx, path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading GCS content for jobrun: %w", err)
 	}
@@ -122,13 +125,7 @@ func (j *GCSJobRun) GetContent(ctx context.Context, path string) ([]byte, error)
 }
 
 func (j *GCSJobRun) ContentExists(ctx context.Context, path string) bool {
-	// Get an Object handle for the path
-	obj := j.bkt.Object(path)
-
-	// if we can get the attrs then presume the object exists
-	// otherwise presume it doesn't
-	_, err := obj.Attrs(ctx)
-	return err == nil
+	return j.bkt.Exists(ctx, path)
 }
 
 func (j *GCSJobRun) FindFirstFile(root string, filename *regexp.Regexp) []byte {
@@ -136,21 +133,18 @@ func (j *GCSJobRun) FindFirstFile(root string, filename *regexp.Regexp) []byte {
 		root = j.gcsProwJobPath
 	}
 
-	it := j.bkt.Objects(context.Background(), &storage.Query{
-		Prefix: root,
-	})
-	for {
-		attrs, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-
-		if filename.MatchString(attrs.Name) {
-			data, err := j.GetContent(context.Background(), attrs.Name)
+	names, err := j.bkt.List(context.Background(), root, "")
+	if err != nil {
+		log.WithError(err).Errorf("Error listing files under: %s", root)
+		return nil
+	}
+	for _, name := range names {
+		if filename.MatchString(name) {
+			data, err := j.GetContent(context.Background(), name)
 
 			// if we had an error keep looking, or bail?
 			if err != nil {
-				log.WithError(err).Errorf("Error reading file: %s/%s", root, attrs.Name)
+				log.WithError(err).Errorf("Error reading file: %s/%s", root, name)
 				return nil
 			}
 			return data
@@ -160,29 +154,8 @@ func (j *GCSJobRun) FindFirstFile(root string, filename *regexp.Regexp) []byte {
 	return nil
 }
 
-// FindAllMatches lists GCS objects under the job run path that match the
-// given glob pattern, using server-side filtering via MatchGlob.
+// FindAllMatches lists objects under the job run path that match the
+// given glob pattern, using server-side filtering where the bucket supports it.
 func (j *GCSJobRun) FindAllMatches(ctx context.Context, glob string) ([]string, error) {
-	q := &storage.Query{
-		Prefix:    j.gcsProwJobPath,
-		MatchGlob: glob,
-	}
-	if err := q.SetAttrSelection([]string{"Name"}); err != nil {
-		return nil, errors.Wrap(err, "error setting attribute selection")
-	}
-
-	var matches []string
-	it := j.bkt.Objects(ctx, q)
-	for {
-		attrs, err := it.Next()
-		if errors.Is(err, iterator.Done) {
-			break
-		}
-		if err != nil {
-			return nil, errors.Wrap(err, "error reading GCS attributes for job run")
-		}
-		matches = append(matches, attrs.Name)
-	}
-
-	return matches, nil
+	return j.bkt.List(ctx, j.gcsProwJobPath, glob)
 }
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	gh "github.com/google/go-github/v45/github"
 	ghauth "github.com/jferrl/go-githubauth"
@@ -56,9 +57,26 @@ type Client struct {
 	prCommentDelete     func(org, repo string, updateID int64) error
 	gitHubCoreRateFetch func() (*gh.Rate, error)
 	gitHubListClosedPRs func(org, repo string) ([]*gh.PullRequest, error)
+	checkRunsFetch      func(org, repo, sha, name string) ([]*gh.CheckRun, error)
+	checkRunCreate      func(org, repo string, opts gh.CreateCheckRunOptions) error
+	checkRunUpdate      func(org, repo string, checkRunID int64, opts gh.UpdateCheckRunOptions) error
 	commentMetaRegEx    *regexp.Regexp
 }
 
+// CheckRun is the completed outcome of a check on a commit, as shown in the PR checks list.
+type CheckRun struct {
+	Name string
+	// Conclusion is one of success, failure or neutral.
+	Conclusion string
+	Title      string
+	// Summary and Text are markdown, truncated to the length GitHub accepts.
+	Summary string
+	Text    string
+}
+
+// maxCheckRunOutput is the most characters GitHub accepts in a check run's summary or text.
+const maxCheckRunOutput = 65535
+
 func New(ctx context.Context, org GitHubOrg) *Client {
 	client := &Client{
 		ctx:   ctx,
@@ -134,6 +152,28 @@ func New(ctx context.Context, org GitHubOrg) *Client {
 		}
 	}
 
+	client.checkRunsFetch = func(org, repo, sha, name string) ([]*gh.CheckRun, error) {
+		appID := int64(GitHubAppID)
+		results, _, err := ghc.Checks.ListCheckRunsForRef(client.ctx, org, repo, sha, &gh.ListCheckRunsOptions{
+			CheckName: &name,
+			AppID:     &appID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return results.CheckRuns, nil
+	}
+
+	client.checkRunCreate = func(org, repo string, opts gh.CreateCheckRunOptions) error {
+		_, _, err := ghc.Checks.CreateCheckRun(client.ctx, org, repo, opts)
+		return err
+	}
+
+	client.checkRunUpdate = func(org, repo string, checkRunID int64, opts gh.UpdateCheckRunOptions) error {
+		_, _, err := ghc.Checks.UpdateCheckRun(client.ctx, org, repo, checkRunID, opts)
+		return err
+	}
+
 	client.commentMetaRegEx = regexp.MustCompile(commentIDRegex)
 
 	return client
@@ -331,6 +371,58 @@ func (c *Client) DeletePRComment(org, repo string, updateID int64) error {
 	return err
 }
 
+// UpsertCheckRun completes the named check run on sha, updating the one our app already created
+// for it, if any, so that there is only one per commit.
+func (c *Client) UpsertCheckRun(org, repo, sha string, run CheckRun) error {
+	existing, err := c.checkRunsFetch(org, repo, sha, run.Name)
+	if err != nil {
+		return err
+	}
+
+	status := "completed"
+	completedAt := gh.Timestamp{Time: time.Now()}
+	output := &gh.CheckRunOutput{
+		Title:   &run.Title,
+		Summary: gh.String(truncateCheckRunOutput(run.Summary)),
+	}
+	if run.Text != "" {
+		output.Text = gh.String(truncateCheckRunOutput(run.Text))
+	}
+
+	for _, checkRun := range existing {
+		if checkRun.ID != nil {
+			return c.checkRunUpdate(org, repo, *checkRun.ID, gh.UpdateCheckRunOptions{
+				Name:        run.Name,
+				Status:      &status,
+				Conclusion:  &run.Conclusion,
+				CompletedAt: &completedAt,
+				Output:      output,
+			})
+		}
+	}
+	return c.checkRunCreate(org, repo, gh.CreateCheckRunOptions{
+		Name:        run.Name,
+		HeadSHA:     sha,
+		Status:      &status,
+		Conclusion:  &run.Conclusion,
+		CompletedAt: &completedAt,
+		Output:      output,
+	})
+}
+
+func truncateCheckRunOutput(markdown string) string {
+	const truncated = "\n\n_Truncated, see the job runs for the full analysis._"
+	if len(markdown) <= maxCheckRunOutput {
+		return markdown
+	}
+	cut := maxCheckRunOutput - len(truncated)
+	// don't split a multi-byte character
+	for cut > 0 && !utf8.RuneStart(markdown[cut]) {
+		cut--
+	}
+	return markdown[:cut] + truncated
+}
+
 func (c *Client) FindCommentID(org, repo string, number int, commentKey, commentID string) (*int64, *string, error) {
 	comments, err := c.prCommentsFetch(org, repo, number)
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	gh "github.com/google/go-github/v45/github"
 )
@@ -218,3 +220,48 @@ func TestClient_IsCommentIdMatch(t *testing.T) {
 	}
 
 }
+
+func TestClient_UpsertCheckRun(t *testing.T) {
+	var existing []*gh.CheckRun
+	var created []gh.CreateCheckRunOptions
+	var updated []int64
+	client := &Client{
+		checkRunsFetch: func(org, repo, sha, name string) ([]*gh.CheckRun, error) {
+			return existing, nil
+		},
+		checkRunCreate: func(org, repo string, opts gh.CreateCheckRunOptions) error {
+			created = append(created, opts)
+			existing = []*gh.CheckRun{{ID: gh.Int64(42), Name: &opts.Name}}
+			return nil
+		},
+		checkRunUpdate: func(org, repo string, checkRunID int64, opts gh.UpdateCheckRunOptions) error {
+			updated = append(updated, checkRunID)
+			return nil
+		},
+	}
+
+	run := CheckRun{Name: "risk", Conclusion: "failure", Title: "High risk", Summary: "summary"}
+	if err := client.UpsertCheckRun(openshift, kubernetes, "sha1", run); err != nil {
+		t.Fatalf("UpsertCheckRun() error = %v", err)
+	}
+	if len(created) != 1 || created[0].HeadSHA != "sha1" || *created[0].Conclusion != "failure" {
+		t.Fatalf("expected a check run to be created for sha1, got %+v", created)
+	}
+	if err := client.UpsertCheckRun(openshift, kubernetes, "sha1", run); err != nil {
+		t.Fatalf("UpsertCheckRun() error = %v", err)
+	}
+	if len(created) != 1 || len(updated) != 1 || updated[0] != 42 {
+		t.Errorf("expected the existing check run to be updated, created %d, updated %v", len(created), updated)
+	}
+}
+
+func TestTruncateCheckRunOutput(t *testing.T) {
+	long := strings.Repeat("é", maxCheckRunOutput)
+	truncated := truncateCheckRunOutput(long)
+	if len(truncated) > maxCheckRunOutput || !utf8.ValidString(truncated) {
+		t.Errorf("expected valid output of at most %d bytes, got %d bytes", maxCheckRunOutput, len(truncated))
+	}
+	if truncateCheckRunOutput("short") != "short" {
+		t.Error("expected short output to be unchanged")
+	}
+}
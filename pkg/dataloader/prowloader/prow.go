@@ -52,13 +52,15 @@ import (
 )
 
 // gcsPathStrip is used to strip out everything but the path, i.e. match "/view/gs/origin-ci-test/"
-// from the path "/view/gs/origin-ci-test/logs/periodic-ci-openshift-release-master-nightly-4.14-e2e-gcp-sdn/1737420379221135360"
-var gcsPathStrip = regexp.MustCompile(`.*/gs/[^/]+/`)
+// from the path "/view/gs/origin-ci-test/logs/periodic-ci-openshift-release-master-nightly-4.14-e2e-gcp-sdn/1737420379221135360".
+// Prow instances uploading to S3 use "/view/s3/<bucket>/" instead.
+var gcsPathStrip = regexp.MustCompile(`.*/(?:gs|s3)/[^/]+/`)
 
 type ProwLoader struct {
 	ctx                          context.Context
 	dbc                          *db.DB
 	errors                       []error
+	rowsWritten                  int64
 	githubClient                 *github.Client
 	bigQueryClient               *bqcachedclient.Client
 	maxConcurrency               int
@@ -71,7 +73,7 @@ type ProwLoader struct {
 	releaseRegexps               map[string][]*regexp.Regexp
 	config                       *v1config.SippyConfig
 	ghCommenter                  *commenter.GitHubCommenter
-	gcsClient                    *storage.Client
+	artifacts                    gcs.ArtifactSource
 	promPusher                   *push.Pusher
 	loadSince                    *time.Time
 	labelsCache                  map[string]pq.StringArray
@@ -81,7 +83,7 @@ type ProwLoader struct {
 func New(
 	ctx context.Context,
 	dbc *db.DB,
-	gcsClient *storage.Client,
+	artifacts gcs.ArtifactSource,
 	bigQueryClient *bqcachedclient.Client,
 	githubClient *github.Client,
 	variantManager testidentification.VariantManager,
@@ -110,7 +112,7 @@ func New(
 	return &ProwLoader{
 		ctx:                          ctx,
 		dbc:                          dbc,
-		gcsClient:                    gcsClient,
+		artifacts:                    artifacts,
 		githubClient:                 githubClient,
 		bigQueryClient:               bigQueryClient,
 		maxConcurrency:               50,
@@ -180,6 +182,16 @@ func (pl *ProwLoader) Errors() []error {
 	return pl.errors
 }
 
+// DependsOn returns the loaders that must finish before prow jobs are loaded.
+func (pl *ProwLoader) DependsOn() []string {
+	return []string{"release-definitions", "pr-merge-sync"}
+}
+
+// RowsWritten returns the number of job runs and job run test results written.
+func (pl *ProwLoader) RowsWritten() int64 {
+	return pl.rowsWritten
+}
+
 // partitionStartDate computes the start of the date range for which partitions must
 // exist to accommodate the given prowJobs. loadSince (minus a 1 day grace period, since
 // bq imports based on modified time which can include job_run_start_time a day earlier,
@@ -568,7 +580,15 @@ func (pl *ProwLoader) findNewJobRunIDs(ctx context.Context, candidateIDs []uint)
 	return sets.New(newIDs...), nil
 }
 
+// fetchJobsJSON reads the prowjobs.js content from the prow URL, which may also be a local
+// file path or file:// URL when loading from a local artifacts tree.
 func fetchJobsJSON(prowURL string) ([]byte, error) {
+	if path, ok := strings.CutPrefix(prowURL, "file://"); ok {
+		return os.ReadFile(path)
+	}
+	if !strings.Contains(prowURL, "://") {
+		return os.ReadFile(prowURL)
+	}
 	resp, err := http.Get(prowURL) // #nosec G107
 	if err != nil {
 		return nil, err
@@ -759,8 +779,8 @@ func (pl *ProwLoader) fetchJobRunResult(ctx context.Context, pj *prow.ProwJob) (
 		return nil, err
 	}
 
-	bkt := pl.gcsClient.Bucket(pj.Spec.DecorationConfig.GCSConfiguration.Bucket)
-	gcsJobRun := gcs.NewGCSJobRun(bkt, path)
+	bkt := pl.artifacts.Bucket(pj.Spec.DecorationConfig.GCSConfiguration.Bucket)
+	gcsJobRun := gcs.NewJobRun(bkt, path)
 	junitMatches, err := gcsJobRun.FindAllMatches(ctx, gcs.GlobJunitXML)
 	if err != nil {
 		return nil, errors.Wrap(err, "error finding junit files")
@@ -834,7 +854,13 @@ func (pl *ProwLoader) buildJobRunResult(ctx context.Context, pj *prow.ProwJob, i
 
 func (pl *ProwLoader) accumulateAndWriteJobRuns(ctx context.Context, results <-chan *pgwriter.JobRunResult) {
 	pl.accumulateAndWrite(ctx, results, func(ctx context.Context, batch []pgwriter.JobRunResult) error {
-		return pgwriter.Write(ctx, pl.dbc, pl.currentDate, batch)
+		if err := pgwriter.Write(ctx, pl.dbc, pl.currentDate, batch); err != nil {
+			return err
+		}
+		for i := range batch {
+			pl.rowsWritten += 1 + int64(len(batch[i].Tests))
+		}
+		return nil
 	})
 }
 
@@ -1062,8 +1088,8 @@ type testCaseKey struct {
 }
 
 func (pl *ProwLoader) prowJobRunTestsFromGCS(ctx context.Context, pj *prow.ProwJob, id, prowJobID uint, prowJobRelease, path string, junitPaths []string) ([]pgwriter.TestRow, int, int, sippyprocessingv1.JobOverallResult, error) {
-	bkt := pl.gcsClient.Bucket(pj.Spec.DecorationConfig.GCSConfiguration.Bucket)
-	gcsJobRun := gcs.NewGCSJobRun(bkt, path)
+	bkt := pl.artifacts.Bucket(pj.Spec.DecorationConfig.GCSConfiguration.Bucket)
+	gcsJobRun := gcs.NewJobRun(bkt, path)
 	gcsJobRun.SetGCSJunitPaths(junitPaths)
 	suites, err := gcsJobRun.GetCombinedJUnitTestSuites(ctx)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -292,3 +293,33 @@ func TestPartitionStartDate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGCSPathForProwJobURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://prow.ci.openshift.org/view/gs/test-platform-results/logs/periodic-ci-e2e-aws/1737420379221135360",
+			expected: "logs/periodic-ci-e2e-aws/1737420379221135360",
+		},
+		{
+			url:      "https://prow.example.com/view/s3/ci-artifacts/pr-logs/pull/org_repo/1/pull-e2e/42",
+			expected: "pr-logs/pull/org_repo/1/pull-e2e/42",
+		},
+		{
+			url: "https://prow.example.com/logs/periodic-ci-e2e-aws/1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			path, err := GetGCSPathForProwJobURL(log.WithField("test", t.Name()), tt.url)
+			if tt.expected == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
@@ -38,6 +38,7 @@ const MaxRegressionsToCache = 300
 //   - view.PrimeCache.Enabled: cache component report and test details in Redis
 //   - view.RegressionTracking.Enabled: sync regressions to Postgres, track job runs
 type RegressionCacheLoader struct {
+	ctx    context.Context
 	dbc    *db.DB
 	errs   []error
 	views  []crview.View
@@ -55,6 +56,7 @@ type RegressionCacheLoader struct {
 }
 
 func New(
+	ctx context.Context,
 	dbc *db.DB,
 	dataProvider dataprovider.DataProvider,
 	config *configv1.SippyConfig,
@@ -72,6 +74,7 @@ func New(
 	}
 
 	return &RegressionCacheLoader{
+		ctx:                  ctx,
 		dbc:                  dbc,
 		dataProvider:         dataProvider,
 		config:               config,
@@ -92,10 +95,14 @@ func (l *RegressionCacheLoader) Errors() []error {
 	return l.errs
 }
 
-func (l *RegressionCacheLoader) Load() {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Hour*1)
-	defer cancel()
+// DependsOn returns the loaders that must finish first, so that regressions are calculated from
+// the newly loaded job runs and test ownership.
+func (l *RegressionCacheLoader) DependsOn() []string {
+	return []string{"release-definitions", "prow", "test-mapping"}
+}
 
+func (l *RegressionCacheLoader) Load() {
+	ctx := l.ctx
 	start := time.Now()
 	defer func() {
 		l.logger.WithField("duration", time.Since(start)).Info("regression cache load cycle complete")
@@ -211,7 +218,7 @@ func (l *RegressionCacheLoader) Load() {
 	}
 }
 
-// processView handles a single view: generates the component report, caches it if needed,
+// processView handles a single view: generates the component report, caches it if needed, snapshots it,
 // syncs regressions if needed, generates test details, caches them, and syncs job runs.
 // Returns the list of active regressions for this view (nil if regression tracking is disabled).
 func (l *RegressionCacheLoader) processView(
@@ -237,6 +244,12 @@ func (l *RegressionCacheLoader) processView(
 		return nil, err
 	}
 
+	// Keep a daily snapshot of the report for history and diffs. Failing to do so should not
+	// hold up regression tracking.
+	if err := componentreadiness.SaveReportSnapshot(l.dbc.DB, view, report, time.Now()); err != nil {
+		vLog.WithError(err).Error("error saving component report snapshot")
+	}
+
 	// Step 2: Sync regressions if enabled
 	var activeRegressions []*models.TestRegression
 	if view.RegressionTracking.Enabled {
@@ -477,9 +490,7 @@ func (l *RegressionCacheLoader) closeRolledOffRegressions(release string, active
 			continue
 		}
 		rLog.Infof("closing regression no longer in any report: %v", reg)
-		reg.Closed.Valid = true
-		reg.Closed.Time = now
-		if err := l.regressionStore.UpdateRegression(reg); err != nil {
+		if err := l.regressionStore.CloseRegression(reg, now); err != nil {
 			errs = append(errs, fmt.Errorf("error closing regression %d: %w", reg.ID, err))
 			continue
 		}
@@ -1,6 +1,7 @@
 package regressioncacheloader
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,7 +12,7 @@ import (
 // this guard a caller would receive a valid-looking loader that later panics when
 // Load dereferences the provider via dataProvider.Cache().
 func TestNew_NilDataProvider(t *testing.T) {
-	loader, err := New(nil, nil, nil, nil, nil, 0, 0, nil)
+	loader, err := New(context.Background(), nil, nil, nil, nil, nil, 0, 0, nil)
 	require.Error(t, err)
 	assert.Nil(t, loader)
 	assert.Contains(t, err.Error(), "dataProvider must not be nil")
@@ -18,10 +18,11 @@ import (
 
 // ReleaseDefinitionLoader fetches release metadata from BigQuery and syncs it to PostgreSQL.
 type ReleaseDefinitionLoader struct {
-	ctx      context.Context
-	dbc      *db.DB
-	bqClient *bqcachedclient.Client
-	errs     []error
+	ctx         context.Context
+	dbc         *db.DB
+	bqClient    *bqcachedclient.Client
+	errs        []error
+	rowsWritten int64
 }
 
 func NewReleaseDefinitionLoader(ctx context.Context, dbc *db.DB, bqClient *bqcachedclient.Client) *ReleaseDefinitionLoader {
@@ -46,7 +47,9 @@ func (l *ReleaseDefinitionLoader) Load() {
 	for _, row := range releaseRows {
 		defs = append(defs, ReleaseRowToDefinition(row))
 	}
-	if err := syncReleaseDefinitions(l.dbc, defs); err != nil {
+	rows, err := syncReleaseDefinitions(l.dbc, defs)
+	l.rowsWritten += rows
+	if err != nil {
 		l.errs = append(l.errs, fmt.Errorf("syncing release definitions: %w", err))
 	}
 }
@@ -55,18 +58,24 @@ func (l *ReleaseDefinitionLoader) Errors() []error {
 	return l.errs
 }
 
-func syncReleaseDefinitions(dbc *db.DB, defs []models.ReleaseDefinition) error {
+func (l *ReleaseDefinitionLoader) RowsWritten() int64 {
+	return l.rowsWritten
+}
+
+func syncReleaseDefinitions(dbc *db.DB, defs []models.ReleaseDefinition) (int64, error) {
+	var rows int64
 	for _, def := range defs {
-		err := dbc.DB.Clauses(clause.OnConflict{
+		res := dbc.DB.Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "release"}},
 			DoUpdates: clause.AssignmentColumns([]string{"major", "minor", "patch", "previous_release", "ga_date", "development_start_date", "product", "status", "capabilities", "updated_at"}),
-		}).Create(&def).Error
-		if err != nil {
-			return fmt.Errorf("upserting release definition %s: %w", def.Release, err)
+		}).Create(&def)
+		if res.Error != nil {
+			return rows, fmt.Errorf("upserting release definition %s: %w", def.Release, res.Error)
 		}
+		rows += res.RowsAffected
 	}
 	log.WithField("count", len(defs)).Info("synced release definitions to postgres")
-	return nil
+	return rows, nil
 }
 
 // ReleaseRowToDefinition converts a BigQuery ReleaseRow directly to a ReleaseDefinition DB model.
@@ -80,6 +80,11 @@ func (r *ReleaseLoader) Errors() []error {
 	return r.errors
 }
 
+// DependsOn returns the loaders that must finish before release tags are loaded.
+func (r *ReleaseLoader) DependsOn() []string {
+	return []string{"release-definitions"}
+}
+
 type tagWithStream struct {
 	Tag    ReleaseTag
 	Stream ReleaseStream
@@ -20,6 +20,7 @@ type TestOwnershipLoader struct {
 	dbc             *db.DB
 	mappingTableMgr *bigquery.MappingTableManager
 	errors          []error
+	rowsWritten     int64
 }
 
 func New(ctx context.Context, dbc *db.DB, googleServiceAccountCredentialFile, googleOAuthClientCredentialFile string) (*TestOwnershipLoader, error) {
@@ -37,7 +38,7 @@ func New(ctx context.Context, dbc *db.DB, googleServiceAccountCredentialFile, go
 }
 
 func (tol *TestOwnershipLoader) Name() string {
-	return "test ownership"
+	return "test-mapping"
 }
 
 func (tol *TestOwnershipLoader) Load() {
@@ -168,6 +169,7 @@ func (tol *TestOwnershipLoader) loadMappings(mappings []v1.TestOwnership) error
 		log.WithField("components", nullComponents).Warn("test ownership rows have unresolved jira_component_id")
 	}
 
+	tol.rowsWritten += known + deleteTag.RowsAffected()
 	log.WithFields(log.Fields{
 		"known":    known,
 		"unknown":  unknown,
@@ -181,3 +183,13 @@ func (tol *TestOwnershipLoader) loadMappings(mappings []v1.TestOwnership) error
 func (tol *TestOwnershipLoader) Errors() []error {
 	return tol.errors
 }
+
+// DependsOn returns the loaders that must finish first. Ownership is only recorded for tests sippy
+// already knows about, so tests first seen by the prow loader are picked up in the same load.
+func (tol *TestOwnershipLoader) DependsOn() []string {
+	return []string{"prow"}
+}
+
+func (tol *TestOwnershipLoader) RowsWritten() int64 {
+	return tol.rowsWritten
+}
@@ -14,18 +14,21 @@ import (
 )
 
 type VariantSyncer struct {
-	dbc    *db.DB
-	mgr    testidentification.VariantManager
-	errors []error
+	ctx         context.Context
+	dbc         *db.DB
+	mgr         testidentification.VariantManager
+	errors      []error
+	rowsWritten int64
 }
 
-func New(dbc *db.DB, bqc *bqcached.Client) (*VariantSyncer, error) {
-	mgr, err := testidentification.NewOpenshiftVariantManager(context.TODO(), bqc)
+func New(ctx context.Context, dbc *db.DB, bqc *bqcached.Client) (*VariantSyncer, error) {
+	mgr, err := testidentification.NewOpenshiftVariantManager(ctx, bqc)
 	if err != nil {
 		return nil, err
 	}
 
 	return &VariantSyncer{
+		ctx: ctx,
 		dbc: dbc,
 		mgr: mgr,
 	}, nil
@@ -39,9 +42,23 @@ func (vl *VariantSyncer) Errors() []error {
 	return vl.errors
 }
 
+// DependsOn returns the loaders that must finish first, so that the expected variants are current
+// and jobs first seen by the prow loader are synced too.
+func (vl *VariantSyncer) DependsOn() []string {
+	return []string{"job-variants", "prow"}
+}
+
+func (vl *VariantSyncer) RowsWritten() int64 {
+	return vl.rowsWritten
+}
+
 func (vl *VariantSyncer) Load() {
-	allJobs := loadAllProwJobs(vl.dbc)
+	allJobs := loadAllProwJobs(vl.ctx, vl.dbc)
 	for _, j := range allJobs {
+		if err := vl.ctx.Err(); err != nil {
+			vl.errors = append(vl.errors, err)
+			return
+		}
 		log.Debugf("syncing variants for %s", j.Name)
 		newVariants := vl.mgr.IdentifyVariants(j.Name)
 		if !reflect.DeepEqual(newVariants, []string(j.Variants)) {
@@ -51,17 +68,19 @@ func (vl *VariantSyncer) Load() {
 				"updated":  strings.Join(newVariants, ", "),
 			}).Debugf("mismatched; updating database")
 			j.Variants = newVariants
-			if res := vl.dbc.DB.WithContext(context.TODO()).Save(j); res.Error != nil {
+			if res := vl.dbc.DB.WithContext(vl.ctx).Save(j); res.Error != nil {
 				vl.errors = append(vl.errors, res.Error)
+			} else {
+				vl.rowsWritten += res.RowsAffected
 			}
 		}
 	}
 }
 
-func loadAllProwJobs(dbc *db.DB) map[string]*models.ProwJob {
+func loadAllProwJobs(ctx context.Context, dbc *db.DB) map[string]*models.ProwJob {
 	results := map[string]*models.ProwJob{}
 	var allJobs []*models.ProwJob
-	dbc.DB.Model(&models.ProwJob{}).Find(&allJobs)
+	dbc.DB.WithContext(ctx).Model(&models.ProwJob{}).Find(&allJobs)
 	for _, j := range allJobs {
 		if _, ok := results[j.Name]; !ok {
 			results[j.Name] = j
@@ -0,0 +1,274 @@
+// Package chatconversation finds, tags, exports and expires shared chat conversations.
+//
+// Tags are kept in the conversation's Metadata under "tags", so that they are stored alongside the
+// persona and page context the chat UI already records there. Searches use PostgreSQL text search
+// over the content of the messages, backed by the index created by CreateIndexes.
+package chatconversation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+const (
+	// MaxTags is the most tags a conversation may have, and MaxTagLength the longest tag.
+	MaxTags      = 20
+	MaxTagLength = 50
+
+	// maxTitleLength is the longest Title of a Summary, in characters.
+	maxTitleLength = 120
+
+	// searchVector is the text search vector of a conversation's message content. It must match
+	// the expression of the index created by CreateIndexes for searches to use it.
+	searchVector = "to_tsvector('english', jsonb_path_query_array(messages, '$[*].content'))"
+)
+
+var (
+	// ErrNotFound is returned for a conversation that does not exist or has been deleted.
+	ErrNotFound = errors.New("conversation not found")
+	// ErrNotOwner is returned when a user changes a conversation someone else shared.
+	ErrNotOwner = errors.New("conversation belongs to another user")
+)
+
+// CreateIndexes creates the text search index over the content of conversation messages.
+func CreateIndexes(dbc *gorm.DB) error {
+	if err := dbc.Exec("CREATE INDEX IF NOT EXISTS idx_chat_conversations_search ON chat_conversations USING GIN (" + searchVector + ")").Error; err != nil {
+		return fmt.Errorf("failed to create text search index on chat_conversations.messages: %w", err)
+	}
+	return nil
+}
+
+// Filter selects the conversations to list.
+type Filter struct {
+	// User only lists conversations shared by this user.
+	User string
+	// Tag only lists conversations with this tag.
+	Tag string
+	// Query is a web search style text query, e.g. `"etcd leader" -metal`, matched against
+	// message content. Results are ordered by relevance instead of newest first.
+	Query string
+	// Limit and Offset page through the results.
+	Limit  int
+	Offset int
+}
+
+// Summary describes a conversation in a listing, without its messages.
+type Summary struct {
+	ID        uuid.UUID  `json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	User      string     `json:"user"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	// Title is the start of the first user message.
+	Title        string   `json:"title"`
+	MessageCount int      `json:"message_count"`
+	Persona      string   `json:"persona,omitempty"`
+	Tags         []string `json:"tags"`
+	// Links contains REST links for clients to follow. Most notably "self".
+	Links map[string]string `json:"links,omitempty"`
+}
+
+type summaryRow struct {
+	ID           uuid.UUID
+	CreatedAt    time.Time
+	User         string
+	ParentID     *uuid.UUID
+	Metadata     pgtype.JSONB
+	MessageCount int
+	Title        *string
+}
+
+// List returns the conversations matching the filter and how many there are in total.
+func List(ctx context.Context, dbc *gorm.DB, filter Filter) ([]Summary, int64, error) {
+	q := dbc.WithContext(ctx).Model(&models.ChatConversation{})
+	if filter.User != "" {
+		q = q.Where(`"user" = ?`, filter.User)
+	}
+	if filter.Tag != "" {
+		tagJSON, err := json.Marshal(map[string][]string{"tags": {filter.Tag}})
+		if err != nil {
+			return nil, 0, err
+		}
+		q = q.Where("metadata @> ?::jsonb", string(tagJSON))
+	}
+	if filter.Query != "" {
+		q = q.Where(searchVector+" @@ websearch_to_tsquery('english', ?)", filter.Query)
+	}
+
+	var total int64
+	if err := q.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("counting chat conversations: %w", err)
+	}
+
+	q = q.Session(&gorm.Session{}).Select(`id, created_at, "user", parent_id, metadata,
+		jsonb_array_length(messages) AS message_count,
+		(SELECT m->>'content' FROM jsonb_array_elements(messages) m WHERE m->>'type' = 'user' LIMIT 1) AS title`)
+	if filter.Query != "" {
+		q = q.Order(gorm.Expr("ts_rank("+searchVector+", websearch_to_tsquery('english', ?)) DESC", filter.Query))
+	}
+	q = q.Order("created_at DESC").Order("id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		q = q.Offset(filter.Offset)
+	}
+	var rows []summaryRow
+	if err := q.Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("listing chat conversations: %w", err)
+	}
+
+	summaries := make([]Summary, 0, len(rows))
+	for _, row := range rows {
+		summary := Summary{
+			ID:           row.ID,
+			CreatedAt:    row.CreatedAt,
+			User:         row.User,
+			ParentID:     row.ParentID,
+			MessageCount: row.MessageCount,
+			Tags:         Tags(row.Metadata),
+		}
+		if row.Title != nil {
+			summary.Title = truncate(strings.Join(strings.Fields(*row.Title), " "), maxTitleLength)
+		}
+		if metadata := metadataMap(row.Metadata); metadata != nil {
+			summary.Persona, _ = metadata["persona"].(string)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, total, nil
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-1]) + "…"
+}
+
+func metadataMap(metadata pgtype.JSONB) map[string]interface{} {
+	if metadata.Status != pgtype.Present {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(metadata.Bytes, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// Tags returns the tags stored in conversation metadata.
+func Tags(metadata pgtype.JSONB) []string {
+	tags := []string{}
+	raw, _ := metadataMap(metadata)["tags"].([]interface{})
+	for _, t := range raw {
+		if tag, ok := t.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// NormalizeTags trims, de-duplicates and sorts tags, and checks that there are not too many, nor
+// any too long.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > MaxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, MaxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("a conversation can have at most %d tags", MaxTags)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// NormalizeMetadataTags normalizes the "tags" of metadata submitted with a new conversation,
+// which must be a list of strings if present.
+func NormalizeMetadataTags(metadata map[string]interface{}) error {
+	raw, ok := metadata["tags"]
+	if !ok {
+		return nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return fmt.Errorf("metadata tags must be a list of strings")
+	}
+	tags := make([]string, 0, len(list))
+	for _, t := range list {
+		tag, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("metadata tags must be a list of strings")
+		}
+		tags = append(tags, tag)
+	}
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	metadata["tags"] = normalized
+	return nil
+}
+
+// SetTags replaces the tags of a conversation shared by user, and returns the normalized tags.
+func SetTags(ctx context.Context, dbc *gorm.DB, id uuid.UUID, user string, tags []string) ([]string, error) {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	tagsJSON, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbc.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var conversations []models.ChatConversation
+		if err := tx.Select(`id, "user"`).Where("id = ?", id).Limit(1).Find(&conversations).Error; err != nil {
+			return fmt.Errorf("looking up chat conversation %s: %w", id, err)
+		}
+		if len(conversations) == 0 {
+			return ErrNotFound
+		}
+		if conversations[0].User != user {
+			return ErrNotOwner
+		}
+		return tx.Model(&models.ChatConversation{}).Where("id = ?", id).
+			Update("metadata", gorm.Expr(
+				"jsonb_set(CASE WHEN jsonb_typeof(metadata) = 'object' THEN metadata ELSE '{}'::jsonb END, '{tags}', ?::jsonb)",
+				string(tagsJSON))).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// SoftDeleteCreatedBefore soft-deletes the conversations shared before cutoff, and returns how
+// many there were.
+func SoftDeleteCreatedBefore(ctx context.Context, dbc *gorm.DB, cutoff time.Time) (int64, error) {
+	res := dbc.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&models.ChatConversation{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("deleting chat conversations created before %s: %w", cutoff.Format(time.RFC3339), res.Error)
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,105 @@
+package chatconversation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+func jsonb(t *testing.T, s string) pgtype.JSONB {
+	var j pgtype.JSONB
+	require.NoError(t, j.Set([]byte(s)))
+	return j
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" etcd ", "", "disruption", "etcd"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"disruption", "etcd"}, tags)
+
+	tags, err = NormalizeTags(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, tags)
+
+	_, err = NormalizeTags([]string{strings.Repeat("x", MaxTagLength+1)})
+	assert.Error(t, err)
+
+	tooMany := make([]string, 0, MaxTags+1)
+	for i := 0; i <= MaxTags; i++ {
+		tooMany = append(tooMany, strings.Repeat("t", i+1))
+	}
+	_, err = NormalizeTags(tooMany)
+	assert.Error(t, err)
+}
+
+func TestNormalizeMetadataTags(t *testing.T) {
+	metadata := map[string]interface{}{"persona": "default", "tags": []interface{}{"b", "a"}}
+	require.NoError(t, NormalizeMetadataTags(metadata))
+	assert.Equal(t, []string{"a", "b"}, metadata["tags"])
+
+	require.NoError(t, NormalizeMetadataTags(map[string]interface{}{"persona": "default"}))
+
+	assert.Error(t, NormalizeMetadataTags(map[string]interface{}{"tags": "a"}))
+	assert.Error(t, NormalizeMetadataTags(map[string]interface{}{"tags": []interface{}{"a", 1.0}}))
+}
+
+func TestTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, Tags(jsonb(t, `{"tags": ["a", "b"]}`)))
+	assert.Equal(t, []string{}, Tags(jsonb(t, `{"persona": "default"}`)))
+	assert.Equal(t, []string{}, Tags(jsonb(t, `null`)))
+	assert.Equal(t, []string{}, Tags(pgtype.JSONB{Status: pgtype.Null}))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abcd…", truncate("abcdefgh", 5))
+	assert.Equal(t, "ééé…", truncate("éééééé", 4))
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	parent := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	conversation := models.ChatConversation{
+		ID:        uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		CreatedAt: time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+		User:      "jdoe",
+		ParentID:  &parent,
+		Messages: jsonb(t, `[
+			{"type": "user", "content": "Why is etcd failing?", "timestamp": "2025-06-01T09:00:00Z"},
+			{"type": "thinking_step", "content": ""},
+			{"type": "assistant", "content": "The **leader** changed.\n\nSee the logs.", "timestamp": "2025-06-01T09:00:05Z"}
+		]`),
+		Metadata: jsonb(t, `{"persona": "default", "tags": ["etcd"]}`),
+	}
+
+	var sb strings.Builder
+	require.NoError(t, WriteMarkdown(&sb, conversation))
+	assert.Equal(t, `# Chat conversation 22222222-2222-2222-2222-222222222222
+
+- Shared by: jdoe
+- Shared at: 2025-06-01T09:30:00Z
+- Forked from: 11111111-1111-1111-1111-111111111111
+- Persona: default
+- Tags: etcd
+
+## User
+
+_2025-06-01T09:00:00Z_
+
+Why is etcd failing?
+
+## Assistant
+
+_2025-06-01T09:00:05Z_
+
+The **leader** changed.
+
+See the logs.
+`, sb.String())
+}
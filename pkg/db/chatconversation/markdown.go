@@ -0,0 +1,69 @@
+package chatconversation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// message is the part of a stored chat message that is exported.
+type message struct {
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	Timestamp string `json:"timestamp"`
+}
+
+// messageHeadings are the headings of the message types of the chat UI.
+var messageHeadings = map[string]string{
+	"user":           "User",
+	"assistant":      "Assistant",
+	"final_response": "Assistant",
+	"thinking_step":  "Assistant (thinking)",
+	"error":          "Error",
+}
+
+// WriteMarkdown exports a conversation as a markdown document, with a section for each message.
+func WriteMarkdown(w io.Writer, conversation models.ChatConversation) error {
+	var messages []message
+	if err := json.Unmarshal(conversation.Messages.Bytes, &messages); err != nil {
+		return fmt.Errorf("parsing messages of chat conversation %s: %w", conversation.ID, err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Chat conversation %s\n\n", conversation.ID)
+	fmt.Fprintf(&sb, "- Shared by: %s\n", conversation.User)
+	fmt.Fprintf(&sb, "- Shared at: %s\n", conversation.CreatedAt.UTC().Format(time.RFC3339))
+	if conversation.ParentID != nil {
+		fmt.Fprintf(&sb, "- Forked from: %s\n", conversation.ParentID)
+	}
+	if persona, _ := metadataMap(conversation.Metadata)["persona"].(string); persona != "" {
+		fmt.Fprintf(&sb, "- Persona: %s\n", persona)
+	}
+	if tags := Tags(conversation.Metadata); len(tags) > 0 {
+		fmt.Fprintf(&sb, "- Tags: %s\n", strings.Join(tags, ", "))
+	}
+
+	for _, m := range messages {
+		content := strings.TrimSpace(m.Content)
+		if content == "" {
+			continue
+		}
+		heading, ok := messageHeadings[m.Type]
+		if !ok {
+			heading = m.Type
+		}
+		fmt.Fprintf(&sb, "\n## %s\n\n", heading)
+		if m.Timestamp != "" {
+			fmt.Fprintf(&sb, "_%s_\n\n", m.Timestamp)
+		}
+		sb.WriteString(content)
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
+	"github.com/openshift/sippy/pkg/db/chatconversation"
 	sippymigrate "github.com/openshift/sippy/pkg/db/migrate"
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
@@ -159,6 +160,7 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		&models.Test{},
 		&models.Suite{},
 		&models.APISnapshot{},
+		&models.ComponentReportSnapshot{},
 		&models.Bug{},
 		&models.ProwPullRequest{},
 		&models.ProwJobRunProwPullRequest{},
@@ -168,16 +170,19 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		&models.JiraComponent{},
 		&models.TestOwnership{},
 		&models.FeatureGate{},
+		&models.FeatureGatePromotionReport{},
 		&models.TestRegression{},
 		&models.RegressionJobRun{},
 		&models.RegressionView{},
 		&models.Triage{},
 		&models.TriageSymptom{},
 		&models.AuditLog{},
+		&models.RegressionAllowance{},
 		&models.ChatRating{},
 		&models.ChatConversation{},
 		&jobrunscan.Label{},
 		&jobrunscan.Symptom{},
+		&jobrunscan.AnnotationCampaign{},
 	}
 
 	// Currently we need RunMigrations to run prior
@@ -195,6 +200,10 @@ func (d *DB) UpdateSchema(reportEnd *time.Time) error {
 		return err
 	}
 
+	if err := chatconversation.CreateIndexes(d.DB); err != nil {
+		return err
+	}
+
 	if err := ensureTriageSymptomCascade(d.DB); err != nil {
 		return err
 	}
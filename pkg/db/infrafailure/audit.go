@@ -0,0 +1,137 @@
+package infrafailure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// AuditTable is the TableName of audit log entries recording manual InfraFailure changes.
+const AuditTable = "prow_job_runs"
+
+// ErrRunNotFound is returned by SetInfraFailure for a prow job run that does not exist.
+var ErrRunNotFound = errors.New("prow job run not found")
+
+// Change is the outcome of SetInfraFailure.
+type Change struct {
+	ProwJobRunID int64 `json:"prow_job_run_id"`
+	InfraFailure bool  `json:"infra_failure"`
+	// Changed is false if the run was already labeled as requested.
+	Changed bool     `json:"changed"`
+	Labels  []string `json:"labels"`
+}
+
+// auditData is the state of a run recorded in the audit log.
+type auditData struct {
+	Labels []string `json:"labels"`
+	Reason string   `json:"reason,omitempty"`
+}
+
+// SetInfraFailure records (infraFailure true) or unrecords a prow job run as an infrastructure
+// failure on behalf of user, writing an audit log entry in the same transaction when the label
+// changes.
+func SetInfraFailure(ctx context.Context, dbc *gorm.DB, prowJobRunID int64, infraFailure bool, user, reason string) (Change, error) {
+	change := Change{ProwJobRunID: prowJobRunID, InfraFailure: infraFailure}
+	err := dbc.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the row before reading the labels, so that they cannot change between this read
+		// and the conditional UPDATE below, which would otherwise take the lock.
+		var before []struct{ Labels pq.StringArray }
+		if err := tx.Raw("SELECT labels FROM prow_job_runs WHERE id = ? FOR UPDATE", prowJobRunID).Scan(&before).Error; err != nil {
+			return fmt.Errorf("locking prow_job_run %d: %w", prowJobRunID, err)
+		}
+		if len(before) == 0 {
+			return ErrRunNotFound
+		}
+
+		var err error
+		if infraFailure {
+			err = recordInfraFailureInTx(tx, prowJobRunID)
+		} else {
+			err = unrecordInfraFailureInTx(tx, prowJobRunID)
+		}
+		if err != nil {
+			return err
+		}
+
+		var after struct{ Labels pq.StringArray }
+		if err := tx.Raw("SELECT labels FROM prow_job_runs WHERE id = ?", prowJobRunID).Scan(&after).Error; err != nil {
+			return fmt.Errorf("reading labels of prow_job_run %d: %w", prowJobRunID, err)
+		}
+		change.Labels = append([]string{}, after.Labels...)
+		change.Changed = slices.Contains(before[0].Labels, LabelInfraFailure) != infraFailure
+		if !change.Changed {
+			return nil
+		}
+
+		oldData, err := json.Marshal(auditData{Labels: before[0].Labels})
+		if err != nil {
+			return err
+		}
+		newData, err := json.Marshal(auditData{Labels: after.Labels, Reason: reason})
+		if err != nil {
+			return err
+		}
+		audit := models.AuditLog{
+			TableName: AuditTable,
+			Operation: string(models.Update),
+			RowID:     uint(prowJobRunID), //nolint:gosec // G115: prow_job_runs.id is a PostgreSQL serial, always positive
+			OldData:   oldData,
+			NewData:   newData,
+			User:      user,
+		}
+		if err := tx.Create(&audit).Error; err != nil {
+			return fmt.Errorf("recording InfraFailure change in audit log: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return change, err
+	}
+
+	log.WithFields(log.Fields{
+		"prowJobRunID": prowJobRunID,
+		"infraFailure": infraFailure,
+		"changed":      change.Changed,
+		"user":         user,
+	}).Info("set InfraFailure on prow job run")
+	return change, nil
+}
+
+// Status is whether a prow job run is recorded as an infrastructure failure, with the audit log
+// of manual changes to it.
+type Status struct {
+	ProwJobRunID int64             `json:"prow_job_run_id"`
+	InfraFailure bool              `json:"infra_failure"`
+	Labels       []string          `json:"labels"`
+	AuditLogs    []models.AuditLog `json:"audit_logs"`
+}
+
+// GetStatus returns the InfraFailure status of a prow job run and its manual changes, newest first.
+func GetStatus(ctx context.Context, dbc *gorm.DB, prowJobRunID int64) (Status, error) {
+	status := Status{ProwJobRunID: prowJobRunID, Labels: []string{}, AuditLogs: []models.AuditLog{}}
+	var runs []struct{ Labels pq.StringArray }
+	if err := dbc.WithContext(ctx).Raw("SELECT labels FROM prow_job_runs WHERE id = ?", prowJobRunID).Scan(&runs).Error; err != nil {
+		return status, fmt.Errorf("reading labels of prow_job_run %d: %w", prowJobRunID, err)
+	}
+	if len(runs) == 0 {
+		return status, ErrRunNotFound
+	}
+	status.Labels = append(status.Labels, runs[0].Labels...)
+	status.InfraFailure = slices.Contains(status.Labels, LabelInfraFailure)
+
+	if err := dbc.WithContext(ctx).
+		Where("table_name = ? AND row_id = ?", AuditTable, prowJobRunID).
+		Order("created_at DESC, id DESC").
+		Find(&status.AuditLogs).Error; err != nil {
+		return status, fmt.Errorf("reading audit log of prow_job_run %d: %w", prowJobRunID, err)
+	}
+	return status, nil
+}
@@ -4,11 +4,13 @@
 // A job run labeled InfraFailure should not count toward test pass rates. To
 // keep the summary tables (test_daily_totals and test_cumulative_summaries)
 // consistent with that rule, RecordInfraFailure subtracts a run's test results
-// from those tables at the moment the label is applied.
+// from those tables at the moment the label is applied, and
+// UnrecordInfraFailure adds them back when the label is removed.
 //
 // The central invariant is: "InfraFailure label in PostgreSQL is equivalent to
-// subtraction done." The conditional UPDATE gate enforces it -- the label is
-// only ever set as part of the same transaction that performs the subtraction.
+// subtraction done." The conditional UPDATE gates enforce it -- the label is
+// only ever set as part of the same transaction that performs the subtraction,
+// and only ever removed as part of the same transaction that adds the run back.
 package infrafailure
 
 import (
@@ -47,8 +49,9 @@ WHERE id = ?
 
 // createDeltasTempTableSQL materializes one job run's test results into a temp
 // table of per-summary-key deltas. The table is dropped automatically when the
-// transaction ends (ON COMMIT DROP); the two UPDATE statements below read from
-// it so the scan and aggregation run once rather than once per statement.
+// transaction ends (ON COMMIT DROP); the statements below read from it so the
+// scan and aggregation run once rather than once per statement. The failure and
+// success timestamps are only used when adding a run back.
 //
 // The grouping matches the write path (pgwriter.createBatchDeltas and
 // dailysummary.insertSQL) exactly so the subtraction targets the same rows the
@@ -72,7 +75,11 @@ SELECT
 	COUNT(*) FILTER (WHERE status = 1) AS successes,
 	COUNT(*) FILTER (WHERE status = 12) AS failures,
 	COUNT(*) FILTER (WHERE status = 13) AS flakes,
-	COUNT(*) AS runs
+	COUNT(*) AS runs,
+	MIN(prow_job_run_timestamp) FILTER (WHERE status = 12) AS min_failure_ts,
+	MAX(prow_job_run_timestamp) FILTER (WHERE status = 12) AS max_failure_ts,
+	MIN(prow_job_run_timestamp) FILTER (WHERE status = 1) AS min_success_ts,
+	MAX(prow_job_run_timestamp) FILTER (WHERE status = 1) AS max_success_ts
 FROM prow_job_run_tests
 WHERE prow_job_run_id = ? AND deleted_at IS NULL
 	AND prow_job_run_release = ?
@@ -116,6 +123,114 @@ WHERE cs.release = d.release
 	AND cs.prow_job_id = d.prow_job_id
 	AND cs.date >= d.date`
 
+// unsetInfraFailureLabelSQL removes the InfraFailure label from a single prow
+// job run and acquires the row lock, mirroring setInfraFailureLabelSQL. When the
+// label is absent the UPDATE matches no rows (RowsAffected == 0), which the
+// caller treats as an idempotent no-op because the run is already counted.
+const unsetInfraFailureLabelSQL = `
+UPDATE prow_job_runs
+SET labels = array_remove(labels, 'InfraFailure')
+WHERE id = ?
+  AND labels @> ARRAY['InfraFailure']`
+
+// ensureDailyTotalRowsSQL inserts zeroed test_daily_totals rows for summary
+// keys the run contributes to but that have no row, so the addition below has
+// a row to update. A key has no row when the run was already labeled when it
+// was loaded, or when the daily summaries were rebuilt while it was labeled.
+const ensureDailyTotalRowsSQL = `
+INSERT INTO test_daily_totals (test_id, prow_job_id, suite_id, lifecycle, release, date,
+	successes, failures, flakes, runs)
+SELECT d.test_id, d.prow_job_id, d.suite_id, d.lifecycle, d.release, d.date, 0, 0, 0, 0
+FROM infra_failure_deltas d
+WHERE NOT EXISTS (
+	SELECT 1 FROM test_daily_totals dt
+	WHERE dt.release = d.release
+		AND dt.date = d.date
+		AND dt.test_id = d.test_id
+		AND dt.suite_id = d.suite_id
+		AND dt.lifecycle = d.lifecycle
+		AND dt.prow_job_id = d.prow_job_id
+)`
+
+// addDailyTotalsSQL adds the run's per-day counts back to test_daily_totals,
+// widening the first and last failure and success timestamps to include it as
+// the loader does (LEAST and GREATEST ignore NULLs).
+const addDailyTotalsSQL = `
+UPDATE test_daily_totals dt SET
+	successes = dt.successes + d.successes,
+	failures = dt.failures + d.failures,
+	flakes = dt.flakes + d.flakes,
+	runs = dt.runs + d.runs,
+	first_failure_timestamp = LEAST(dt.first_failure_timestamp, d.min_failure_ts),
+	last_failure_timestamp = GREATEST(dt.last_failure_timestamp, d.max_failure_ts),
+	first_success_timestamp = LEAST(dt.first_success_timestamp, d.min_success_ts),
+	last_success_timestamp = GREATEST(dt.last_success_timestamp, d.max_success_ts)
+FROM infra_failure_deltas d
+WHERE dt.release = d.release
+	AND dt.date = d.date
+	AND dt.test_id = d.test_id
+	AND dt.suite_id = d.suite_id
+	AND dt.lifecycle = d.lifecycle
+	AND dt.prow_job_id = d.prow_job_id`
+
+// ensureCumulativeSummaryRowsSQL inserts the test_cumulative_summaries rows
+// missing for the run's summary keys, from the run's date through the latest
+// date summarized for the release. Each carries forward the key's latest
+// earlier prefix sums, or zero if the key has none, as the loader's
+// carry-forward would have.
+const ensureCumulativeSummaryRowsSQL = `
+INSERT INTO test_cumulative_summaries (date, test_id, prow_job_id, suite_id, lifecycle, release,
+	prefix_sum_successes, prefix_sum_failures, prefix_sum_flakes, prefix_sum_runs,
+	prefix_max_last_failure, prefix_max_last_success)
+SELECT day::date, d.test_id, d.prow_job_id, d.suite_id, d.lifecycle, d.release,
+	COALESCE(prev.prefix_sum_successes, 0), COALESCE(prev.prefix_sum_failures, 0),
+	COALESCE(prev.prefix_sum_flakes, 0), COALESCE(prev.prefix_sum_runs, 0),
+	prev.prefix_max_last_failure, prev.prefix_max_last_success
+FROM infra_failure_deltas d
+CROSS JOIN LATERAL generate_series(d.date,
+	(SELECT MAX(date) FROM test_cumulative_summaries WHERE release = d.release), interval '1 day') AS day
+LEFT JOIN LATERAL (
+	SELECT cs.prefix_sum_successes, cs.prefix_sum_failures, cs.prefix_sum_flakes, cs.prefix_sum_runs,
+		cs.prefix_max_last_failure, cs.prefix_max_last_success
+	FROM test_cumulative_summaries cs
+	WHERE cs.release = d.release
+		AND cs.test_id = d.test_id
+		AND cs.suite_id = d.suite_id
+		AND cs.lifecycle = d.lifecycle
+		AND cs.prow_job_id = d.prow_job_id
+		AND cs.date < day::date
+	ORDER BY cs.date DESC
+	LIMIT 1
+) prev ON true
+WHERE NOT EXISTS (
+	SELECT 1 FROM test_cumulative_summaries cs
+	WHERE cs.date = day::date
+		AND cs.release = d.release
+		AND cs.test_id = d.test_id
+		AND cs.suite_id = d.suite_id
+		AND cs.lifecycle = d.lifecycle
+		AND cs.prow_job_id = d.prow_job_id
+)`
+
+// addCumulativeSummariesSQL cascades the addition into the cumulative prefix
+// sums from the affected date onward, the inverse of
+// subtractCumulativeSummariesSQL.
+const addCumulativeSummariesSQL = `
+UPDATE test_cumulative_summaries cs SET
+	prefix_sum_successes = cs.prefix_sum_successes + d.successes,
+	prefix_sum_failures = cs.prefix_sum_failures + d.failures,
+	prefix_sum_flakes = cs.prefix_sum_flakes + d.flakes,
+	prefix_sum_runs = cs.prefix_sum_runs + d.runs,
+	prefix_max_last_failure = GREATEST(cs.prefix_max_last_failure, d.max_failure_ts),
+	prefix_max_last_success = GREATEST(cs.prefix_max_last_success, d.max_success_ts)
+FROM infra_failure_deltas d
+WHERE cs.release = d.release
+	AND cs.test_id = d.test_id
+	AND cs.suite_id = d.suite_id
+	AND cs.lifecycle = d.lifecycle
+	AND cs.prow_job_id = d.prow_job_id
+	AND cs.date >= d.date`
+
 // RecordInfraFailure marks a prow job run as an infrastructure failure and
 // removes its contribution from the pre-aggregated summary tables. It opens its
 // own transaction on dbc (scoped to ctx) so the label set and the summary
@@ -164,6 +279,31 @@ func recordInfraFailureInTx(tx *gorm.DB, prowJobRunID int64) error {
 func subtractFromSummaries(tx *gorm.DB, prowJobRunID int64) error {
 	logger := log.WithField("prowJobRunID", prowJobRunID)
 
+	if err := materializeDeltas(tx, prowJobRunID); err != nil {
+		return err
+	}
+
+	// Subtract the run's counts from the per-day totals.
+	dailyRes := tx.Exec(subtractDailyTotalsSQL)
+	if dailyRes.Error != nil {
+		return fmt.Errorf("subtracting daily totals for prow_job_run %d: %w", prowJobRunID, dailyRes.Error)
+	}
+	logger.WithField("rowsAffected", dailyRes.RowsAffected).Debug("subtracted infra-failure run from test_daily_totals")
+
+	// Cascade the subtraction into the cumulative prefix sums from the affected
+	// date onward.
+	cumulativeRes := tx.Exec(subtractCumulativeSummariesSQL)
+	if cumulativeRes.Error != nil {
+		return fmt.Errorf("subtracting cumulative summaries for prow_job_run %d: %w", prowJobRunID, cumulativeRes.Error)
+	}
+	logger.WithField("rowsAffected", cumulativeRes.RowsAffected).Debug("subtracted infra-failure run from test_cumulative_summaries")
+
+	return nil
+}
+
+// materializeDeltas creates the infra_failure_deltas temp table holding a
+// single prow job run's test results per summary key.
+func materializeDeltas(tx *gorm.DB, prowJobRunID int64) error {
 	// Read the run's partition keys (release and timestamp) so the delta scan
 	// below can prune to the run's single partition instead of scanning every
 	// partition for the run id.
@@ -174,7 +314,7 @@ func subtractFromSummaries(tx *gorm.DB, prowJobRunID int64) error {
 
 	// Drop any stale temp table before recreating it. ON COMMIT DROP ties the
 	// table's lifetime to the outermost transaction, not to a savepoint, so when
-	// the subtraction runs twice inside the same outer transaction (tx is
+	// the deltas are materialized twice inside the same outer transaction (tx is
 	// already a transaction, so gorm nests each call as a savepoint) the second
 	// CREATE would collide with the table left by the first. Dropping first makes
 	// the CREATE idempotent within the outer transaction.
@@ -182,27 +322,81 @@ func subtractFromSummaries(tx *gorm.DB, prowJobRunID int64) error {
 		return fmt.Errorf("dropping stale infra_failure_deltas temp table for prow_job_run %d: %w", prowJobRunID, err)
 	}
 
-	// Materialize the per-summary-key deltas once so both UPDATEs below read
-	// them from a single scan and aggregation rather than re-evaluating the
-	// join twice.
+	// Materialize the per-summary-key deltas once so the statements that apply
+	// them read from a single scan and aggregation rather than re-evaluating the
+	// join each time.
 	if err := tx.Exec(createDeltasTempTableSQL, prowJobRunID, partKeys.ProwJobRelease, partKeys.Timestamp).Error; err != nil {
 		return fmt.Errorf("materializing deltas for prow_job_run %d: %w", prowJobRunID, err)
 	}
+	return nil
+}
 
-	// Subtract the run's counts from the per-day totals.
-	dailyRes := tx.Exec(subtractDailyTotalsSQL)
+// UnrecordInfraFailure is the inverse of RecordInfraFailure: it removes the
+// InfraFailure label from a prow job run and adds its test results back to the
+// pre-aggregated summary tables, in one transaction opened on dbc (scoped to
+// ctx). It is for runs that were labeled by mistake, such as by an over-broad
+// symptom; if the symptom still matches, re-evaluating the run labels it again.
+//
+// The operation is idempotent: if the run does not carry the InfraFailure label
+// the function returns nil without touching the summary tables, because the
+// invariant guarantees the run is already counted.
+func UnrecordInfraFailure(ctx context.Context, dbc *gorm.DB, prowJobRunID int64) error {
+	return dbc.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return unrecordInfraFailureInTx(tx, prowJobRunID)
+	})
+}
+
+// unrecordInfraFailureInTx performs the label removal and summary addition on
+// the supplied transaction. Returning an error rolls back the transaction so
+// the label stays in place and the run stays subtracted, preserving the
+// invariant.
+func unrecordInfraFailureInTx(tx *gorm.DB, prowJobRunID int64) error {
+	logger := log.WithField("prowJobRunID", prowJobRunID)
+
+	// Conditional UPDATE as the first operation, as in
+	// recordInfraFailureInTx: remove the label and acquire the row lock
+	// together. RowsAffected == 0 means the label was not set, so the run is
+	// already counted -- nothing further to do.
+	res := tx.Exec(unsetInfraFailureLabelSQL, prowJobRunID)
+	if res.Error != nil {
+		return fmt.Errorf("removing InfraFailure label from prow_job_run %d: %w", prowJobRunID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		logger.Debug("prow job run not labeled InfraFailure; already counted in summaries, skipping")
+		return nil
+	}
+	logger.Debug("removed InfraFailure label from prow job run")
+
+	return addToSummaries(tx, prowJobRunID)
+}
+
+// addToSummaries adds a single prow job run's test results back to the
+// pre-aggregated summary tables, creating any rows they need. Like
+// subtractFromSummaries it does not touch the InfraFailure label.
+func addToSummaries(tx *gorm.DB, prowJobRunID int64) error {
+	logger := log.WithField("prowJobRunID", prowJobRunID)
+
+	if err := materializeDeltas(tx, prowJobRunID); err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ensureDailyTotalRowsSQL).Error; err != nil {
+		return fmt.Errorf("ensuring daily total rows for prow_job_run %d: %w", prowJobRunID, err)
+	}
+	dailyRes := tx.Exec(addDailyTotalsSQL)
 	if dailyRes.Error != nil {
-		return fmt.Errorf("subtracting daily totals for prow_job_run %d: %w", prowJobRunID, dailyRes.Error)
+		return fmt.Errorf("adding daily totals for prow_job_run %d: %w", prowJobRunID, dailyRes.Error)
 	}
-	logger.WithField("rowsAffected", dailyRes.RowsAffected).Debug("subtracted infra-failure run from test_daily_totals")
+	logger.WithField("rowsAffected", dailyRes.RowsAffected).Debug("added run back to test_daily_totals")
 
-	// Cascade the subtraction into the cumulative prefix sums from the affected
-	// date onward.
-	cumulativeRes := tx.Exec(subtractCumulativeSummariesSQL)
+	if err := tx.Exec(ensureCumulativeSummaryRowsSQL).Error; err != nil {
+		return fmt.Errorf("ensuring cumulative summary rows for prow_job_run %d: %w", prowJobRunID, err)
+	}
+	cumulativeRes := tx.Exec(addCumulativeSummariesSQL)
 	if cumulativeRes.Error != nil {
-		return fmt.Errorf("subtracting cumulative summaries for prow_job_run %d: %w", prowJobRunID, cumulativeRes.Error)
+		return fmt.Errorf("adding cumulative summaries for prow_job_run %d: %w", prowJobRunID, cumulativeRes.Error)
 	}
-	logger.WithField("rowsAffected", cumulativeRes.RowsAffected).Debug("subtracted infra-failure run from test_cumulative_summaries")
+	logger.WithField("rowsAffected", cumulativeRes.RowsAffected).Debug("added run back to test_cumulative_summaries")
 
 	return nil
 }
@@ -21,4 +21,9 @@ const (
 	Create OperationType = "CREATE"
 	Update OperationType = "UPDATE"
 	Delete OperationType = "DELETE"
+	Denied OperationType = "DENIED"
 )
+
+// AuthorizationAuditTable is the TableName of audit entries that are not about a table row, but
+// record requests denied by authorization.
+const AuthorizationAuditTable = "authorization"
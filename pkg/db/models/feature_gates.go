@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
 // FeatureGate maps feature gates to feature sets for a specific release.
 //
 //	feature gate = NetworkSegmentation
@@ -15,3 +21,17 @@ type FeatureGate struct {
 	FeatureGate string `gorm:"column:feature_gate;not null;primaryKey;index" json:"feature_gate"`
 	Status      string `gorm:"column:status;not null" json:"status"`
 }
+
+// FeatureGatePromotionReport stores a day's promotion readiness report of the unpromoted feature
+// gates of a release, so that reports can be compared over time.
+type FeatureGatePromotionReport struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Release string    `json:"release" gorm:"not null;uniqueIndex:idx_feature_gate_promotion_report_release_date"`
+	Date    time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_feature_gate_promotion_report_release_date"`
+
+	// Report is the featuregatepromotion.ReleaseReport json of the gates' promotion readiness.
+	Report pgtype.JSONB `json:"report" gorm:"type:jsonb"`
+}
@@ -0,0 +1,65 @@
+package jobrunscan
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// AnnotationCampaign is a stored annotate-job-runs search. While it is active, the sippy daemon
+// periodically labels the job runs started since the campaign last ran.
+type AnnotationCampaign struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	// Human-readable name, e.g. "vsphere02 registry outage"
+	Name string `gorm:"type:varchar(200);not null" json:"name"`
+
+	AnnotationCampaignSearch
+
+	// Paused campaigns are not run until resumed. Job runs started while paused are scanned on resume.
+	Paused bool `gorm:"not null;default:false" json:"paused"`
+
+	AnnotationCampaignProgress
+	Metadata
+}
+
+// AnnotationCampaignSearch selects the job runs to label, as the flags of annotate-job-runs do.
+type AnnotationCampaignSearch struct {
+	Release string `json:"release"`
+	// Variants are key:value pairs a job must all have, e.g. Platform:metal
+	Variants       pq.StringArray `gorm:"type:text[]" json:"variants"`
+	BuildClusters  pq.StringArray `gorm:"type:text[]" json:"build_clusters"`
+	MinFailures    int            `json:"min_failures"`
+	FlakeAsFailure bool           `json:"flake_as_failure"`
+	// Artifacts matching PathGlob are searched for TextContains or TextRegex
+	TextContains string `json:"text_contains"`
+	TextRegex    string `json:"text_regex"`
+	PathGlob     string `json:"path_glob"`
+
+	// The label applied to matching job runs, and a comment recorded with it
+	Label   string `gorm:"type:varchar(80);not null" json:"label"`
+	Comment string `json:"comment"`
+
+	// StartTime is when the earliest job runs to label started. Without an EndTime the
+	// campaign runs until it is paused.
+	StartTime time.Time  `gorm:"not null" json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+// AnnotationCampaignProgress is maintained by campaign runs, and is ignored when creating a campaign.
+type AnnotationCampaignProgress struct {
+	// ScannedThrough is the start time up to which job runs have been scanned.
+	ScannedThrough time.Time `gorm:"not null" json:"scanned_through"`
+	// RunsScanned are the job runs that passed the release, variant, cluster and failure filters,
+	// RunsMatched those that also matched the artifact search, and RunsLabeled those that were
+	// newly labeled, rather than already having the label.
+	RunsScanned int64      `gorm:"not null;default:0" json:"runs_scanned"`
+	RunsMatched int64      `gorm:"not null;default:0" json:"runs_matched"`
+	RunsLabeled int64      `gorm:"not null;default:0" json:"runs_labeled"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	// LastError is why the last run failed, empty if it succeeded.
+	LastError string `gorm:"type:text" json:"last_error,omitempty"`
+}
+
+func (AnnotationCampaign) TableName() string {
+	return "job_run_annotation_campaigns"
+}
@@ -35,6 +35,8 @@ type SymptomContent struct {
 	// Match string - interpretation depends on MatcherType:
 	// - "string": substring to find in file
 	// - "regex": regular expression pattern
+	// - "jq": jq expression run against a JSON file; matches when it yields anything but null/false
+	// - "xpath": XPath expression run against an XML file; matches when it selects nodes or is truthy
 	// - "none": ignored (just checks file existence)
 	// - "cel": CEL expression referencing applied labels (e.g. "DNSTimeout && !OperatorError")
 	MatchString string `gorm:"type:text" json:"match_string,omitempty"`
@@ -63,6 +65,8 @@ func (Symptom) TableName() string {
 const (
 	MatcherTypeString = "string" // Simple substring match
 	MatcherTypeRegex  = "regex"  // Regular expression match
+	MatcherTypeJQ     = "jq"     // jq expression against JSON content
+	MatcherTypeXPath  = "xpath"  // XPath expression against XML content
 	MatcherTypeFile   = "none"   // File exists (no content match)
 	MatcherTypeCEL    = "cel"    // Common Expression Language for compound logic
 )
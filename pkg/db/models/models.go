@@ -58,6 +58,21 @@ type APISnapshot struct {
 	UpgradeHealth pgtype.JSONB `json:"upgrade_health" gorm:"type:jsonb"`
 }
 
+// ComponentReportSnapshot is a compact copy of a component readiness view's report, stored once per day by the
+// regression cache loader so that past reports can be viewed and compared. Later loads on the same day replace it.
+type ComponentReportSnapshot struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	View    string    `json:"view" gorm:"not null;uniqueIndex:idx_component_report_snapshot_view_date"`
+	Date    time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_component_report_snapshot_view_date"`
+	Release string    `json:"release" gorm:"not null"`
+
+	// Report is the crsnapshot.Snapshot json of the report's cells and regressed tests.
+	Report pgtype.JSONB `json:"report" gorm:"type:jsonb"`
+}
+
 // JiraIncident is an implementation of incident tracking.
 type JiraIncident struct {
 	Model
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// RegressionAllowance records a product-owner-approved regression of a test in a release. Component readiness
+// tolerates the regressed pass rate in that release, and when that release is later used as a basis, compares
+// against the previous (better) pass rate recorded here instead.
+type RegressionAllowance struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Release is the release in which the regression is allowed.
+	Release       string `json:"release" gorm:"not null;uniqueIndex:idx_regression_allowance_key"`
+	JiraComponent string `json:"jira_component" gorm:"not null"`
+	TestID        string `json:"test_id" gorm:"not null;uniqueIndex:idx_regression_allowance_key"`
+	TestName      string `json:"test_name" gorm:"not null"`
+	// Variants identifies the column the allowance applies to, as sorted key:value pairs.
+	Variants pq.StringArray `json:"variants" gorm:"not null;type:text[];uniqueIndex:idx_regression_allowance_key"`
+
+	// PreviousRelease and the Previous counts record the pass rate the test had before the regression.
+	PreviousRelease   string `json:"previous_release" gorm:"not null"`
+	PreviousSuccesses int    `json:"previous_successes"`
+	PreviousFailures  int    `json:"previous_failures"`
+	PreviousFlakes    int    `json:"previous_flakes"`
+	// The Regressed counts record the pass rate being allowed in Release.
+	RegressedSuccesses int `json:"regressed_successes"`
+	RegressedFailures  int `json:"regressed_failures"`
+	RegressedFlakes    int `json:"regressed_flakes"`
+
+	JiraBug                   string `json:"jira_bug" gorm:"not null"`
+	ReasonToAllowInsteadOfFix string `json:"reason_to_allow_instead_of_fix" gorm:"not null"`
+
+	// Links contains REST links for clients to follow for this specific allowance.
+	// These are injected by the API and not stored in the DB.
+	Links map[string]string `json:"links,omitempty" gorm:"-"`
+}
+
+const OldRegressionAllowanceKey contextKey = "old_regression_allowance"
+
+func (a *RegressionAllowance) BeforeUpdate(db *gorm.DB) error {
+	return a.before(db)
+}
+
+func (a *RegressionAllowance) BeforeDelete(db *gorm.DB) error {
+	return a.before(db)
+}
+
+func (a *RegressionAllowance) before(db *gorm.DB) error {
+	// Check if we've already captured the old allowance in this transaction
+	if existing := db.Statement.Context.Value(OldRegressionAllowanceKey); existing != nil {
+		return nil
+	}
+
+	var old RegressionAllowance
+	if err := db.First(&old, a.ID).Error; err != nil {
+		return err
+	}
+
+	db.Statement.Context = context.WithValue(db.Statement.Context, OldRegressionAllowanceKey, old)
+	return nil
+}
+
+func (a *RegressionAllowance) AfterUpdate(db *gorm.DB) error {
+	return a.after(db, Update)
+}
+
+func (a *RegressionAllowance) AfterCreate(db *gorm.DB) error {
+	return a.after(db, Create)
+}
+
+func (a *RegressionAllowance) AfterDelete(db *gorm.DB) error {
+	return a.after(db, Delete)
+}
+
+func (a *RegressionAllowance) after(db *gorm.DB, operation OperationType) error {
+	var oldJSON []byte
+	if operation == Update || operation == Delete {
+		var err error
+		old, ok := db.Statement.Context.Value(OldRegressionAllowanceKey).(RegressionAllowance)
+		if !ok {
+			return fmt.Errorf("value of old_regression_allowance is not a RegressionAllowance type")
+		}
+		old.Links = nil
+		oldJSON, err = json.Marshal(old)
+		if err != nil {
+			return fmt.Errorf("error marshalling old regression allowance record: %w", err)
+		}
+	}
+
+	var newJSON []byte
+	if operation != Delete {
+		var err error
+		current := *a
+		current.Links = nil
+		newJSON, err = json.Marshal(current)
+		if err != nil {
+			return fmt.Errorf("error marshalling new regression allowance record: %w", err)
+		}
+	}
+	user := db.Statement.Context.Value(CurrentUserKey)
+	if user == nil {
+		return fmt.Errorf("current user not found in context")
+	}
+	audit := AuditLog{
+		TableName: "regression_allowances",
+		Operation: string(operation),
+		RowID:     a.ID,
+		User:      user.(string),
+		OldData:   oldJSON,
+		NewData:   newJSON,
+	}
+
+	return db.Create(&audit).Error
+}
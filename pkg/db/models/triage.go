@@ -228,6 +228,9 @@ type TestRegression struct {
 	// This is intended to help inform the min fail thresholds we should be using, and what kind of regressions
 	// disappear on their own.
 	MaxFailures int `json:"max_failures"`
+	// FailedFixDetected is when the regression was first seen failing after its triage was resolved as fixed.
+	// It is set while any active view sees a failed fix, and cleared once none does.
+	FailedFixDetected sql.NullTime `json:"failed_fix_detected"`
 
 	// JobRuns accumulates the unique set of all job runs ever observed while this regression was open.
 	// As the 7-day sample window slides, old runs roll off and new ones appear, but this list retains all of them.
@@ -248,6 +251,9 @@ type RegressionView struct {
 	Active           bool         `json:"active" gorm:"not null;default:true"`
 	OpenedAt         time.Time    `json:"opened_at" gorm:"not null;default:now()"`
 	ClosedAt         sql.NullTime `json:"closed_at"`
+	// FailedFixDetected is when this view's report first showed the regression failing after its
+	// triage was resolved as fixed, or null if it does not.
+	FailedFixDetected sql.NullTime `json:"failed_fix_detected"`
 }
 
 // RegressionJobRun represents a single job run observed during the lifetime of a regression.
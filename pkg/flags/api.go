@@ -2,6 +2,9 @@ package flags
 
 import (
 	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/sippyserver/authz"
+	"github.com/openshift/sippy/pkg/sippyserver/ratelimit"
 )
 
 // APIFlags holds configuration information for Sippy API servers.
@@ -10,6 +13,8 @@ type APIFlags struct {
 	ListenAddr           string
 	MetricsAddr          string
 	ChatAPIURL           string
+	AuthorizationConfig  string
+	RateLimits           []string
 }
 
 func NewAPIFlags() *APIFlags {
@@ -24,4 +29,19 @@ func (f *APIFlags) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&f.ListenAddr, "listen", f.ListenAddr, "The address to serve analysis reports on (default :8080)")
 	fs.StringVar(&f.MetricsAddr, "listen-metrics", f.MetricsAddr, "The address to serve prometheus metrics on (default :2112)")
 	fs.StringVar(&f.ChatAPIURL, "chat-api", f.ChatAPIURL, "URL of the sippy-chat service to proxy chat requests to")
+	fs.StringVar(&f.AuthorizationConfig, "authorization-config", f.AuthorizationConfig, "Optional yaml file restricting write endpoints to the groups in the X-Forwarded-Groups header")
+	fs.StringArrayVar(&f.RateLimits, "rate-limit", f.RateLimits, "Override the rate limit of an endpoint, as <endpoint path>=<requests>/<period>, e.g. /api/tests/v2/runs=50/1h (one per arg instance)")
+}
+
+// GetAuthorizationConfig returns the write endpoint authorization config, or nil if none is configured.
+func (f *APIFlags) GetAuthorizationConfig() (*authz.Config, error) {
+	if f.AuthorizationConfig == "" {
+		return nil, nil
+	}
+	return authz.LoadConfig(f.AuthorizationConfig)
+}
+
+// GetRateLimits returns the configured endpoint rate limit overrides.
+func (f *APIFlags) GetRateLimits() (map[string]ratelimit.Quota, error) {
+	return ratelimit.ParseQuotas(f.RateLimits)
 }
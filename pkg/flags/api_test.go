@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/sippyserver/ratelimit"
+)
+
+func TestGetRateLimits(t *testing.T) {
+	f := NewAPIFlags()
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{
+		"--rate-limit", "/api/tests/v2/runs=50/1h",
+		"--rate-limit", "/api/jobs/runs/risk_analysis=0/0s",
+	}))
+
+	quotas, err := f.GetRateLimits()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ratelimit.Quota{
+		"/api/tests/v2/runs":           {Requests: 50, Period: time.Hour},
+		"/api/jobs/runs/risk_analysis": {},
+	}, quotas)
+
+	f = NewAPIFlags()
+	fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f.BindFlags(fs)
+	require.NoError(t, fs.Parse([]string{"--rate-limit", "/api/tests/v2/runs=fifty/1h"}))
+	_, err = f.GetRateLimits()
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+)
+
+const (
+	ArtifactSourceGCS        = "gcs"
+	ArtifactSourceFileSystem = "fs"
+	ArtifactSourceS3         = "s3"
+)
+
+// ArtifactSourceFlags select where prow job artifacts such as junit are read from.
+type ArtifactSourceFlags struct {
+	Source      string
+	Dir         string
+	S3Endpoint  string
+	S3Region    string
+	googleFlags *GoogleCloudFlags
+}
+
+func NewArtifactSourceFlags(googleFlags *GoogleCloudFlags) *ArtifactSourceFlags {
+	return &ArtifactSourceFlags{
+		Source:      ArtifactSourceGCS,
+		googleFlags: googleFlags,
+	}
+}
+
+func (f *ArtifactSourceFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&f.Source,
+		"artifact-source",
+		f.Source,
+		"Where to read prow job artifacts from: gcs, fs (a local directory with one sub-directory per bucket, see --artifact-dir), or s3 (an S3-compatible store, see --s3-endpoint)")
+	fs.StringVar(&f.Dir,
+		"artifact-dir",
+		f.Dir,
+		"Root directory of the artifacts when --artifact-source=fs")
+	fs.StringVar(&f.S3Endpoint,
+		"s3-endpoint",
+		f.S3Endpoint,
+		"Base URL of the S3-compatible store when --artifact-source=s3, e.g. http://minio:9000. Credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	fs.StringVar(&f.S3Region,
+		"s3-region",
+		"us-east-1",
+		"Region used to sign S3 requests")
+}
+
+// UsesGoogleCloud reports whether artifacts are read from Google Cloud Storage.
+func (f *ArtifactSourceFlags) UsesGoogleCloud() bool {
+	return f.Source == ArtifactSourceGCS
+}
+
+func (f *ArtifactSourceFlags) GetArtifactSource(ctx context.Context) (gcs.ArtifactSource, error) {
+	switch f.Source {
+	case ArtifactSourceGCS:
+		gcsClient, err := gcs.NewGCSClient(ctx,
+			f.googleFlags.ServiceAccountCredentialFile,
+			f.googleFlags.OAuthClientCredentialFile,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &gcs.GCSSource{Client: gcsClient}, nil
+	case ArtifactSourceFileSystem:
+		if f.Dir == "" {
+			return nil, fmt.Errorf("--artifact-dir is required with --artifact-source=%s", ArtifactSourceFileSystem)
+		}
+		return &gcs.FileSystemSource{Root: f.Dir}, nil
+	case ArtifactSourceS3:
+		return gcs.NewS3Source(f.S3Endpoint, f.S3Region)
+	default:
+		return nil, fmt.Errorf("unknown artifact source %q, must be one of %s, %s or %s",
+			f.Source, ArtifactSourceGCS, ArtifactSourceFileSystem, ArtifactSourceS3)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/openshift/sippy/pkg/apis/cache"
+	"github.com/openshift/sippy/pkg/cache/lru"
 	"github.com/openshift/sippy/pkg/cache/redis"
 )
 
@@ -23,10 +24,14 @@ type CacheFlags struct {
 	EnablePersistentCacheWrite bool
 	EnablePersistentCaching    bool
 	ForcePersistentLookup      bool
+	MemoryCacheSizeMB          int
+	MemoryCacheMaxAge          time.Duration
 }
 
 func NewCacheFlags() *CacheFlags {
-	return &CacheFlags{}
+	return &CacheFlags{
+		MemoryCacheMaxAge: 10 * time.Minute,
+	}
 }
 
 func (f *CacheFlags) BindFlags(fs *pflag.FlagSet) {
@@ -35,6 +40,16 @@ func (f *CacheFlags) BindFlags(fs *pflag.FlagSet) {
 		os.Getenv("REDIS_URL"),
 		"Redis URL for caching")
 
+	fs.IntVar(&f.MemoryCacheSizeMB,
+		"memory-cache-size",
+		f.MemoryCacheSizeMB,
+		"Size in MiB of an in-memory tier to keep in front of redis for frequently used items, disabled when 0")
+
+	fs.DurationVar(&f.MemoryCacheMaxAge,
+		"memory-cache-max-age",
+		f.MemoryCacheMaxAge,
+		"Maximum time to serve an item from the in-memory cache tier before checking redis for a newer one")
+
 	fs.BoolVar(&f.EnablePersistentCaching,
 		"enable-persistent-cache",
 		false,
@@ -67,7 +82,14 @@ func (f *CacheFlags) BindFlags(fs *pflag.FlagSet) {
 
 func (f *CacheFlags) GetCacheClient() (cache.Cache, error) {
 	if f.RedisURL != "" {
-		return redis.NewRedisCache(f.RedisURL)
+		redisCache, err := redis.NewRedisCache(f.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		if f.MemoryCacheSizeMB <= 0 {
+			return redisCache, nil
+		}
+		return lru.NewCache(redisCache, f.MemoryCacheSizeMB*1024*1024, f.MemoryCacheMaxAge), nil
 	}
 
 	return nil, nil
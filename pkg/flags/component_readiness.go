@@ -70,6 +70,9 @@ func (f *ComponentReadinessFlags) validateViews(views *api.SippyViews) error {
 				}
 			}
 		}
+		if err := view.AdvancedOptions.ValidateAnalyzer(); err != nil {
+			return fmt.Errorf("view %s: %w", view.Name, err)
+		}
 	}
 
 	return nil
@@ -1 +1,50 @@
 package flags
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/componentreadiness/jobrunannotator"
+)
+
+// AnnotationCampaignFlags configures running job run annotation campaigns in the daemon.
+type AnnotationCampaignFlags struct {
+	AnnotationCampaigns       bool
+	AnnotationCampaignsDryRun bool
+	Interval                  time.Duration
+	SettleTime                time.Duration
+}
+
+func NewAnnotationCampaignFlags() *AnnotationCampaignFlags {
+	return &AnnotationCampaignFlags{
+		AnnotationCampaignsDryRun: true,
+		Interval:                  time.Hour,
+		SettleTime:                jobrunannotator.DefaultCampaignSettleTime,
+	}
+}
+
+func (f *AnnotationCampaignFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&f.AnnotationCampaigns, "annotation-campaigns", f.AnnotationCampaigns, "Enable running job run annotation campaigns")
+	fs.BoolVar(&f.AnnotationCampaignsDryRun, "annotation-campaigns-dry-run", f.AnnotationCampaignsDryRun, "Only log the labels annotation campaigns would write, enabled by default")
+	fs.DurationVar(&f.Interval, "annotation-campaign-interval", f.Interval, "How often to run the active annotation campaigns")
+	fs.DurationVar(&f.SettleTime, "annotation-campaign-settle-time", f.SettleTime, "How long after a job run starts before annotation campaigns scan it, so that its results are loaded")
+}
+
+// ChatRetentionFlags configures expiring shared chat conversations in the daemon.
+type ChatRetentionFlags struct {
+	// Retention is how long shared conversations are kept before they are soft-deleted, zero keeps them forever.
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+func NewChatRetentionFlags() *ChatRetentionFlags {
+	return &ChatRetentionFlags{
+		Interval: 24 * time.Hour,
+	}
+}
+
+func (f *ChatRetentionFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&f.Retention, "chat-conversation-retention", f.Retention, "Soft-delete shared chat conversations created longer ago than this, e.g. 4320h for 180 days. Disabled by default")
+	fs.DurationVar(&f.Interval, "chat-conversation-retention-interval", f.Interval, "How often to soft-delete expired chat conversations")
+}
@@ -19,7 +19,7 @@ func NewGithubCommenterFlags() *GithubCommenterFlags {
 }
 
 func (f *GithubCommenterFlags) BindFlags(fs *pflag.FlagSet) {
-	fs.StringArrayVar(&f.IncludeReposCommenting, "include-repo-commenting", f.IncludeReposCommenting, "Which repos do we include for pr commenting (one repo per arg instance  org/repo or just repo if openshift org)")
+	fs.StringArrayVar(&f.IncludeReposCommenting, "include-repo-commenting", f.IncludeReposCommenting, "Which repos do we include for pr commenting (one repo per arg instance  org/repo or just repo if openshift org). Append :check-run to publish results as a GitHub check run on the PR head commit instead of a comment")
 	fs.StringArrayVar(&f.ExcludeReposCommenting, "exclude-repo-commenting", f.ExcludeReposCommenting, "Which repos do we skip for pr commenting (one repo per arg instance  org/repo or just repo if openshift org)")
 	fs.BoolVar(&f.CommentProcessing, "comment-processing", f.CommentProcessing, "Enable comment processing for github repos")
 	fs.BoolVar(&f.CommentProcessingDryRun, "comment-processing-dry-run", commentProcessingDryRunDefault, "Enable github comment interaction for comment processing, disabled by default")
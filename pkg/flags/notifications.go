@@ -0,0 +1,35 @@
+package flags
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/sippy/pkg/componentreadiness/notifications"
+)
+
+// NotificationFlags holds configuration for regression lifecycle notifications.
+type NotificationFlags struct {
+	NotificationsConfigFile string
+}
+
+func NewNotificationFlags() *NotificationFlags {
+	return &NotificationFlags{}
+}
+
+func (f *NotificationFlags) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&f.NotificationsConfigFile,
+		"notifications-config",
+		f.NotificationsConfigFile,
+		"Optional yaml file defining webhooks to notify when regressions open and close")
+}
+
+// GetNotifier returns a notifier for the configured webhooks, or nil if none are configured.
+func (f *NotificationFlags) GetNotifier() (notifications.Notifier, error) {
+	if f.NotificationsConfigFile == "" {
+		return nil, nil
+	}
+	config, err := notifications.LoadConfig(f.NotificationsConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return notifications.NewWebhookNotifier(config), nil
+}
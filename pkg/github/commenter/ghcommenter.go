@@ -20,10 +20,21 @@ type GitHubCommenter struct {
 	dbc          *db.DB
 	includeRepos map[string]sets.Set[string]
 	excludeRepos map[string]sets.Set[string]
+	outputModes  map[string]OutputMode // by org/repo, for included repos not using OutputModeComment
 }
 
 const TrtCommentIDKey = `trt_comment_id`
 
+// OutputMode is how analysis results are published on a PR.
+type OutputMode string
+
+const (
+	// OutputModeComment posts results as a PR comment, replacing the previous one. This is the default.
+	OutputModeComment OutputMode = "comment"
+	// OutputModeCheckRun completes a check run on the PR head commit, which reviewers can require.
+	OutputModeCheckRun OutputMode = "check-run"
+)
+
 func NewGitHubCommenter(githubClient *github.Client, dbc *db.DB, excludedRepos, includedRepos []string) (*GitHubCommenter, error) {
 	ghCommenter := &GitHubCommenter{}
 	ghCommenter.githubClient = githubClient
@@ -36,6 +47,11 @@ func NewGitHubCommenter(githubClient *github.Client, dbc *db.DB, excludedRepos,
 		return nil, err
 	}
 
+	includedRepos, ghCommenter.outputModes, err = splitOutputModes(includedRepos)
+	if err != nil {
+		log.WithError(err).Error("Failed GitHub commenter initialization")
+		return nil, err
+	}
 	ghCommenter.includeRepos, err = buildOrgRepos(includedRepos)
 	if err != nil {
 		log.WithError(err).Error("Failed GitHub commenter initialization")
@@ -45,6 +61,31 @@ func NewGitHubCommenter(githubClient *github.Client, dbc *db.DB, excludedRepos,
 	return ghCommenter, nil
 }
 
+// splitOutputModes strips any :<mode> suffix from included repos, returning the repos and the
+// modes set for them by org/repo.
+func splitOutputModes(in []string) ([]string, map[string]OutputMode, error) {
+	repos := make([]string, 0, len(in))
+	modes := make(map[string]OutputMode)
+	for _, r := range in {
+		orgRepo, mode, found := strings.Cut(r, `:`)
+		repos = append(repos, orgRepo)
+		if !found {
+			continue
+		}
+		switch OutputMode(mode) {
+		case OutputModeComment:
+		case OutputModeCheckRun:
+			if !strings.Contains(orgRepo, `/`) {
+				orgRepo = `openshift/` + orgRepo
+			}
+			modes[orgRepo] = OutputModeCheckRun
+		default:
+			return nil, nil, fmt.Errorf("invalid output mode %q for %s, expected %s or %s", mode, orgRepo, OutputModeComment, OutputModeCheckRun)
+		}
+	}
+	return repos, modes, nil
+}
+
 func buildOrgRepos(in []string) (map[string]sets.Set[string], error) {
 	if len(in) < 1 {
 		return nil, nil
@@ -127,6 +168,14 @@ func (ghc *GitHubCommenter) IsRepoIncluded(org, repo string) bool {
 	return val.Has(repo)
 }
 
+// OutputMode returns how results should be published for the repo.
+func (ghc *GitHubCommenter) OutputMode(org, repo string) OutputMode {
+	if mode, ok := ghc.outputModes[org+`/`+repo]; ok {
+		return mode
+	}
+	return OutputModeComment
+}
+
 func (ghc *GitHubCommenter) UpdatePendingCommentRecords(org, repo string, prNumber int, sha string, commentType models.CommentType, mergedAt *time.Time, pjPath string) {
 	if !ghc.IsRepoIncluded(org, repo) {
 		return
@@ -354,3 +403,13 @@ func (ghc *GitHubCommenter) DeleteComment(org, repo string, updateID int64) erro
 
 	return ghc.githubClient.DeletePRComment(org, repo, updateID)
 }
+
+func (ghc *GitHubCommenter) UpsertCheckRun(org, repo, sha string, checkRun github.CheckRun) error {
+	// could return error or log something but handle silently for now
+	// we shouldn't even get called in this case
+	if !ghc.IsRepoIncluded(org, repo) {
+		return nil
+	}
+
+	return ghc.githubClient.UpsertCheckRun(org, repo, sha, checkRun)
+}
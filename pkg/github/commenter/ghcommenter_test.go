@@ -133,3 +133,33 @@ func TestGitHubCommenter_IsRepoIncluded(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubCommenter_OutputMode(t *testing.T) {
+	ghCommenter, err := NewGitHubCommenter(nil, nil, nil, []string{`origin:check-run`, `org1/repo1:comment`, `org1/repo2:check-run`, `org1/repo3`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		org          string
+		repo         string
+		expectedMode OutputMode
+	}{
+		{org: "openshift", repo: "origin", expectedMode: OutputModeCheckRun},
+		{org: "org1", repo: "repo1", expectedMode: OutputModeComment},
+		{org: "org1", repo: "repo2", expectedMode: OutputModeCheckRun},
+		{org: "org1", repo: "repo3", expectedMode: OutputModeComment},
+	}
+	for _, tt := range tests {
+		if !ghCommenter.IsRepoIncluded(tt.org, tt.repo) {
+			t.Errorf("%s/%s should be included regardless of its output mode", tt.org, tt.repo)
+		}
+		if mode := ghCommenter.OutputMode(tt.org, tt.repo); mode != tt.expectedMode {
+			t.Errorf("%s/%s: expected output mode %s, got %s", tt.org, tt.repo, tt.expectedMode, mode)
+		}
+	}
+
+	if _, err := NewGitHubCommenter(nil, nil, nil, []string{`org1/repo1:status`}); err == nil {
+		t.Error("expected an error for an unknown output mode")
+	}
+}
@@ -7,9 +7,6 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/openshift/sippy/pkg/apis/cache"
-	"github.com/openshift/sippy/pkg/bigquery"
-	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/mcp/tools"
 )
 
@@ -18,7 +15,7 @@ type MCPServer struct {
 	streamSrv *server.StreamableHTTPServer
 }
 
-func NewMCPServer(ctx context.Context, sippyServer *http.Server, dbClient *db.DB, bigQueryClient *bigquery.Client, cacheClient cache.Cache) *MCPServer {
+func NewMCPServer(ctx context.Context, sippyServer *http.Server, deps *tools.ToolDependencies) *MCPServer {
 	hooks := &server.Hooks{}
 
 	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
@@ -41,15 +38,15 @@ func NewMCPServer(ctx context.Context, sippyServer *http.Server, dbClient *db.DB
 	log.Debug("Created MCP server instance")
 
 	// Register tools
-	if dbClient != nil {
-		tools.RegisterTools(mcpServer, dbClient, bigQueryClient, cacheClient)
+	if deps.DBClient != nil {
+		tools.RegisterTools(mcpServer, deps)
 		log.Debug("Registered MCP tools")
 	}
 
 	streamSrv := server.NewStreamableHTTPServer(
 		mcpServer,
 		server.WithStreamableHTTPServer(sippyServer),
-		server.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context { return ctx }),
+		server.WithHTTPContextFunc(tools.ContextWithRequest),
 	)
 
 	return &MCPServer{
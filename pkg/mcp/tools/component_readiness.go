@@ -0,0 +1,286 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/api/componentreadiness/utils"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	sippyv1 "github.com/openshift/sippy/pkg/apis/sippy/v1"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// parseComponentReportRequest resolves component readiness request options from the given query
+// parameters, the same way the component readiness API endpoints do.
+func (bt *BaseTool) parseComponentReportRequest(ctx context.Context, params url.Values) (reqopts.RequestOptions, []sippyv1.Release, error) {
+	req, err := bt.NewRequest(ctx, "/api/component_readiness", params)
+	if err != nil {
+		return reqopts.RequestOptions{}, nil, err
+	}
+
+	allJobVariants, errs := componentreadiness.GetJobVariants(ctx, bt.deps.CRDataProvider, reqopts.RequestOptions{})
+	if len(errs) > 0 {
+		return reqopts.RequestOptions{}, nil, fmt.Errorf("failed to get job variants: %v", errs)
+	}
+
+	allReleases, err := bt.deps.CRDataProvider.QueryReleases(ctx)
+	if err != nil {
+		return reqopts.RequestOptions{}, nil, fmt.Errorf("error querying releases: %w", err)
+	}
+
+	options, _, err := utils.ParseComponentReportRequest(bt.deps.Views, allReleases, req, allJobVariants,
+		bt.deps.CRTimeRoundingFactor, bt.deps.CRTimeRoundingOffset)
+	if err != nil {
+		return reqopts.RequestOptions{}, nil, err
+	}
+	return options, allReleases, nil
+}
+
+// ComponentReportTool implements the get_component_report MCP tool
+type ComponentReportTool struct {
+	*BaseTool
+}
+
+// NewComponentReportTool creates a new component report tool instance
+func NewComponentReportTool(deps *ToolDependencies) *ComponentReportTool {
+	return &ComponentReportTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the component report tool
+func (ct *ComponentReportTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("get_component_report",
+		mcp.WithDescription("Get the component readiness report for a named view, listing the tests that are currently "+
+			"regressed along with their pass rates, variants and statistical explanations."),
+		mcp.WithString("view", mcp.Required(), mcp.Description("Name of the component readiness view to report on")),
+		mcp.WithString("component", mcp.Description("Only include regressed tests for this component")),
+	)
+}
+
+// ComponentReportResponse is a condensed component report containing only the regressed tests.
+type ComponentReportResponse struct {
+	View           string                              `json:"view"`
+	GeneratedAt    *time.Time                          `json:"generated_at,omitempty"`
+	RegressedTests []componentreport.ReportTestSummary `json:"regressed_tests"`
+	Warnings       []string                            `json:"warnings,omitempty"`
+}
+
+// GetHandler returns the request handler for the component report tool
+func (ct *ComponentReportTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling get_component_report tool call")
+
+		view, err := request.RequireString("view")
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+		component := request.GetString("component", "")
+
+		params := url.Values{"view": []string{view}}
+		options, _, err := ct.parseComponentReportRequest(ctx, params)
+		if err != nil {
+			return ct.CreateErrorResponse(fmt.Errorf("error parsing component report request: %w", err))
+		}
+		req, err := ct.NewRequest(ctx, "/api/component_readiness", params)
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+
+		report, errs := componentreadiness.GetComponentReport(ctx, ct.deps.CRDataProvider, ct.deps.DBClient, options,
+			api.GetBaseFrontendURL(req))
+		if len(errs) > 0 {
+			log.WithField("errors", errs).Error("error generating component report")
+			return ct.CreateErrorResponse(fmt.Errorf("error generating component report: %v", errs))
+		}
+
+		response := ComponentReportResponse{
+			View:           view,
+			GeneratedAt:    report.GeneratedAt,
+			RegressedTests: []componentreport.ReportTestSummary{},
+			Warnings:       report.Warnings,
+		}
+		for _, row := range report.Rows {
+			if component != "" && row.Component != component {
+				continue
+			}
+			for _, col := range row.Columns {
+				response.RegressedTests = append(response.RegressedTests, col.RegressedTests...)
+			}
+		}
+		return ct.CreateJSONResponse(response)
+	}
+}
+
+// ListRegressionsTool implements the list_regressions MCP tool
+type ListRegressionsTool struct {
+	*BaseTool
+}
+
+// NewListRegressionsTool creates a new list regressions tool instance
+func NewListRegressionsTool(deps *ToolDependencies) *ListRegressionsTool {
+	return &ListRegressionsTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the list regressions tool
+func (lt *ListRegressionsTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("list_regressions",
+		mcp.WithDescription("List component readiness regressions tracked by Sippy for a view or release. Only open "+
+			"regressions are returned unless include_closed is set. Each regression includes its triages and links to "+
+			"test details per view, which can be passed to get_regression_test_details."),
+		mcp.WithString("view", mcp.Description("Name of the component readiness view, cannot be combined with release")),
+		mcp.WithString("release", mcp.Description("Release to list regressions for, e.g. \"4.20\"")),
+		mcp.WithString("test", mcp.Description("Only include regressions for this exact test name")),
+		mcp.WithBoolean("include_closed", mcp.Description("Include regressions that have since closed"), mcp.DefaultBool(false)),
+	)
+}
+
+// GetHandler returns the request handler for the list regressions tool
+func (lt *ListRegressionsTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling list_regressions tool call")
+
+		view := request.GetString("view", "")
+		release := request.GetString("release", "")
+		testName := request.GetString("test", "")
+		includeClosed := request.GetBool("include_closed", false)
+		if view != "" && release != "" {
+			return lt.CreateErrorResponse(fmt.Errorf("cannot specify both view and release, please use only one"))
+		}
+
+		views := lt.deps.Views
+		if view != "" {
+			v, ok := componentreadiness.FindViewByName(view, lt.deps.Views)
+			if !ok {
+				return lt.CreateErrorResponse(fmt.Errorf("view %q not found", view))
+			}
+			views = []crview.View{v}
+			release = v.SampleRelease.Name
+		}
+
+		allReleases, err := lt.deps.CRDataProvider.QueryReleases(ctx)
+		if err != nil {
+			return lt.CreateErrorResponse(fmt.Errorf("error querying releases: %w", err))
+		}
+		req, err := lt.NewRequest(ctx, "/api/component_readiness/regressions", nil)
+		if err != nil {
+			return lt.CreateErrorResponse(err)
+		}
+
+		var regressions []models.TestRegression
+		if testName != "" {
+			regressions, err = componentreadiness.GetRegressionsForTest(lt.deps.DBClient, release, testName, views, allReleases,
+				lt.deps.CRTimeRoundingFactor, lt.deps.CRTimeRoundingOffset, req)
+		} else {
+			regressions, err = componentreadiness.ListRegressions(lt.deps.DBClient, release, views, allReleases,
+				lt.deps.CRTimeRoundingFactor, lt.deps.CRTimeRoundingOffset, req)
+		}
+		if err != nil {
+			return lt.CreateErrorResponse(fmt.Errorf("error listing regressions: %w", err))
+		}
+
+		if !includeClosed {
+			open := make([]models.TestRegression, 0, len(regressions))
+			for _, r := range regressions {
+				if !r.Closed.Valid {
+					open = append(open, r)
+				}
+			}
+			regressions = open
+		}
+		return lt.CreateJSONResponse(regressions)
+	}
+}
+
+// RegressionTestDetailsTool implements the get_regression_test_details MCP tool
+type RegressionTestDetailsTool struct {
+	*BaseTool
+}
+
+// NewRegressionTestDetailsTool creates a new regression test details tool instance
+func NewRegressionTestDetailsTool(deps *ToolDependencies) *RegressionTestDetailsTool {
+	return &RegressionTestDetailsTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the regression test details tool
+func (rt *RegressionTestDetailsTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("get_regression_test_details",
+		mcp.WithDescription("Get the component readiness test details for a regression, including basis and sample "+
+			"pass rates, the statistical analysis and the failed job runs behind it."),
+		mcp.WithNumber("regression_id", mcp.Required(), mcp.Description("ID of the regression, as returned by list_regressions")),
+		mcp.WithString("view", mcp.Description("View to analyze the regression in; defaults to the first view the regression is active in")),
+	)
+}
+
+// GetHandler returns the request handler for the regression test details tool
+func (rt *RegressionTestDetailsTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling get_regression_test_details tool call")
+
+		regressionID, err := request.RequireInt("regression_id")
+		if err != nil {
+			return rt.CreateErrorResponse(err)
+		}
+		view := request.GetString("view", "")
+
+		allReleases, err := rt.deps.CRDataProvider.QueryReleases(ctx)
+		if err != nil {
+			return rt.CreateErrorResponse(fmt.Errorf("error querying releases: %w", err))
+		}
+		req, err := rt.NewRequest(ctx, "/api/component_readiness/regressions", nil)
+		if err != nil {
+			return rt.CreateErrorResponse(err)
+		}
+		regression, err := componentreadiness.GetRegression(rt.deps.DBClient, regressionID, rt.deps.Views, allReleases,
+			rt.deps.CRTimeRoundingFactor, rt.deps.CRTimeRoundingOffset, req)
+		if err != nil {
+			return rt.CreateErrorResponse(fmt.Errorf("error looking up regression %d: %w", regressionID, err))
+		}
+		if regression == nil {
+			return rt.CreateErrorResponse(fmt.Errorf("regression %d not found", regressionID))
+		}
+
+		// the regression links carry the fully resolved test details query for each view it is active in
+		if view == "" {
+			for _, rv := range regression.Views {
+				if _, ok := regression.Links["test_details:"+rv.ViewName]; ok {
+					view = rv.ViewName
+					break
+				}
+			}
+		}
+		link, ok := regression.Links["test_details:"+view]
+		if !ok {
+			return rt.CreateErrorResponse(fmt.Errorf("regression %d is not active in view %q", regressionID, view))
+		}
+		testDetailsURL, err := url.Parse(link)
+		if err != nil {
+			return rt.CreateErrorResponse(fmt.Errorf("error parsing test details link: %w", err))
+		}
+
+		options, releases, err := rt.parseComponentReportRequest(ctx, testDetailsURL.Query())
+		if err != nil {
+			return rt.CreateErrorResponse(fmt.Errorf("error parsing test details request: %w", err))
+		}
+		report, errs := componentreadiness.GetTestDetails(ctx, rt.deps.CRDataProvider, rt.deps.DBClient, options, releases,
+			api.GetBaseFrontendURL(req))
+		if len(errs) > 0 {
+			log.WithField("errors", errs).Error("error querying test details")
+			return rt.CreateErrorResponse(fmt.Errorf("error querying test details: %v", errs))
+		}
+		return rt.CreateJSONResponse(report)
+	}
+}
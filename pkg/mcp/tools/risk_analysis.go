@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+)
+
+// JobRunRiskAnalysisTool implements the get_job_run_risk_analysis MCP tool
+type JobRunRiskAnalysisTool struct {
+	*BaseTool
+}
+
+// NewJobRunRiskAnalysisTool creates a new job run risk analysis tool instance
+func NewJobRunRiskAnalysisTool(deps *ToolDependencies) *JobRunRiskAnalysisTool {
+	return &JobRunRiskAnalysisTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the job run risk analysis tool
+func (jt *JobRunRiskAnalysisTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("get_job_run_risk_analysis",
+		mcp.WithDescription("Analyze the test failures in a prow job run and estimate the risk that they are new "+
+			"regressions rather than known flakes or infrastructure problems, based on historical pass rates."),
+		mcp.WithString("prow_job_run_id", mcp.Required(), mcp.Description("ID of the prow job run, as found at the end of its prow URL")),
+	)
+}
+
+// GetHandler returns the request handler for the job run risk analysis tool
+func (jt *JobRunRiskAnalysisTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling get_job_run_risk_analysis tool call")
+
+		jobRunIDStr, err := request.RequireString("prow_job_run_id")
+		if err != nil {
+			return jt.CreateErrorResponse(err)
+		}
+		jobRunID, err := strconv.ParseInt(jobRunIDStr, 10, 64)
+		if err != nil {
+			return jt.CreateErrorResponse(fmt.Errorf("unable to parse prow_job_run_id: %w", err))
+		}
+		logger := log.WithField("func", "get_job_run_risk_analysis").WithField("jobRunID", jobRunID)
+
+		jobRun, err := api.FetchJobRun(jt.deps.DBClient, jobRunID, false, nil, logger)
+		if err != nil {
+			return jt.CreateErrorResponse(err)
+		}
+
+		result, err := api.JobRunRiskAnalysis(ctx, logger, jt.deps.DBClient, jt.deps.BigQueryClient, jt.deps.CacheClient, jobRun, false)
+		if err != nil {
+			return jt.CreateErrorResponse(fmt.Errorf("error analyzing job run %d: %w", jobRunID, err))
+		}
+		return jt.CreateJSONResponse(result)
+	}
+}
@@ -4,36 +4,52 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/andygrunwald/go-jira"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/openshift/sippy/pkg/api/componentreadiness/dataprovider"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
 	"github.com/openshift/sippy/pkg/apis/cache"
 	"github.com/openshift/sippy/pkg/bigquery"
 	"github.com/openshift/sippy/pkg/db"
 )
 
 // RegisterTools registers all available MCP tools with the server
-func RegisterTools(mcpServer *server.MCPServer, dbClient *db.DB, bigQueryClient *bigquery.Client, cacheClient cache.Cache) {
-	// Create tool dependencies
-	deps := &ToolDependencies{
-		DBClient:       dbClient,
-		BigQueryClient: bigQueryClient,
-		CacheClient:    cacheClient,
+func RegisterTools(mcpServer *server.MCPServer, deps *ToolDependencies) {
+	for _, tool := range availableTools(deps) {
+		mcpServer.AddTool(tool.GetDefinition(), tool.GetHandler())
+		log.WithField("tool", tool.GetDefinition().Name).Info("Registered MCP tool")
 	}
+}
 
-	// Register all tools
+// availableTools returns the tools that can be offered with the given dependencies
+func availableTools(deps *ToolDependencies) []MCPTool {
 	tools := []MCPTool{
 		NewReleasesTool(deps),
 		NewHealthTool(deps), // Example tool demonstrating the pattern
+		NewListTriagesTool(deps),
+		NewJobRunRiskAnalysisTool(deps),
 		// Add new tools here following the same pattern
 	}
-
-	for _, tool := range tools {
-		mcpServer.AddTool(tool.GetDefinition(), tool.GetHandler())
-		log.WithField("tool", tool.GetDefinition().Name).Info("Registered MCP tool")
+	// Component readiness tools need a data provider, like the corresponding API endpoints
+	if deps.CRDataProvider != nil {
+		tools = append(tools,
+			NewComponentReportTool(deps),
+			NewListRegressionsTool(deps),
+			NewRegressionTestDetailsTool(deps),
+		)
 	}
+	// Tools that modify data are only offered when the server allows writes
+	if deps.EnableWriteAPIs {
+		tools = append(tools, NewCreateTriageTool(deps))
+	}
+	return tools
 }
 
 // ToolDependencies holds common dependencies that tools may need
@@ -41,6 +57,27 @@ type ToolDependencies struct {
 	DBClient       *db.DB
 	BigQueryClient *bigquery.Client
 	CacheClient    cache.Cache
+
+	// Component readiness configuration, as used by the corresponding API endpoints
+	CRDataProvider       dataprovider.DataProvider
+	Views                []crview.View
+	CRTimeRoundingFactor time.Duration
+	CRTimeRoundingOffset time.Duration
+
+	JiraClient *jira.Client
+	// EnableWriteAPIs allows registering tools that modify data
+	EnableWriteAPIs bool
+	// AuthorizeWrite, when set, applies the authorization of the API route a write tool stands in
+	// for to the tool's request, returning an error when the caller is not allowed.
+	AuthorizeWrite func(req *http.Request, route string) error
+}
+
+type requestContextKey struct{}
+
+// ContextWithRequest stores the HTTP request carrying an MCP call, so tools can build links and
+// identify the calling user the same way the API does.
+func ContextWithRequest(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, req)
 }
 
 // MCPTool defines the interface that all MCP tools must implement
@@ -100,3 +137,18 @@ func (bt *BaseTool) CreateTextResponse(text string) *mcp.CallToolResult {
 func (bt *BaseTool) CreateErrorResponse(err error) (*mcp.CallToolResult, error) {
 	return nil, err
 }
+
+// NewRequest builds a GET request so tools can reuse API functions that read parameters from a request.
+// The host and headers of the MCP request are carried over, keeping generated links and the user identity intact.
+func (bt *BaseTool) NewRequest(ctx context.Context, path string, params url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if orig, ok := ctx.Value(requestContextKey{}).(*http.Request); ok && orig != nil {
+		req.Host = orig.Host
+		req.TLS = orig.TLS
+		req.Header = orig.Header.Clone()
+	}
+	return req, nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness/dataprovider"
+)
+
+func toolNames(deps *ToolDependencies) []string {
+	var names []string
+	for _, tool := range availableTools(deps) {
+		names = append(names, tool.GetDefinition().Name)
+	}
+	return names
+}
+
+func TestAvailableTools(t *testing.T) {
+	var provider dataprovider.DataProvider = struct{ dataprovider.DataProvider }{}
+
+	names := toolNames(&ToolDependencies{})
+	assert.Contains(t, names, "list_triages")
+	assert.Contains(t, names, "get_job_run_risk_analysis")
+	assert.NotContains(t, names, "get_component_report")
+	assert.NotContains(t, names, "create_triage")
+
+	names = toolNames(&ToolDependencies{CRDataProvider: provider})
+	assert.Contains(t, names, "get_component_report")
+	assert.Contains(t, names, "list_regressions")
+	assert.Contains(t, names, "get_regression_test_details")
+	assert.NotContains(t, names, "create_triage")
+
+	names = toolNames(&ToolDependencies{EnableWriteAPIs: true})
+	assert.Contains(t, names, "create_triage")
+}
+
+func TestNewRequestCarriesCallerIdentity(t *testing.T) {
+	orig, err := http.NewRequest(http.MethodPost, "https://sippy.example.com/mcp/v1", nil)
+	require.NoError(t, err)
+	orig.Header.Set("X-Forwarded-User", "jdoe")
+	ctx := ContextWithRequest(context.Background(), orig)
+
+	bt := NewBaseTool(&ToolDependencies{})
+	req, err := bt.NewRequest(ctx, "/api/component_readiness/triages", url.Values{"view": []string{"4.20-main"}})
+	require.NoError(t, err)
+	assert.Equal(t, "sippy.example.com", req.Host)
+	assert.Equal(t, "jdoe", req.Header.Get("X-Forwarded-User"))
+	assert.Equal(t, "4.20-main", req.URL.Query().Get("view"))
+
+	req, err = bt.NewRequest(context.Background(), "/api/component_readiness/triages", nil)
+	require.NoError(t, err)
+	assert.Empty(t, req.Header.Get("X-Forwarded-User"))
+}
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// ListTriagesTool implements the list_triages MCP tool
+type ListTriagesTool struct {
+	*BaseTool
+}
+
+// NewListTriagesTool creates a new list triages tool instance
+func NewListTriagesTool(deps *ToolDependencies) *ListTriagesTool {
+	return &ListTriagesTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the list triages tool
+func (lt *ListTriagesTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("list_triages",
+		mcp.WithDescription("List triage records, which tie component readiness regressions to Jira bugs along with "+
+			"the type of failure (product, test, ci-infra or product-infra) and whether it has been resolved."),
+		mcp.WithString("view", mcp.Description("Only include triages with regressions in this component readiness view")),
+	)
+}
+
+// GetHandler returns the request handler for the list triages tool
+func (lt *ListTriagesTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling list_triages tool call")
+
+		params := url.Values{}
+		if view := request.GetString("view", ""); view != "" {
+			params.Set("view", view)
+		}
+		req, err := lt.NewRequest(ctx, "/api/component_readiness/triages", params)
+		if err != nil {
+			return lt.CreateErrorResponse(err)
+		}
+
+		triages, err := componentreadiness.ListTriages(lt.deps.DBClient, req)
+		if err != nil {
+			log.WithError(err).Error("error listing triages")
+			return lt.CreateErrorResponse(fmt.Errorf("error listing triages: %w", err))
+		}
+		return lt.CreateJSONResponse(triages)
+	}
+}
+
+// CreateTriageTool implements the create_triage MCP tool. It is only registered when write APIs are enabled.
+type CreateTriageTool struct {
+	*BaseTool
+}
+
+// NewCreateTriageTool creates a new create triage tool instance
+func NewCreateTriageTool(deps *ToolDependencies) *CreateTriageTool {
+	return &CreateTriageTool{
+		BaseTool: NewBaseTool(deps),
+	}
+}
+
+// GetDefinition returns the MCP tool definition for the create triage tool
+func (ct *CreateTriageTool) GetDefinition() mcp.Tool {
+	return mcp.NewTool("create_triage",
+		mcp.WithDescription("Create a triage record associating one or more component readiness regressions with a Jira bug. "+
+			"Only use this when explicitly asked to triage regressions."),
+		mcp.WithString("url", mcp.Required(), mcp.Description("URL of the Jira bug the regressions are triaged to")),
+		mcp.WithString("type", mcp.Required(), mcp.Description("Type of failure"),
+			mcp.Enum(string(models.TriageTypeProduct), string(models.TriageTypeTest),
+				string(models.TriageTypeCIInfra), string(models.TriageTypeProductInfra))),
+		mcp.WithString("description", mcp.Description("Short description of the issue")),
+		mcp.WithArray("regression_ids", mcp.Required(), mcp.Description("IDs of the regressions to triage"),
+			mcp.WithNumberItems()),
+	)
+}
+
+// GetHandler returns the request handler for the create triage tool
+func (ct *CreateTriageTool) GetHandler() func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		log.Debug("Handling create_triage tool call")
+
+		req, err := ct.NewRequest(ctx, "/api/component_readiness/triages", nil)
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+		// the same groups may create triages as through POST /api/component_readiness/triages
+		req.Method = http.MethodPost
+		if ct.deps.AuthorizeWrite != nil {
+			if err := ct.deps.AuthorizeWrite(req, "/api/component_readiness/triages"); err != nil {
+				return ct.CreateErrorResponse(err)
+			}
+		}
+		// writes are attributed to the authenticated user just like the API, so refuse anonymous callers
+		user := api.GetUserForRequest(req)
+		if user == "" {
+			return ct.CreateErrorResponse(fmt.Errorf("creating a triage requires an authenticated user"))
+		}
+
+		triageURL, err := request.RequireString("url")
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+		triageType, err := request.RequireString("type")
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+		regressionIDs, err := request.RequireIntSlice("regression_ids")
+		if err != nil {
+			return ct.CreateErrorResponse(err)
+		}
+
+		triage := models.Triage{
+			URL:         triageURL,
+			Type:        models.TriageType(triageType),
+			Description: request.GetString("description", ""),
+		}
+		for _, id := range regressionIDs {
+			triage.Regressions = append(triage.Regressions, models.TestRegression{ID: uint(id)})
+		}
+
+		log.Infof("triage creation made via MCP by user: %s", user)
+		dbCtx := context.WithValue(ctx, models.CurrentUserKey, user)
+		triage, err = componentreadiness.CreateTriage(ct.deps.DBClient.DB.WithContext(dbCtx), ct.deps.JiraClient, triage, req)
+		if err != nil {
+			return ct.CreateErrorResponse(fmt.Errorf("error creating triage: %w", err))
+		}
+		return ct.CreateJSONResponse(triage)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"io/fs"
+	"path"
 	"strings"
 )
 
@@ -73,48 +75,75 @@ import (
 	- TRT-1234-some-other-problem.json
 	- TRT-5678-several-for-same-problem.json
 */
+// Allowances used to be defined only by these embedded files; they now live in the database and the
+// files are kept as the source for `sippy migrate regression-allowances`, and seed an empty database
+// whenever the schema is updated.
+//
 //go:embed regressions
 var regressionsDir embed.FS
 
-func init() {
-	// get a list of directories in the regressions directory
-	// for each directory, get the json files
-	// for each json file, unmarshal the json into a slice of IntentionalRegression
-	// for each IntentionalRegression, add it to the map
+// Embedded returns the regressions directory compiled into sippy, laid out as in the example above.
+func Embedded() fs.FS {
+	sub, err := fs.Sub(regressionsDir, "regressions")
+	if err != nil {
+		panic(err) // the directory is embedded, so this cannot happen
+	}
+	return sub
+}
 
-	fs, err := regressionsDir.ReadDir("regressions")
+// LoadJSON reads every json file in each release directory of fsys, returning the validated
+// intentional regressions keyed by the release they are allowed in.
+func LoadJSON(fsys fs.FS) (map[string][]IntentionalRegression, error) {
+	entries, err := fs.ReadDir(fsys, ".")
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	for _, entry := range fs {
+
+	result := map[string][]IntentionalRegression{}
+	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
 
 		releaseStr := entry.Name()
-		dirPath := "regressions/" + releaseStr
-		releaseDir, err := regressionsDir.ReadDir(dirPath)
+		releaseDir, err := fs.ReadDir(fsys, releaseStr)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 
+		seen := map[string]bool{}
 		for _, file := range releaseDir {
 			if !strings.HasSuffix(file.Name(), ".json") {
 				continue // only interested in json files under each release
 			}
 
-			filePath := dirPath + "/" + file.Name()
-			regressionsFile, err := regressionsDir.ReadFile(filePath)
+			filePath := path.Join(releaseStr, file.Name())
+			regressionsFile, err := fs.ReadFile(fsys, filePath)
 			if err != nil {
-				panic(err)
+				return nil, err
 			}
 
-			importIntentionalRegressions(release(releaseStr), filePath, regressionsFile)
+			regressions, err := parseIntentionalRegressions(filePath, regressionsFile)
+			if err != nil {
+				return nil, err
+			}
+			for _, regression := range regressions {
+				if err := Validate(regression); err != nil {
+					return nil, fmt.Errorf("invalid regression for test %q in %q: %w", regression.TestID, filePath, err)
+				}
+				key := keyFor(regression.TestID, regression.Variant)
+				if seen[key] {
+					return nil, fmt.Errorf("test %q was already added", regression.TestID)
+				}
+				seen[key] = true
+				result[releaseStr] = append(result[releaseStr], regression)
+			}
 		}
 	}
+	return result, nil
 }
 
-func importIntentionalRegressions(releaseTarget release, path string, jsonRegressions []byte) {
+func parseIntentionalRegressions(path string, jsonRegressions []byte) ([]IntentionalRegression, error) {
 	regressions := []IntentionalRegression{}
 	regression := IntentionalRegression{}
 
@@ -122,15 +151,9 @@ func importIntentionalRegressions(releaseTarget release, path string, jsonRegres
 	if listErr != nil {
 		singleErr := json.Unmarshal(jsonRegressions, &regression)
 		if singleErr != nil {
-			panic(fmt.Errorf("could not load json file %q either as a list (%s) or single (%s) regression", path, listErr, singleErr))
+			return nil, fmt.Errorf("could not load json file %q either as a list (%s) or single (%s) regression", path, listErr, singleErr)
 		}
 		regressions = []IntentionalRegression{regression}
 	}
-
-	for _, regression := range regressions {
-		mustAddIntentionalRegression(
-			releaseTarget,
-			regression,
-		)
-	}
+	return regressions, nil
 }
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,9 +14,18 @@ import (
 var overrideBytes []byte
 
 func Test_ApprovalRequiredForRegressionInConsecutiveReleases(t *testing.T) {
+	loaded, err := LoadJSON(Embedded())
+	require.NoError(t, err, "unable to load the embedded regressions")
+	intentionalRegressions := map[string]map[string]IntentionalRegression{}
+	for rel, irs := range loaded {
+		intentionalRegressions[rel] = map[string]IntentionalRegression{}
+		for _, ir := range irs {
+			intentionalRegressions[rel][keyFor(ir.TestID, ir.Variant)] = ir
+		}
+	}
 
 	// Look up the test IDs that were allowed regressions in each release
-	allowedTestIDs := map[release]map[string]bool{}
+	allowedTestIDs := map[string]map[string]bool{}
 	for rel, regressions := range intentionalRegressions {
 		allowedTestIDs[rel] = map[string]bool{}
 		for key := range regressions {
@@ -29,13 +39,13 @@ func Test_ApprovalRequiredForRegressionInConsecutiveReleases(t *testing.T) {
 
 	// Parse the overrides file:
 	overrides := map[string]map[string]bool{}
-	err := json.Unmarshal(overrideBytes, &overrides)
+	err = json.Unmarshal(overrideBytes, &overrides)
 	require.NoError(t, err, "unable to parse the overrides.json file")
 	t.Logf("override allowance test IDs: %v", overrides)
 
 	for thisRelease, regressions := range intentionalRegressions {
 		for thisKey, ir := range regressions {
-			prevRelease := release(ir.PreviousRelease)
+			prevRelease := ir.PreviousRelease
 
 			// We now do just a very rudimentary check on test ID, without considering variants; if
 			// the same test is regressed in two releases consecutively, extra approval is required.
@@ -50,7 +60,7 @@ func Test_ApprovalRequiredForRegressionInConsecutiveReleases(t *testing.T) {
 			}
 
 			// this release's testID was in the previous; does it have an override?
-			releaseOverrides, ok := overrides[string(thisRelease)]
+			releaseOverrides, ok := overrides[thisRelease]
 			assert.True(t, ok && releaseOverrides[regKey.TestID],
 				"test ID %s found in both %s and %s without an override",
 				regKey.TestID, thisRelease, prevRelease)
@@ -62,3 +72,64 @@ func Test_ApprovalRequiredForRegressionInConsecutiveReleases(t *testing.T) {
 		t.Logf("Please see https://github.com/openshift/sippy/blob/master/pkg/regressionallowances/consecutiveoverrides/README.md for instructions.")
 	}
 }
+
+const testRegressionJSON = `{
+  "JiraComponent": "Networking",
+  "TestID": "openshift-tests:abc123",
+  "TestName": "[sig-network] pods should talk",
+  "JiraBug": "https://issues.redhat.com/browse/OCPBUGS-1",
+  "ReasonToAllowInsteadOfFix": "fix needs an upstream rebase",
+  "variant": {"variants": {"Architecture": "amd64", "FeatureSet": "default", "Installer": "ipi", "Network": "ovn",
+    "Platform": "aws", "Suite": "unknown", "Topology": "ha", "Upgrade": "none"}},
+  "PreviousRelease": "4.18",
+  "PreviousSuccesses": 100,
+  "RegressedSuccesses": 90,
+  "RegressedFailures": 10
+}`
+
+func TestLoadJSON(t *testing.T) {
+	t.Run("single and list files", func(t *testing.T) {
+		loaded, err := LoadJSON(fstest.MapFS{
+			"OWNERS":           {Data: []byte("ignored")},
+			"4.19/single.json": {Data: []byte(testRegressionJSON)},
+			"4.20/list.json":   {Data: []byte("[" + testRegressionJSON + "]")},
+			"4.20/README.md":   {Data: []byte("ignored")},
+		})
+		require.NoError(t, err)
+		require.Len(t, loaded["4.19"], 1)
+		require.Len(t, loaded["4.20"], 1)
+		assert.Equal(t, "openshift-tests:abc123", loaded["4.19"][0].TestID)
+		assert.Equal(t, "aws", loaded["4.19"][0].Variant.Variants["Platform"])
+	})
+	t.Run("duplicate in a release", func(t *testing.T) {
+		_, err := LoadJSON(fstest.MapFS{
+			"4.19/a.json": {Data: []byte(testRegressionJSON)},
+			"4.19/b.json": {Data: []byte(testRegressionJSON)},
+		})
+		assert.ErrorContains(t, err, "already added")
+	})
+	t.Run("invalid regression", func(t *testing.T) {
+		_, err := LoadJSON(fstest.MapFS{
+			"4.19/a.json": {Data: []byte(`{"TestID": "openshift-tests:abc123"}`)},
+		})
+		assert.ErrorContains(t, err, "jiraComponent must be specified")
+	})
+	t.Run("unparseable file", func(t *testing.T) {
+		_, err := LoadJSON(fstest.MapFS{
+			"4.19/a.json": {Data: []byte(`nope`)},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestModelRoundTrip(t *testing.T) {
+	var ir IntentionalRegression
+	require.NoError(t, json.Unmarshal([]byte(testRegressionJSON), &ir))
+
+	model := ToModel("4.19", ir)
+	assert.Equal(t, "4.19", model.Release)
+	assert.Equal(t, []string{"Architecture:amd64", "FeatureSet:default", "Installer:ipi", "Network:ovn",
+		"Platform:aws", "Suite:unknown", "Topology:ha", "Upgrade:none"}, []string(model.Variants))
+	assert.Equal(t, ir, FromModel(model))
+	assert.NoError(t, Validate(FromModel(model)))
+}
@@ -0,0 +1,110 @@
+package regressionallowances
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
+	"github.com/openshift/sippy/pkg/db/models"
+)
+
+// Store looks up intentional regressions in the database. Each release's allowances are loaded on first use
+// and kept for the life of the Store, so create one per request to see changes made through the API.
+type Store struct {
+	dbc *gorm.DB
+
+	lock      sync.Mutex
+	byRelease map[string]map[string]IntentionalRegression
+}
+
+func NewStore(dbc *gorm.DB) *Store {
+	return &Store{
+		dbc:       dbc,
+		byRelease: map[string]map[string]IntentionalRegression{},
+	}
+}
+
+// IntentionalRegressionFor returns the allowance for the test and variant in the given release, or nil if there is none.
+func (s *Store) IntentionalRegressionFor(releaseString string, variant crtest.ColumnIdentification, testID string) *IntentionalRegression {
+	s.lock.Lock()
+	targetMap, ok := s.byRelease[releaseString]
+	if !ok {
+		targetMap = s.loadRelease(releaseString)
+		s.byRelease[releaseString] = targetMap
+	}
+	s.lock.Unlock()
+
+	if t, ok := targetMap[keyFor(testID, variant)]; ok {
+		log.Debugf("found approved regression: %+v", t)
+		return &t
+	}
+	return nil
+}
+
+func (s *Store) loadRelease(releaseString string) map[string]IntentionalRegression {
+	targetMap := map[string]IntentionalRegression{}
+	if s.dbc == nil {
+		return targetMap
+	}
+	var allowances []models.RegressionAllowance
+	if err := s.dbc.Where("release = ?", releaseString).Find(&allowances).Error; err != nil {
+		// analysis can proceed without allowances, it will just be stricter than intended
+		log.WithError(err).Errorf("error loading regression allowances for release %s", releaseString)
+		return targetMap
+	}
+	for _, allowance := range allowances {
+		ir := FromModel(allowance)
+		targetMap[keyFor(ir.TestID, ir.Variant)] = ir
+	}
+	return targetMap
+}
+
+// Import creates database allowances for the given intentional regressions keyed by release, skipping any
+// that already exist for the same release, test and variants so that it is safe to run repeatedly.
+// The user performing the import must be set on the dbc context for audit logging.
+func Import(dbc *gorm.DB, regressions map[string][]IntentionalRegression) (created, skipped int, err error) {
+	for release, irs := range regressions {
+		for _, ir := range irs {
+			allowance := ToModel(release, ir)
+			var existing models.RegressionAllowance
+			res := dbc.Where("release = ? AND test_id = ? AND variants = ?", release, allowance.TestID, allowance.Variants).
+				First(&existing)
+			switch {
+			case res.Error == nil:
+				skipped++
+				continue
+			case !errors.Is(res.Error, gorm.ErrRecordNotFound):
+				return created, skipped, fmt.Errorf("error looking up regression allowance for test %q in %s: %w", ir.TestID, release, res.Error)
+			}
+			if err := dbc.Create(&allowance).Error; err != nil {
+				return created, skipped, fmt.Errorf("error creating regression allowance for test %q in %s: %w", ir.TestID, release, err)
+			}
+			created++
+		}
+	}
+	return created, skipped, nil
+}
+
+// SeedEmbedded imports the allowances compiled into sippy when the database has none, so that a new or
+// upgraded database keeps the allowances that used to be read straight from the embedded files. Once any
+// allowance exists the table is left alone, so allowances deleted through the API are not brought back.
+// The user performing the import must be set on the dbc context for audit logging.
+func SeedEmbedded(dbc *gorm.DB) (int, error) {
+	var count int64
+	if err := dbc.Model(&models.RegressionAllowance{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("error counting regression allowances: %w", err)
+	}
+	if count > 0 {
+		return 0, nil
+	}
+	regressions, err := LoadJSON(Embedded())
+	if err != nil {
+		return 0, fmt.Errorf("error loading embedded regression allowances: %w", err)
+	}
+	created, _, err := Import(dbc, regressions)
+	return created, err
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
 	"github.com/openshift/sippy/pkg/componentreadiness/resolvedissues"
+	"github.com/openshift/sippy/pkg/db/models"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	log "github.com/sirupsen/logrus"
@@ -28,30 +30,11 @@ type IntentionalRegression struct {
 	ReasonToAllowInsteadOfFix string
 }
 
-type release string
-
-var intentionalRegressions = map[release]map[string]IntentionalRegression{}
-
 type regressionKey struct {
 	TestID  string
 	Variant crtest.ColumnIdentification
 }
 
-func IntentionalRegressionFor(releaseString string, variant crtest.ColumnIdentification, testID string) *IntentionalRegression {
-	var targetMap map[string]IntentionalRegression
-	var ok bool
-	if targetMap, ok = intentionalRegressions[release(releaseString)]; !ok {
-		return nil
-	}
-
-	inKey := keyFor(testID, variant)
-	if t, ok := targetMap[inKey]; ok {
-		log.Debugf("found approved regression: %+v", t)
-		return &t
-	}
-	return nil
-}
-
 func (i *IntentionalRegression) RegressedPassPercentage(flakeAsFailure bool) float64 {
 	return crtest.CalculatePassRate(i.RegressedSuccesses, i.RegressedFailures, i.RegressedFlakes, flakeAsFailure)
 }
@@ -82,13 +65,8 @@ func parseRegressionKey(key string) (regressionKey, error) {
 	return result, nil
 }
 
-func mustAddIntentionalRegression(release release, in IntentionalRegression) {
-	if err := addIntentionalRegression(release, in); err != nil {
-		panic(err)
-	}
-}
-
-func addIntentionalRegression(release release, in IntentionalRegression) error {
+// Validate ensures an intentional regression is complete and actually describes a regression.
+func Validate(in IntentionalRegression) error {
 	if len(in.JiraComponent) == 0 {
 		return fmt.Errorf("jiraComponent must be specified")
 	}
@@ -119,20 +97,55 @@ func addIntentionalRegression(release release, in IntentionalRegression) error {
 			return fmt.Errorf("%s must be specified", v)
 		}
 	}
+	return nil
+}
 
-	var targetMap map[string]IntentionalRegression
-	var ok bool
-	if targetMap, ok = intentionalRegressions[release]; !ok {
-		targetMap = map[string]IntentionalRegression{}
-		intentionalRegressions[release] = targetMap
+// FromModel converts a database allowance into the IntentionalRegression used during analysis.
+func FromModel(m models.RegressionAllowance) IntentionalRegression {
+	variants := map[string]string{}
+	for _, variant := range m.Variants {
+		if k, v := crtest.VariantStringToKeyValue(variant); k != "" {
+			variants[k] = v
+		}
 	}
-
-	inKey := keyFor(in.TestID, in.Variant)
-	if _, ok := targetMap[inKey]; ok {
-		return fmt.Errorf("test %q was already added", in.TestID)
+	return IntentionalRegression{
+		JiraComponent:             m.JiraComponent,
+		TestID:                    m.TestID,
+		TestName:                  m.TestName,
+		Variant:                   crtest.ColumnIdentification{Variants: variants},
+		PreviousSuccesses:         m.PreviousSuccesses,
+		PreviousFailures:          m.PreviousFailures,
+		PreviousFlakes:            m.PreviousFlakes,
+		RegressedSuccesses:        m.RegressedSuccesses,
+		RegressedFailures:         m.RegressedFailures,
+		RegressedFlakes:           m.RegressedFlakes,
+		PreviousRelease:           m.PreviousRelease,
+		JiraBug:                   m.JiraBug,
+		ReasonToAllowInsteadOfFix: m.ReasonToAllowInsteadOfFix,
 	}
+}
 
-	targetMap[inKey] = in
-
-	return nil
+// ToModel converts an IntentionalRegression allowed in the given release into its database form.
+func ToModel(release string, in IntentionalRegression) models.RegressionAllowance {
+	variants := make([]string, 0, len(in.Variant.Variants))
+	for k, v := range in.Variant.Variants {
+		variants = append(variants, crtest.VariantKeyValueToString(k, v))
+	}
+	sort.Strings(variants)
+	return models.RegressionAllowance{
+		Release:                   release,
+		JiraComponent:             in.JiraComponent,
+		TestID:                    in.TestID,
+		TestName:                  in.TestName,
+		Variants:                  variants,
+		PreviousRelease:           in.PreviousRelease,
+		PreviousSuccesses:         in.PreviousSuccesses,
+		PreviousFailures:          in.PreviousFailures,
+		PreviousFlakes:            in.PreviousFlakes,
+		RegressedSuccesses:        in.RegressedSuccesses,
+		RegressedFailures:         in.RegressedFailures,
+		RegressedFlakes:           in.RegressedFlakes,
+		JiraBug:                   in.JiraBug,
+		ReasonToAllowInsteadOfFix: in.ReasonToAllowInsteadOfFix,
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -24,6 +25,46 @@ type Client struct {
 	Token      string
 }
 
+// APIError is returned when the server responds with a non-success status code.
+type APIError struct {
+	StatusCode int
+	// Message is the message from the server's JSON error response, if it sent one.
+	Message string
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Page is one page of a paginated list response.
+type Page[T any] struct {
+	Rows      []T   `json:"rows"`
+	PageSize  int   `json:"page_size"`
+	Page      int   `json:"page"`
+	TotalRows int64 `json:"total_rows"`
+}
+
+// newAPIError builds an APIError from an unsuccessful response.
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	var failure struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &failure) == nil {
+		apiErr.Message = failure.Message
+	}
+	return apiErr
+}
+
 // Option is a functional option for configuring the client
 type Option func(*Client)
 
@@ -85,8 +126,7 @@ func (c *Client) Get(ctx context.Context, path string, result interface{}) error
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
@@ -126,8 +166,7 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return nil
@@ -164,8 +203,7 @@ func (c *Client) doJSONRequest(ctx context.Context, method, path string, body, r
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return newAPIError(resp)
 	}
 
 	if result != nil {
@@ -0,0 +1,70 @@
+package componentreadiness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+func TestReportQueryValues(t *testing.T) {
+	q := ReportQuery{
+		View:            "4.20-main",
+		TestID:          "openshift-tests:abc",
+		IncludeVariants: []string{"Platform:aws", "Network:ovn"},
+		Extra:           map[string][]string{"confidence": {"90"}},
+	}
+	values := q.Values()
+	assert.Equal(t, "4.20-main", values.Get("view"))
+	assert.Equal(t, "openshift-tests:abc", values.Get("testId"))
+	assert.Equal(t, []string{"Platform:aws", "Network:ovn"}, values["includeVariant"])
+	assert.Equal(t, "90", values.Get("confidence"))
+	assert.NotContains(t, values, "component")
+}
+
+func TestTriagesClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/component_readiness/triages":
+			assert.Equal(t, "2", r.URL.Query().Get("perPage"))
+			assert.Equal(t, "1", r.URL.Query().Get("page"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"rows":       []models.Triage{{ID: 3, Type: models.TriageTypeProduct}},
+				"page_size":  2,
+				"page":       1,
+				"total_rows": 3,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": http.StatusNotFound, "message": "triage not found"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewTriagesClient(sippyclient.New(sippyclient.WithServerURL(server.URL)))
+	ctx := context.Background()
+
+	page, err := client.ListPage(ctx, "", 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), page.TotalRows)
+	require.Len(t, page.Rows, 1)
+	assert.Equal(t, uint(3), page.Rows[0].ID)
+
+	_, err = client.Get(ctx, 42)
+	require.Error(t, err)
+	assert.True(t, sippyclient.IsNotFound(err))
+	var apiErr *sippyclient.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "triage not found", apiErr.Message)
+
+	_, err = client.Update(ctx, 1, models.Triage{ID: 2})
+	assert.Error(t, err)
+}
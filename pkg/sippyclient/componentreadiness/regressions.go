@@ -0,0 +1,100 @@
+package componentreadiness
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	api "github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+// RegressionQuery filters the regressions listed. View and Release cannot both be set.
+type RegressionQuery struct {
+	View    string
+	Release string
+	// Test is an exact test name.
+	Test string
+}
+
+func (q RegressionQuery) values() url.Values {
+	values := url.Values{}
+	if q.View != "" {
+		values.Set("view", q.View)
+	}
+	if q.Release != "" {
+		values.Set("release", q.Release)
+	}
+	if q.Test != "" {
+		values.Set("test", q.Test)
+	}
+	return values
+}
+
+// RegressionsClient provides methods for interacting with the component readiness regressions API
+type RegressionsClient struct {
+	client *sippyclient.Client
+}
+
+// NewRegressionsClient creates a new regressions client
+func NewRegressionsClient(client *sippyclient.Client) *RegressionsClient {
+	return &RegressionsClient{
+		client: client,
+	}
+}
+
+func regressionPath(id uint) string {
+	return fmt.Sprintf("/api/component_readiness/regressions/%d", id)
+}
+
+// List retrieves the regressions matching the query
+func (rc *RegressionsClient) List(ctx context.Context, query RegressionQuery) ([]models.TestRegression, error) {
+	if query.View != "" && query.Release != "" {
+		return nil, fmt.Errorf("cannot list regressions by both view and release")
+	}
+
+	var regressions []models.TestRegression
+	if err := rc.client.Get(ctx, pathWithQuery("/api/component_readiness/regressions", query.values()), &regressions); err != nil {
+		return nil, fmt.Errorf("failed to list regressions: %w", err)
+	}
+	return regressions, nil
+}
+
+// ListPage retrieves one page of the regressions matching the query, counting pages from zero.
+func (rc *RegressionsClient) ListPage(ctx context.Context, query RegressionQuery, perPage, page int) (*sippyclient.Page[models.TestRegression], error) {
+	if query.View != "" && query.Release != "" {
+		return nil, fmt.Errorf("cannot list regressions by both view and release")
+	}
+	if perPage <= 0 {
+		return nil, fmt.Errorf("invalid page size: %d", perPage)
+	}
+	values := query.values()
+	values.Set("perPage", strconv.Itoa(perPage))
+	values.Set("page", strconv.Itoa(page))
+
+	var result sippyclient.Page[models.TestRegression]
+	if err := rc.client.Get(ctx, pathWithQuery("/api/component_readiness/regressions", values), &result); err != nil {
+		return nil, fmt.Errorf("failed to list regressions: %w", err)
+	}
+	return &result, nil
+}
+
+// Get retrieves a single regression by ID
+func (rc *RegressionsClient) Get(ctx context.Context, id uint) (*models.TestRegression, error) {
+	var regression models.TestRegression
+	if err := rc.client.Get(ctx, regressionPath(id), &regression); err != nil {
+		return nil, fmt.Errorf("failed to get regression %d: %w", id, err)
+	}
+	return &regression, nil
+}
+
+// Matches retrieves the triages that potentially match the regression
+func (rc *RegressionsClient) Matches(ctx context.Context, id uint) ([]api.PotentialMatchingTriage, error) {
+	var matches []api.PotentialMatchingTriage
+	if err := rc.client.Get(ctx, regressionPath(id)+"/matches", &matches); err != nil {
+		return nil, fmt.Errorf("failed to get matches for regression %d: %w", id, err)
+	}
+	return matches, nil
+}
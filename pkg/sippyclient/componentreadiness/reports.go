@@ -0,0 +1,123 @@
+package componentreadiness
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+// ReportQuery selects a component readiness report. Typically only View is set, with the
+// other fields narrowing the report down to a component, capability or test.
+type ReportQuery struct {
+	View          string
+	BaseRelease   string
+	SampleRelease string
+	Component     string
+	Capability    string
+	TestID        string
+	// IncludeVariants and CompareVariants are given as name:value.
+	IncludeVariants     []string
+	CompareVariants     []string
+	VariantCrossCompare []string
+	// Extra holds any other report parameters, such as confidence or sampleStartTime.
+	Extra url.Values
+}
+
+// Values returns the query as URL parameters.
+func (q ReportQuery) Values() url.Values {
+	values := url.Values{}
+	for key, vals := range q.Extra {
+		values[key] = append([]string(nil), vals...)
+	}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("view", q.View)
+	set("baseRelease", q.BaseRelease)
+	set("sampleRelease", q.SampleRelease)
+	set("component", q.Component)
+	set("capability", q.Capability)
+	set("testId", q.TestID)
+	for _, v := range q.IncludeVariants {
+		values.Add("includeVariant", v)
+	}
+	for _, v := range q.CompareVariants {
+		values.Add("compareVariant", v)
+	}
+	for _, v := range q.VariantCrossCompare {
+		values.Add("variantCrossCompare", v)
+	}
+	return values
+}
+
+// pathWithQuery appends the encoded values to path, if there are any.
+func pathWithQuery(path string, values url.Values) string {
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
+
+// ViewsClient provides methods for interacting with the component readiness views API
+type ViewsClient struct {
+	client *sippyclient.Client
+}
+
+// NewViewsClient creates a new views client
+func NewViewsClient(client *sippyclient.Client) *ViewsClient {
+	return &ViewsClient{
+		client: client,
+	}
+}
+
+// List retrieves all component readiness views
+func (vc *ViewsClient) List(ctx context.Context) ([]crview.View, error) {
+	var views []crview.View
+	if err := vc.client.Get(ctx, "/api/component_readiness/views", &views); err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+	return views, nil
+}
+
+// ReportsClient provides methods for retrieving component readiness reports
+type ReportsClient struct {
+	client *sippyclient.Client
+}
+
+// NewReportsClient creates a new reports client
+func NewReportsClient(client *sippyclient.Client) *ReportsClient {
+	return &ReportsClient{
+		client: client,
+	}
+}
+
+// Get retrieves the component report for the query
+func (rc *ReportsClient) Get(ctx context.Context, query ReportQuery) (*componentreport.ComponentReport, error) {
+	var report componentreport.ComponentReport
+	path := pathWithQuery("/api/component_readiness", query.Values())
+	if err := rc.client.Get(ctx, path, &report); err != nil {
+		return nil, fmt.Errorf("failed to get component report: %w", err)
+	}
+	return &report, nil
+}
+
+// TestDetails retrieves the test details report for the query, which must identify a test
+func (rc *ReportsClient) TestDetails(ctx context.Context, query ReportQuery) (*testdetails.Report, error) {
+	if query.TestID == "" {
+		return nil, fmt.Errorf("test ID is required for test details")
+	}
+
+	var report testdetails.Report
+	path := pathWithQuery("/api/component_readiness/test_details", query.Values())
+	if err := rc.client.Get(ctx, path, &report); err != nil {
+		return nil, fmt.Errorf("failed to get test details for %s: %w", query.TestID, err)
+	}
+	return &report, nil
+}
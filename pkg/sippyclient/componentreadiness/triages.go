@@ -0,0 +1,123 @@
+package componentreadiness
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	api "github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+// TriagesClient provides methods for interacting with the component readiness triages API
+type TriagesClient struct {
+	client *sippyclient.Client
+}
+
+// NewTriagesClient creates a new triages client
+func NewTriagesClient(client *sippyclient.Client) *TriagesClient {
+	return &TriagesClient{
+		client: client,
+	}
+}
+
+func triagePath(id uint) string {
+	return fmt.Sprintf("/api/component_readiness/triages/%d", id)
+}
+
+// List retrieves all triages. If view is set, the triaged regressions link to it.
+func (tc *TriagesClient) List(ctx context.Context, view string) ([]models.Triage, error) {
+	values := url.Values{}
+	if view != "" {
+		values.Set("view", view)
+	}
+
+	var triages []models.Triage
+	if err := tc.client.Get(ctx, pathWithQuery("/api/component_readiness/triages", values), &triages); err != nil {
+		return nil, fmt.Errorf("failed to list triages: %w", err)
+	}
+	return triages, nil
+}
+
+// ListPage retrieves one page of triages, counting pages from zero.
+func (tc *TriagesClient) ListPage(ctx context.Context, view string, perPage, page int) (*sippyclient.Page[models.Triage], error) {
+	if perPage <= 0 {
+		return nil, fmt.Errorf("invalid page size: %d", perPage)
+	}
+	values := url.Values{}
+	if view != "" {
+		values.Set("view", view)
+	}
+	values.Set("perPage", strconv.Itoa(perPage))
+	values.Set("page", strconv.Itoa(page))
+
+	var result sippyclient.Page[models.Triage]
+	if err := tc.client.Get(ctx, pathWithQuery("/api/component_readiness/triages", values), &result); err != nil {
+		return nil, fmt.Errorf("failed to list triages: %w", err)
+	}
+	return &result, nil
+}
+
+// Get retrieves a single triage by ID
+func (tc *TriagesClient) Get(ctx context.Context, id uint) (*models.Triage, error) {
+	var triage models.Triage
+	if err := tc.client.Get(ctx, triagePath(id), &triage); err != nil {
+		return nil, fmt.Errorf("failed to get triage %d: %w", id, err)
+	}
+	return &triage, nil
+}
+
+// Create creates a new triage
+func (tc *TriagesClient) Create(ctx context.Context, triage models.Triage) (*models.Triage, error) {
+	var result models.Triage
+	if err := tc.client.Post(ctx, "/api/component_readiness/triages", triage, &result); err != nil {
+		return nil, fmt.Errorf("failed to create triage: %w", err)
+	}
+	return &result, nil
+}
+
+// Update updates an existing triage. The server requires the ID in the triage to match id.
+func (tc *TriagesClient) Update(ctx context.Context, id uint, triage models.Triage) (*models.Triage, error) {
+	if triage.ID != id {
+		return nil, fmt.Errorf("triage ID %d does not match update ID %d", triage.ID, id)
+	}
+
+	var result models.Triage
+	if err := tc.client.Put(ctx, triagePath(id), triage, &result); err != nil {
+		return nil, fmt.Errorf("failed to update triage %d: %w", id, err)
+	}
+	return &result, nil
+}
+
+// Delete deletes a triage by ID
+func (tc *TriagesClient) Delete(ctx context.Context, id uint) error {
+	if err := tc.client.Delete(ctx, triagePath(id)); err != nil {
+		return fmt.Errorf("failed to delete triage %d: %w", id, err)
+	}
+	return nil
+}
+
+// Matches retrieves the regressions in the view that potentially match the triage
+func (tc *TriagesClient) Matches(ctx context.Context, id uint, view string) ([]api.PotentialMatchingRegression, error) {
+	if view == "" {
+		return nil, fmt.Errorf("view is required to match regressions")
+	}
+
+	var matches []api.PotentialMatchingRegression
+	path := pathWithQuery(triagePath(id)+"/matches", url.Values{"view": {view}})
+	if err := tc.client.Get(ctx, path, &matches); err != nil {
+		return nil, fmt.Errorf("failed to get matches for triage %d: %w", id, err)
+	}
+	return matches, nil
+}
+
+// Audit retrieves the audit log of changes made to the triage
+func (tc *TriagesClient) Audit(ctx context.Context, id uint) ([]api.TriageAuditLog, error) {
+	var logs []api.TriageAuditLog
+	if err := tc.client.Get(ctx, triagePath(id)+"/audit", &logs); err != nil {
+		return nil, fmt.Errorf("failed to get audit log for triage %d: %w", id, err)
+	}
+	return logs, nil
+}
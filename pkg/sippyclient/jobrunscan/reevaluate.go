@@ -0,0 +1,36 @@
+package jobrunscan
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/openshift/sippy/pkg/api/jobrunscan"
+	"github.com/openshift/sippy/pkg/sippyclient"
+)
+
+// ReEvaluateClient provides methods for re-evaluating symptom matches on job runs
+type ReEvaluateClient struct {
+	client *sippyclient.Client
+}
+
+// NewReEvaluateClient creates a new re-evaluation client
+func NewReEvaluateClient(client *sippyclient.Client) *ReEvaluateClient {
+	return &ReEvaluateClient{
+		client: client,
+	}
+}
+
+// ReEvaluate re-evaluates symptom matches for the given job runs. With dryRun set the
+// results are reported without updating the job run labels.
+func (rc *ReEvaluateClient) ReEvaluate(ctx context.Context, prowJobBuildIDs []string, dryRun bool) (*api.ReEvaluationResponse, error) {
+	if err := api.ValidateReEvalRequest(prowJobBuildIDs); err != nil {
+		return nil, err
+	}
+
+	var result api.ReEvaluationResponse
+	body := api.ReEvaluationRequest{ProwJobBuildIDs: prowJobBuildIDs, DryRun: dryRun}
+	if err := rc.client.Post(ctx, "/api/jobs/runs/reevaluate", body, &result); err != nil {
+		return nil, fmt.Errorf("failed to re-evaluate job runs: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,99 @@
+package sippyserver
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/storage"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	bqprovider "github.com/openshift/sippy/pkg/api/componentreadiness/dataprovider/bigquery"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/cache"
+	"github.com/openshift/sippy/pkg/bigquery"
+	"github.com/openshift/sippy/pkg/bigquery/bqlabel"
+	"github.com/openshift/sippy/pkg/componentreadiness/jobrunannotator"
+	"github.com/openshift/sippy/pkg/db"
+)
+
+// AnnotationCampaignProcessor periodically runs the active job run annotation campaigns over the
+// job runs started since they last ran.
+type AnnotationCampaignProcessor struct {
+	dbc            *db.DB
+	bigQueryClient *bigquery.Client
+	runner         *jobrunannotator.CampaignRunner
+	interval       time.Duration
+	dryRunOnly     bool
+}
+
+// NewAnnotationCampaignProcessor creates an annotation campaign processor from parameters.
+// interval: the duration between runs of the active campaigns
+// settleTime: how long after a job run starts before it is scanned, so that its results are loaded
+// dryRunOnly: log the labels that would be written, without writing them or recording progress
+func NewAnnotationCampaignProcessor(dbc *db.DB, bigQueryClient *bigquery.Client, gcsClient *storage.Client, cacheClient cache.Cache, interval, settleTime time.Duration, dryRunOnly bool) *AnnotationCampaignProcessor {
+	return &AnnotationCampaignProcessor{
+		dbc:            dbc,
+		bigQueryClient: bigQueryClient,
+		runner:         jobrunannotator.NewCampaignRunner(bigQueryClient, cache.RequestOptions{}, gcsClient, dbc, cacheClient, !dryRunOnly, settleTime),
+		interval:       interval,
+		dryRunOnly:     dryRunOnly,
+	}
+}
+
+func (p *AnnotationCampaignProcessor) Run(ctx context.Context) {
+	if p.dryRunOnly {
+		log.Warning("Annotation campaign processor started in dry run only mode, job run labeling is disabled")
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.work(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Annotation campaign processor no longer active, shutting down")
+			return
+		case <-ticker.C:
+			p.work(ctx)
+		}
+	}
+}
+
+func (p *AnnotationCampaignProcessor) work(ctx context.Context) {
+	campaigns, err := jobrunannotator.ListActiveCampaigns(p.dbc.DB.WithContext(ctx))
+	if err != nil {
+		log.WithError(err).Error("error listing annotation campaigns")
+		return
+	}
+	if len(campaigns) == 0 {
+		return
+	}
+
+	allVariants, errs := componentreadiness.GetJobVariants(ctx, bqprovider.NewBigQueryProvider(p.bigQueryClient), reqopts.RequestOptions{})
+	if len(errs) > 0 {
+		log.WithField("errors", errs).Error("error getting job variants for annotation campaigns")
+		return
+	}
+
+	for _, campaign := range campaigns {
+		if ctx.Err() != nil {
+			return
+		}
+		// attribute the BigQuery usage of each campaign to whoever created it
+		campaignCtx := context.WithValue(ctx, bigquery.RequestContextKey, bqlabel.RequestContext{User: campaign.CreatedBy})
+		result, err := p.runner.Run(campaignCtx, campaign, allVariants)
+		logger := log.WithFields(log.Fields{
+			"campaign": campaign.ID,
+			"scanned":  result.Scanned,
+			"matched":  result.Matched,
+			"labeled":  result.Labeled,
+		})
+		if err != nil {
+			logger.WithError(err).Error("error running annotation campaign")
+			continue
+		}
+		logger.Info("ran annotation campaign")
+	}
+}
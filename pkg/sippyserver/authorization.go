@@ -0,0 +1,105 @@
+package sippyserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/sippyserver/authz"
+)
+
+// authorize checks write requests to the endpoint registered at route against the groups allowed
+// by the authorization config. Denied requests get a 403 and are recorded in the audit log.
+func (s *Server) authorize(route string, handler func(w http.ResponseWriter, req *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, groups, decision := s.checkAuthorization(req, route)
+		if decision.Allowed {
+			handler(w, req)
+			return
+		}
+
+		api.RespondWithJSON(http.StatusForbidden, w, map[string]interface{}{
+			"code":            http.StatusForbidden,
+			"errorType":       AuthorizationDenied,
+			"message":         deniedMessage(req.Method, route, user, decision),
+			"user":            user,
+			"groups":          groups,
+			"required_groups": decision.Groups,
+		})
+	}
+}
+
+// authorizeWrite applies the authorization of the endpoint registered at route to req, which MCP
+// tools build for the API functions they call. Denied calls are audited like denied API requests.
+func (s *Server) authorizeWrite(req *http.Request, route string) error {
+	if s.authorization == nil {
+		return nil
+	}
+	user, _, decision := s.checkAuthorization(req, route)
+	if !decision.Allowed {
+		return errors.New(deniedMessage(req.Method, route, user, decision))
+	}
+	return nil
+}
+
+// checkAuthorization decides whether the caller of req may call the endpoint registered at route,
+// and logs and audits the request when it is denied.
+func (s *Server) checkAuthorization(req *http.Request, route string) (string, []string, authz.Decision) {
+	user := api.GetUserForRequest(req)
+	groups := api.GetGroupsForRequest(req)
+	decision := s.authorization.Authorize(route, req.Method, user, groups)
+	if !decision.Allowed {
+		log.WithFields(log.Fields{
+			"user":   user,
+			"groups": groups,
+			"method": req.Method,
+			"path":   req.URL.Path,
+		}).Warn("denied unauthorized write request")
+		s.auditDenial(req, route, user, groups)
+	}
+	return user, groups, decision
+}
+
+func deniedMessage(method, route, user string, decision authz.Decision) string {
+	if user == "" {
+		return "authentication is required for this request"
+	}
+	return fmt.Sprintf("%s %s requires membership of one of the groups: %s", method, route, strings.Join(decision.Groups, ", "))
+}
+
+// auditDenial records a denied request in the audit log, keyed by the id in the request path if it has one.
+func (s *Server) auditDenial(req *http.Request, route, user string, groups []string) {
+	if s.db == nil {
+		return
+	}
+	details, err := json.Marshal(map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+		"route":  route,
+		"groups": groups,
+	})
+	if err != nil {
+		log.WithError(err).Error("error marshalling denied request for audit")
+		return
+	}
+	audit := models.AuditLog{
+		TableName: models.AuthorizationAuditTable,
+		Operation: string(models.Denied),
+		User:      user,
+		NewData:   details,
+	}
+	if id, err := strconv.ParseUint(mux.Vars(req)["id"], 10, 64); err == nil {
+		audit.RowID = uint(id)
+	}
+	if err := s.db.DB.WithContext(req.Context()).Create(&audit).Error; err != nil {
+		log.WithError(err).Error("error recording denied request in audit log")
+	}
+}
@@ -0,0 +1,65 @@
+package sippyserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/mcp/tools"
+	"github.com/openshift/sippy/pkg/sippyserver/authz"
+)
+
+func TestAuthorize(t *testing.T) {
+	s := &Server{authorization: &authz.Config{
+		Rules: []authz.Rule{{Path: "/api/jobs/symptoms/{id}", Groups: []string{"symptom-editors"}}},
+	}}
+	handler := s.authorize("/api/jobs/symptoms/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/jobs/symptoms/abc", nil)
+	req.Header.Set("X-Forwarded-User", "alice")
+	req.Header.Set("X-Forwarded-Groups", "viewers, symptom-editors")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/jobs/symptoms/abc", nil)
+	req.Header.Set("X-Forwarded-User", "bob")
+	req.Header.Set("X-Forwarded-Groups", "viewers")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	response := map[string]any{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, AuthorizationDenied, response["errorType"])
+	assert.Equal(t, []any{"symptom-editors"}, response["required_groups"])
+}
+
+func TestAuthorizeMCPCreateTriage(t *testing.T) {
+	s := &Server{authorization: &authz.Config{
+		Rules: []authz.Rule{{Path: "/api/component_readiness/triages", Methods: []string{http.MethodPost}, Groups: []string{"triagers"}}},
+	}}
+	tool := tools.NewCreateTriageTool(&tools.ToolDependencies{EnableWriteAPIs: true, AuthorizeWrite: s.authorizeWrite})
+
+	orig := httptest.NewRequest(http.MethodPost, "/mcp/v1/", nil)
+	orig.Header.Set("X-Forwarded-User", "bob")
+	orig.Header.Set("X-Forwarded-Groups", "viewers")
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "create_triage"
+	request.Params.Arguments = map[string]any{
+		"url":            "https://issues.redhat.com/browse/OCPBUGS-1",
+		"type":           "product",
+		"regression_ids": []any{1},
+	}
+
+	_, err := tool.GetHandler()(tools.ContextWithRequest(context.Background(), orig), request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "POST /api/component_readiness/triages requires membership of one of the groups: triagers")
+}
@@ -0,0 +1,133 @@
+package authz
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnyUser may be listed as a group to allow any authenticated user.
+const AnyUser = "*"
+
+// Config maps write endpoints to the groups allowed to call them.
+type Config struct {
+	// DefaultGroups may call write endpoints not matched by any rule. If empty, such
+	// endpoints remain open to anyone, as they are without an authorization config.
+	DefaultGroups []string `yaml:"default_groups,omitempty"`
+	Rules         []Rule   `yaml:"rules"`
+}
+
+// Rule allows the listed groups to call an endpoint.
+type Rule struct {
+	// Path is the route as registered with the router, e.g. /api/jobs/symptoms/{id}. A trailing
+	// * matches every route starting with the rest, so /api/jobs/symptoms* covers both the
+	// collection and its items.
+	Path string `yaml:"path"`
+	// Methods the rule applies to, all write methods if empty.
+	Methods []string `yaml:"methods,omitempty"`
+	Groups  []string `yaml:"groups"`
+}
+
+// Decision is the outcome of an authorization check.
+type Decision struct {
+	Allowed bool
+	// Groups that would have been allowed, for reporting in denials.
+	Groups []string
+}
+
+// LoadConfig reads and validates an authorization config file.
+func LoadConfig(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read authorization config from %s: %w", file, err)
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse authorization config from %s: %w", file, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid authorization config %s: %w", file, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		if r.Path == "" {
+			return fmt.Errorf("rule %d has no path", i)
+		}
+		if strings.Contains(strings.TrimSuffix(r.Path, "*"), "*") {
+			return fmt.Errorf("rule %d: invalid path %q, * is only allowed at the end", i, r.Path)
+		}
+		if len(r.Groups) == 0 {
+			return fmt.Errorf("rule %d (%s) has no groups", i, r.Path)
+		}
+		for j, m := range r.Methods {
+			r.Methods[j] = strings.ToUpper(m)
+			if !IsWriteMethod(r.Methods[j]) {
+				return fmt.Errorf("rule %d (%s): %s is not a write method", i, r.Path, m)
+			}
+		}
+	}
+	return nil
+}
+
+// IsWriteMethod reports whether requests with the method modify data, and so need authorizing.
+func IsWriteMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// Authorize decides whether a user in the given groups may call the endpoint registered at
+// route with method. Read requests are always allowed. All rules matching the route and method
+// are combined, so membership of any of their groups is enough.
+func (c *Config) Authorize(route, method, user string, groups []string) Decision {
+	if !IsWriteMethod(method) {
+		return Decision{Allowed: true}
+	}
+
+	var allowed []string
+	matched := false
+	for _, r := range c.Rules {
+		if r.matches(route, method) {
+			matched = true
+			allowed = append(allowed, r.Groups...)
+		}
+	}
+	if !matched {
+		if len(c.DefaultGroups) == 0 {
+			return Decision{Allowed: true}
+		}
+		allowed = c.DefaultGroups
+	}
+
+	decision := Decision{Groups: allowed}
+	if user == "" {
+		return decision
+	}
+	for _, g := range allowed {
+		if g == AnyUser || slices.Contains(groups, g) {
+			decision.Allowed = true
+			break
+		}
+	}
+	return decision
+}
+
+func (r *Rule) matches(route, method string) bool {
+	if len(r.Methods) > 0 && !slices.Contains(r.Methods, method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(route, prefix)
+	}
+	return r.Path == route
+}
@@ -0,0 +1,75 @@
+package authz
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+rules:
+  - path: /api/jobs/symptoms*
+    groups: [symptom-editors]
+  - path: /api/component_readiness/triages*
+    methods: [post, put]
+    groups: ["*"]
+  - path: /api/component_readiness/triages/{id}
+    methods: [DELETE]
+    groups: [triage-admins]
+`
+
+func loadTestConfig(t *testing.T, content string) (*Config, error) {
+	file := filepath.Join(t.TempDir(), "authorization.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return LoadConfig(file)
+}
+
+func TestAuthorize(t *testing.T) {
+	cfg, err := loadTestConfig(t, testConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		route   string
+		method  string
+		user    string
+		groups  []string
+		allowed bool
+	}{
+		{"reads are always allowed", "/api/jobs/symptoms", http.MethodGet, "", nil, true},
+		{"editor creates symptom", "/api/jobs/symptoms", http.MethodPost, "alice", []string{"symptom-editors"}, true},
+		{"editor deletes symptom", "/api/jobs/symptoms/{id}", http.MethodDelete, "alice", []string{"other", "symptom-editors"}, true},
+		{"non-editor deletes symptom", "/api/jobs/symptoms/{id}", http.MethodDelete, "bob", []string{"other"}, false},
+		{"any user updates triage", "/api/component_readiness/triages/{id}", http.MethodPut, "bob", nil, true},
+		{"anonymous updates triage", "/api/component_readiness/triages/{id}", http.MethodPut, "", nil, false},
+		{"non-admin deletes triage", "/api/component_readiness/triages/{id}", http.MethodDelete, "bob", nil, false},
+		{"admin deletes triage", "/api/component_readiness/triages/{id}", http.MethodDelete, "carol", []string{"triage-admins"}, true},
+		{"unmatched write is open", "/api/jobs/labels", http.MethodPost, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := cfg.Authorize(tt.route, tt.method, tt.user, tt.groups)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+		})
+	}
+
+	cfg.DefaultGroups = []string{"sippy-admins"}
+	decision := cfg.Authorize("/api/jobs/labels", http.MethodPost, "bob", nil)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, []string{"sippy-admins"}, decision.Groups)
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	_, err := loadTestConfig(t, "rules:\n  - path: /api/jobs/labels\n")
+	assert.ErrorContains(t, err, "has no groups")
+
+	_, err = loadTestConfig(t, "rules:\n  - path: /api/jobs/labels\n    methods: [GET]\n    groups: [a]\n")
+	assert.ErrorContains(t, err, "not a write method")
+
+	_, err = loadTestConfig(t, "rules:\n  - path: /api/*/labels\n    groups: [a]\n")
+	assert.ErrorContains(t, err, "only allowed at the end")
+}
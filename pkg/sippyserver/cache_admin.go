@@ -0,0 +1,254 @@
+package sippyserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api"
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
+	"github.com/openshift/sippy/pkg/apis/cache"
+	"github.com/openshift/sippy/pkg/util/param"
+)
+
+const defaultCacheEntryLimit = 100
+
+// cacheEntry is a cached item, as listed by the cache admin endpoints.
+type cacheEntry struct {
+	// Cache is "api" for the main cache of API responses and reports, or "persistent" for the
+	// BigQuery backed cache of component readiness reports, if enabled.
+	Cache string `json:"cache"`
+	Key   string `json:"key"`
+	// TTLSeconds is how long until the entry expires, zero if it does not.
+	TTLSeconds int `json:"ttl_seconds,omitempty"`
+
+	inspector cache.Inspector
+}
+
+type namedInspector struct {
+	name      string
+	inspector cache.Inspector
+}
+
+// inspectableCaches returns the configured caches that support listing and removing entries.
+func (s *Server) inspectableCaches() []namedInspector {
+	var caches []namedInspector
+	if inspector, ok := s.cache.(cache.Inspector); ok {
+		caches = append(caches, namedInspector{name: "api", inspector: inspector})
+	}
+	if s.bigQueryClient != nil && s.bigQueryClient.Cache != s.cache {
+		if inspector, ok := s.bigQueryClient.Cache.(cache.Inspector); ok {
+			caches = append(caches, namedInspector{name: "persistent", inspector: inspector})
+		}
+	}
+	return caches
+}
+
+// cacheEntryFilter selects cache entries by exact key, or by key prefix and view.
+type cacheEntryFilter struct {
+	prefix string
+	key    string
+	view   *crview.View
+}
+
+func (s *Server) parseCacheEntryFilter(req *http.Request) (cacheEntryFilter, error) {
+	filter := cacheEntryFilter{
+		prefix: req.URL.Query().Get("prefix"),
+		key:    req.URL.Query().Get("key"),
+	}
+	if viewName := param.SafeRead(req, "view"); viewName != "" {
+		idx := slices.IndexFunc(s.views.ComponentReadiness, func(v crview.View) bool { return v.Name == viewName })
+		if idx < 0 {
+			return filter, fmt.Errorf("view '%s' not found in views", viewName)
+		}
+		filter.view = &s.views.ComponentReadiness[idx]
+	}
+	return filter, nil
+}
+
+// findCacheEntries lists the entries matching filter in every inspectable cache. With withTTL their
+// remaining TTL is looked up too, from the listing itself for caches that support it.
+func (s *Server) findCacheEntries(ctx context.Context, filter cacheEntryFilter, withTTL bool) ([]cacheEntry, error) {
+	var entries []cacheEntry
+	for _, c := range s.inspectableCaches() {
+		var found []cacheEntry
+		needTTL := false
+		if filter.key != "" {
+			ttl, err := c.inspector.TTL(ctx, filter.key)
+			if errors.Is(err, cache.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			found = []cacheEntry{{Cache: c.name, Key: filter.key, TTLSeconds: int(ttl.Seconds())}}
+		} else if lister, ok := c.inspector.(cache.EntryLister); ok && withTTL {
+			listed, err := lister.Entries(ctx, filter.prefix)
+			if err != nil {
+				return nil, fmt.Errorf("error listing %s cache: %w", c.name, err)
+			}
+			for _, e := range listed {
+				found = append(found, cacheEntry{Cache: c.name, Key: e.Key, TTLSeconds: int(e.TTL.Seconds())})
+			}
+		} else {
+			keys, err := c.inspector.Keys(ctx, filter.prefix)
+			if err != nil {
+				return nil, fmt.Errorf("error listing %s cache: %w", c.name, err)
+			}
+			for _, k := range keys {
+				found = append(found, cacheEntry{Cache: c.name, Key: k})
+			}
+			needTTL = withTTL
+		}
+
+		for _, entry := range found {
+			if filter.view != nil && !cacheKeyMatchesView(entry.Key, *filter.view) {
+				continue
+			}
+			if needTTL {
+				ttl, err := c.inspector.TTL(ctx, entry.Key)
+				if errors.Is(err, cache.ErrNotFound) {
+					continue // expired since it was listed
+				} else if err != nil {
+					return nil, fmt.Errorf("error getting %s cache entry TTL: %w", c.name, err)
+				}
+				entry.TTLSeconds = int(ttl.Seconds())
+			}
+			entry.inspector = c.inspector
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// cacheKeyMatchesView reports whether key caches a component readiness or test details report for
+// the view. Reports for a view share its releases and included variants; other options may have
+// been changed by the user.
+func cacheKeyMatchesView(key string, view crview.View) bool {
+	var raw string
+	var ok bool
+	if raw, ok = strings.CutPrefix(key, componentreadiness.ComponentReportCacheKeyPrefix); !ok {
+		if raw, ok = strings.CutPrefix(key, componentreadiness.TestDetailsReportCacheKeyPrefix); !ok {
+			return false
+		}
+	}
+	cacheKey := componentreadiness.GeneratorCacheKey{}
+	if err := json.Unmarshal([]byte(raw), &cacheKey); err != nil {
+		return false
+	}
+	if cacheKey.BaseRelease.Name != view.BaseRelease.Name || cacheKey.SampleRelease.Name != view.SampleRelease.Name ||
+		cacheKey.SampleRelease.PullRequestOptions != nil || cacheKey.SampleRelease.PayloadOptions != nil {
+		return false
+	}
+	return sameVariants(cacheKey.VariantOption.IncludeVariants, view.VariantOptions.IncludeVariants)
+}
+
+func sameVariants(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, values := range a {
+		other, ok := b[name]
+		if !ok || len(values) != len(other) {
+			return false
+		}
+		for _, v := range values {
+			if !slices.Contains(other, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// jsonListCacheEntries lists cached entries for a view or key prefix, with their remaining TTL.
+func (s *Server) jsonListCacheEntries(w http.ResponseWriter, req *http.Request) {
+	if len(s.inspectableCaches()) == 0 {
+		failureResponse(w, http.StatusNotImplemented, "the configured caches do not support inspection")
+		return
+	}
+	limit := defaultCacheEntryLimit
+	if l := param.SafeRead(req, "limit"); l != "" {
+		limit, _ = strconv.Atoi(l)
+	}
+
+	filter, err := s.parseCacheEntryFilter(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	entries, err := s.findCacheEntries(req.Context(), filter, true)
+	if err != nil {
+		log.WithError(err).Error("error listing cache entries")
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	api.RespondWithJSON(http.StatusOK, w, entries)
+}
+
+// cachePurgeResult lists the entries removed by a purge.
+type cachePurgeResult struct {
+	Deleted []cacheEntry `json:"deleted"`
+	// Skipped are entries held by a read only cache, which keeps serving them until they expire.
+	Skipped []cacheEntry `json:"skipped,omitempty"`
+}
+
+// jsonDeleteCacheEntries purges the cached entries for a view, key prefix or key, returning the
+// entries removed and those a read only cache could not remove.
+func (s *Server) jsonDeleteCacheEntries(w http.ResponseWriter, req *http.Request) {
+	if len(s.inspectableCaches()) == 0 {
+		failureResponse(w, http.StatusNotImplemented, "the configured caches do not support inspection")
+		return
+	}
+	filter, err := s.parseCacheEntryFilter(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.prefix == "" && filter.key == "" && filter.view == nil {
+		failureResponse(w, http.StatusBadRequest, "one of prefix, view or key is required, to purge everything use prefix=*")
+		return
+	}
+	if filter.prefix == "*" {
+		filter.prefix = ""
+	}
+	entries, err := s.findCacheEntries(req.Context(), filter, false)
+	if err != nil {
+		log.WithError(err).Error("error listing cache entries")
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := cachePurgeResult{Deleted: make([]cacheEntry, 0, len(entries))}
+	for _, entry := range entries {
+		err := entry.inspector.Delete(req.Context(), entry.Key)
+		if errors.Is(err, cache.ErrReadOnly) {
+			result.Skipped = append(result.Skipped, entry)
+			continue
+		} else if err != nil {
+			log.WithError(err).WithField("key", entry.Key).Error("error deleting cache entry")
+			failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("deleted %d entries before failing to delete %s: %v", len(result.Deleted), entry.Key, err))
+			return
+		}
+		result.Deleted = append(result.Deleted, entry)
+	}
+	log.WithFields(log.Fields{
+		"user":    api.GetUserForRequest(req),
+		"query":   req.URL.RawQuery,
+		"deleted": len(result.Deleted),
+		"skipped": len(result.Skipped),
+	}).Info("purged cache entries")
+	api.RespondWithJSON(http.StatusOK, w, result)
+}
@@ -0,0 +1,163 @@
+package sippyserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/cache"
+	bqcachedclient "github.com/openshift/sippy/pkg/bigquery"
+)
+
+type inspectableMapCache map[string][]byte
+
+func (m inspectableMapCache) Get(_ context.Context, key string, _ time.Duration) ([]byte, error) {
+	return m[key], nil
+}
+
+func (m inspectableMapCache) Set(_ context.Context, key string, content []byte, _ time.Duration) error {
+	m[key] = content
+	return nil
+}
+
+func (m inspectableMapCache) Keys(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m inspectableMapCache) TTL(_ context.Context, key string) (time.Duration, error) {
+	if _, ok := m[key]; !ok {
+		return 0, cache.ErrNotFound
+	}
+	return time.Hour, nil
+}
+
+func (m inspectableMapCache) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+// readOnlyListerCache lists its entries with their TTL and refuses deletes, like a read only
+// persistent cache. Looking up TTLs one key at a time is an error, as each would be a query.
+type readOnlyListerCache struct {
+	inspectableMapCache
+}
+
+func (c readOnlyListerCache) Entries(_ context.Context, prefix string) ([]cache.Entry, error) {
+	keys, _ := c.Keys(context.Background(), prefix)
+	entries := make([]cache.Entry, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, cache.Entry{Key: key, TTL: 2 * time.Hour})
+	}
+	return entries, nil
+}
+
+func (c readOnlyListerCache) TTL(_ context.Context, key string) (time.Duration, error) {
+	return 0, fmt.Errorf("unexpected TTL lookup of %s", key)
+}
+
+func (c readOnlyListerCache) Delete(_ context.Context, key string) error {
+	return fmt.Errorf("cannot delete %s: %w", key, cache.ErrReadOnly)
+}
+
+func reportCacheKey(t *testing.T, prefix, base, sample string, variants map[string][]string) string {
+	raw, err := json.Marshal(componentreadiness.GeneratorCacheKey{
+		BaseRelease:   reqopts.Release{Name: base},
+		SampleRelease: reqopts.Release{Name: sample},
+		VariantOption: reqopts.Variants{IncludeVariants: variants},
+	})
+	require.NoError(t, err)
+	return prefix + string(raw)
+}
+
+func TestCacheEntries(t *testing.T) {
+	view := crview.View{
+		Name:           "4.20-main",
+		BaseRelease:    reqopts.RelativeRelease{Release: reqopts.Release{Name: "4.19"}},
+		SampleRelease:  reqopts.RelativeRelease{Release: reqopts.Release{Name: "4.20"}},
+		VariantOptions: reqopts.Variants{IncludeVariants: map[string][]string{"Platform": {"aws", "gcp"}}},
+	}
+	inViewReport := reportCacheKey(t, componentreadiness.ComponentReportCacheKeyPrefix, "4.19", "4.20", map[string][]string{"Platform": {"gcp", "aws"}})
+	inViewDetails := reportCacheKey(t, componentreadiness.TestDetailsReportCacheKeyPrefix, "4.19", "4.20", map[string][]string{"Platform": {"aws", "gcp"}})
+	otherVariants := reportCacheKey(t, componentreadiness.ComponentReportCacheKeyPrefix, "4.19", "4.20", map[string][]string{"Platform": {"aws"}})
+	c := inspectableMapCache{
+		inViewReport:     []byte("{}"),
+		inViewDetails:    []byte("{}"),
+		otherVariants:    []byte("{}"),
+		"/api/jobs?x=y":  []byte("[]"),
+		"/api/tests?x=y": []byte("[]"),
+	}
+	s := &Server{cache: c, views: &apitype.SippyViews{ComponentReadiness: []crview.View{view}}}
+
+	rec := httptest.NewRecorder()
+	s.jsonListCacheEntries(rec, httptest.NewRequest(http.MethodGet, "/api/cache/entries?view=4.20-main", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var listed []cacheEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed, 2)
+	assert.Equal(t, inViewReport, listed[0].Key)
+	assert.Equal(t, inViewDetails, listed[1].Key)
+	assert.Equal(t, 3600, listed[0].TTLSeconds)
+
+	rec = httptest.NewRecorder()
+	s.jsonDeleteCacheEntries(rec, httptest.NewRequest(http.MethodDelete, "/api/cache/entries", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "purging requires a filter")
+
+	rec = httptest.NewRecorder()
+	s.jsonDeleteCacheEntries(rec, httptest.NewRequest(http.MethodDelete, "/api/cache/entries?prefix=/api/jobs", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, c, "/api/jobs?x=y")
+	assert.Contains(t, c, "/api/tests?x=y")
+
+	rec = httptest.NewRecorder()
+	s.jsonDeleteCacheEntries(rec, httptest.NewRequest(http.MethodDelete, "/api/cache/entries?view=4.20-main", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, c, 2)
+	assert.Contains(t, c, otherVariants)
+
+	rec = httptest.NewRecorder()
+	s.jsonListCacheEntries(rec, httptest.NewRequest(http.MethodGet, "/api/cache/entries?view=unknown", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCacheEntriesReadOnlyPersistentCache(t *testing.T) {
+	c := inspectableMapCache{"/api/jobs?x=y": []byte("[]")}
+	persistent := readOnlyListerCache{inspectableMapCache{"/api/jobs?x=y": []byte("[]"), "/api/jobs?x=z": []byte("[]")}}
+	s := &Server{cache: c, bigQueryClient: &bqcachedclient.Client{Cache: persistent}}
+
+	rec := httptest.NewRecorder()
+	s.jsonListCacheEntries(rec, httptest.NewRequest(http.MethodGet, "/api/cache/entries?prefix=/api/jobs", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var listed []cacheEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed, 3)
+	assert.Equal(t, cacheEntry{Cache: "api", Key: "/api/jobs?x=y", TTLSeconds: 3600}, listed[0])
+	assert.Equal(t, cacheEntry{Cache: "persistent", Key: "/api/jobs?x=y", TTLSeconds: 7200}, listed[1])
+	assert.Equal(t, cacheEntry{Cache: "persistent", Key: "/api/jobs?x=z", TTLSeconds: 7200}, listed[2])
+
+	rec = httptest.NewRecorder()
+	s.jsonDeleteCacheEntries(rec, httptest.NewRequest(http.MethodDelete, "/api/cache/entries?prefix=/api/jobs", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var purged cachePurgeResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &purged))
+	assert.Equal(t, []cacheEntry{{Cache: "api", Key: "/api/jobs?x=y"}}, purged.Deleted)
+	assert.Equal(t, []cacheEntry{{Cache: "persistent", Key: "/api/jobs?x=y"}, {Cache: "persistent", Key: "/api/jobs?x=z"}}, purged.Skipped)
+	assert.Empty(t, c)
+}
@@ -1,7 +1,9 @@
 package sippyserver
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,13 +12,20 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgtype"
 	"github.com/openshift/sippy/pkg/api"
+	apitype "github.com/openshift/sippy/pkg/apis/api"
+	"github.com/openshift/sippy/pkg/db/chatconversation"
 	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/util/param"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
 	// MaxConversationSizeBytes is the maximum size of a conversation's messages in bytes (4MB)
 	MaxConversationSizeBytes = 4194304
+
+	// defaultConversationsPerPage and maxConversationsPerPage bound the pages of conversation listings
+	defaultConversationsPerPage = 25
+	maxConversationsPerPage     = 100
 )
 
 // CreateChatConversationRequest is the request payload for creating a new chat conversation
@@ -36,7 +45,7 @@ type ChatConversationResponse struct {
 
 // jsonCreateChatConversation handles POST requests to save a new chat conversation
 func (s *Server) jsonCreateChatConversation(w http.ResponseWriter, req *http.Request) {
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	if user == "" {
 		failureResponse(w, http.StatusUnauthorized, "User authentication required")
 		return
@@ -78,6 +87,10 @@ func (s *Server) jsonCreateChatConversation(w http.ResponseWriter, req *http.Req
 	// Marshal metadata to JSON if provided
 	var metadataJSONB pgtype.JSONB
 	if request.Metadata != nil {
+		if err := chatconversation.NormalizeMetadataTags(request.Metadata); err != nil {
+			failureResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		metadataJSON, err := json.Marshal(request.Metadata)
 		if err != nil {
 			chatLog.WithError(err).Error("error marshaling metadata")
@@ -111,14 +124,11 @@ func (s *Server) jsonCreateChatConversation(w http.ResponseWriter, req *http.Req
 		return
 	}
 
-	baseURL := api.GetBaseURL(req)
 	response := ChatConversationResponse{
 		ID:        conversation.ID,
 		CreatedAt: conversation.CreatedAt,
 		User:      conversation.User,
-		Links: map[string]string{
-			"self": fmt.Sprintf("%s/api/chat/conversations/%s", baseURL, conversation.ID.String()),
-		},
+		Links:     chatConversationLinks(api.GetBaseURL(req), conversation.ID, conversation.ParentID),
 	}
 
 	chatLog.WithFields(log.Fields{
@@ -151,13 +161,168 @@ func (s *Server) jsonGetChatConversation(w http.ResponseWriter, req *http.Reques
 	}
 
 	// Add HATEOAS links
+	conversation.Links = chatConversationLinks(api.GetBaseURL(req), conversation.ID, conversation.ParentID)
+
+	api.RespondWithJSON(http.StatusOK, w, conversation)
+}
+
+// chatConversationLinks are the HATEOAS links of a conversation.
+func chatConversationLinks(baseURL string, id uuid.UUID, parentID *uuid.UUID) map[string]string {
+	links := map[string]string{
+		"self":   fmt.Sprintf("%s/api/chat/conversations/%s", baseURL, id.String()),
+		"tags":   fmt.Sprintf("%s/api/chat/conversations/%s/tags", baseURL, id.String()),
+		"export": fmt.Sprintf("%s/api/chat/conversations/%s/export", baseURL, id.String()),
+	}
+	if parentID != nil {
+		links["parent"] = fmt.Sprintf("%s/api/chat/conversations/%s", baseURL, parentID.String())
+	}
+	return links
+}
+
+// jsonListChatConversations handles GET requests to list and search chat conversations, newest
+// first, or most relevant first when searching. Only the requesting user's conversations are listed
+// unless another user, or all users, are asked for.
+func (s *Server) jsonListChatConversations(w http.ResponseWriter, req *http.Request) {
+	user := param.SafeRead(req, "user")
+	if user == "" && req.URL.Query().Get("user") != "" {
+		failureResponse(w, http.StatusBadRequest, "Invalid user parameter")
+		return
+	}
+	allUsers, err := param.ReadBool(req, "all_users", false)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if allUsers && user != "" {
+		failureResponse(w, http.StatusBadRequest, "user and all_users cannot both be set")
+		return
+	}
+	if !allUsers && user == "" {
+		user = api.GetUserForRequest(req)
+		if user == "" {
+			failureResponse(w, http.StatusUnauthorized, "User authentication required, or set user or all_users=true")
+			return
+		}
+	}
+
+	pagination, err := getPaginationParams(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "Invalid pagination parameters: "+err.Error())
+		return
+	}
+	if pagination == nil {
+		pagination = &apitype.Pagination{PerPage: defaultConversationsPerPage}
+	}
+	if pagination.PerPage <= 0 || pagination.PerPage > maxConversationsPerPage || pagination.Page < 0 {
+		failureResponse(w, http.StatusBadRequest, fmt.Sprintf("perPage must be between 1 and %d, and page must not be negative", maxConversationsPerPage))
+		return
+	}
+
+	filter := chatconversation.Filter{
+		User:   user,
+		Tag:    param.SafeRead(req, "tag"),
+		Query:  param.SafeRead(req, "q"),
+		Limit:  pagination.PerPage,
+		Offset: pagination.Page * pagination.PerPage,
+	}
+	summaries, total, err := chatconversation.List(req.Context(), s.db.DB, filter)
+	if err != nil {
+		log.WithError(err).Error("error listing chat conversations")
+		failureResponse(w, http.StatusInternalServerError, "Failed to list conversations")
+		return
+	}
+
 	baseURL := api.GetBaseURL(req)
-	conversation.Links = map[string]string{
-		"self": fmt.Sprintf("%s/api/chat/conversations/%s", baseURL, conversation.ID.String()),
+	for i := range summaries {
+		summaries[i].Links = chatConversationLinks(baseURL, summaries[i].ID, summaries[i].ParentID)
 	}
-	if conversation.ParentID != nil {
-		conversation.Links["parent"] = fmt.Sprintf("%s/api/chat/conversations/%s", baseURL, conversation.ParentID.String())
+	api.RespondWithJSON(http.StatusOK, w, apitype.PaginationResult{
+		Rows:      summaries,
+		PageSize:  pagination.PerPage,
+		Page:      pagination.Page,
+		TotalRows: total,
+	})
+}
+
+// ChatConversationTags is the request and response payload for setting the tags of a chat conversation
+type ChatConversationTags struct {
+	Tags []string `json:"tags"`
+}
+
+// jsonSetChatConversationTags handles PUT requests to replace the tags of a chat conversation,
+// which only the user who shared it may do.
+func (s *Server) jsonSetChatConversationTags(w http.ResponseWriter, req *http.Request) {
+	user := api.GetUserForRequest(req)
+	if user == "" {
+		failureResponse(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+	conversationID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "Invalid conversation ID format")
+		return
 	}
 
-	api.RespondWithJSON(http.StatusOK, w, conversation)
+	var request ChatConversationTags
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		failureResponse(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return
+	}
+
+	if _, err := chatconversation.NormalizeTags(request.Tags); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tags, err := chatconversation.SetTags(req.Context(), s.db.DB, conversationID, user, request.Tags)
+	switch {
+	case errors.Is(err, chatconversation.ErrNotFound):
+		failureResponse(w, http.StatusNotFound, "Conversation not found")
+		return
+	case errors.Is(err, chatconversation.ErrNotOwner):
+		failureResponse(w, http.StatusForbidden, "Only the user who shared a conversation can tag it")
+		return
+	case err != nil:
+		log.WithError(err).WithField("conversationID", conversationID).Error("error setting chat conversation tags")
+		failureResponse(w, http.StatusInternalServerError, "Failed to update conversation tags")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"conversationID": conversationID,
+		"user":           user,
+		"tags":           tags,
+	}).Info("chat conversation tags updated")
+	api.RespondWithJSON(http.StatusOK, w, ChatConversationTags{Tags: tags})
+}
+
+// jsonExportChatConversation handles GET requests to download a chat conversation as markdown
+func (s *Server) jsonExportChatConversation(w http.ResponseWriter, req *http.Request) {
+	conversationID, err := uuid.Parse(mux.Vars(req)["id"])
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "Invalid conversation ID format")
+		return
+	}
+
+	var conversation models.ChatConversation
+	if err := s.db.DB.First(&conversation, "id = ?", conversationID).Error; err != nil {
+		log.WithError(err).WithField("conversationID", conversationID).Warn("conversation not found")
+		failureResponse(w, http.StatusNotFound, "Conversation not found")
+		return
+	}
+
+	// render fully first, so that an error can still be reported with a status code
+	var buf bytes.Buffer
+	if err := chatconversation.WriteMarkdown(&buf, conversation); err != nil {
+		log.WithError(err).WithField("conversationID", conversationID).Error("error exporting chat conversation")
+		failureResponse(w, http.StatusInternalServerError, "Failed to export conversation")
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "conversation-"+conversationID.String()+".md"))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Warn("error writing chat conversation export")
+	}
 }
@@ -0,0 +1,35 @@
+package sippyserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListChatConversationsParams(t *testing.T) {
+	s := &Server{}
+	tests := []struct {
+		name  string
+		query string
+		user  string
+		code  int
+	}{
+		{name: "anonymous without a user", query: "", code: http.StatusUnauthorized},
+		{name: "invalid user", query: "?user=a%20b", user: "jdoe", code: http.StatusBadRequest},
+		{name: "invalid all_users", query: "?all_users=yes", user: "jdoe", code: http.StatusBadRequest},
+		{name: "user with all_users", query: "?user=jdoe&all_users=true", user: "jdoe", code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/chat/conversations"+tt.query, nil)
+			if tt.user != "" {
+				req.Header.Set("X-Forwarded-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			s.jsonListChatConversations(rec, req)
+			assert.Equal(t, tt.code, rec.Code, rec.Body.String())
+		})
+	}
+}
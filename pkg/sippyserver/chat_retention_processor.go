@@ -0,0 +1,60 @@
+package sippyserver
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/chatconversation"
+)
+
+// ChatRetentionProcessor periodically soft-deletes shared chat conversations older than the
+// retention period. Soft-deleted conversations are no longer listed or returned by the API, but
+// remain in the database, so they can be restored by clearing deleted_at.
+type ChatRetentionProcessor struct {
+	dbc       *db.DB
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewChatRetentionProcessor creates a chat retention processor from parameters.
+// retention: how long after a conversation is shared before it is soft-deleted
+// interval: the duration between checks for expired conversations
+func NewChatRetentionProcessor(dbc *db.DB, retention, interval time.Duration) *ChatRetentionProcessor {
+	return &ChatRetentionProcessor{
+		dbc:       dbc,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+func (p *ChatRetentionProcessor) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.work(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Chat retention processor no longer active, shutting down")
+			return
+		case <-ticker.C:
+			p.work(ctx)
+		}
+	}
+}
+
+func (p *ChatRetentionProcessor) work(ctx context.Context) {
+	cutoff := time.Now().Add(-p.retention)
+	deleted, err := chatconversation.SoftDeleteCreatedBefore(ctx, p.dbc.DB, cutoff)
+	if err != nil {
+		log.WithError(err).Error("error deleting expired chat conversations")
+		return
+	}
+	log.WithFields(log.Fields{
+		"cutoff":  cutoff.Format(time.RFC3339),
+		"deleted": deleted,
+	}).Info("deleted expired chat conversations")
+}
@@ -0,0 +1,59 @@
+package sippyserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/api/componentreadiness/export"
+)
+
+// exportFormat returns the export format requested, or an empty string for JSON.
+func exportFormat(req *http.Request) (string, error) {
+	format := req.URL.Query().Get("format")
+	if format == "" || format == "json" {
+		return "", nil
+	}
+	if !slices.Contains(export.Formats, format) {
+		return "", fmt.Errorf("unsupported format %q, expected json, %s", format, strings.Join(export.Formats, ", "))
+	}
+	return format, nil
+}
+
+// respondWithExport writes the rows as a download named after name.
+func respondWithExport(w http.ResponseWriter, format, name string, rows []export.Row) {
+	// render fully first, so that an error can still be reported with a status code
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, rows); err != nil {
+		log.WithError(err).Error("error exporting component readiness")
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Warn("error writing component readiness export")
+	}
+}
+
+// exportName names an export after the view or release it covers.
+func exportName(prefix string, parts ...string) string {
+	name := prefix
+	for _, p := range parts {
+		if p != "" {
+			name += "-" + p
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -2,11 +2,14 @@ package sippyserver
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/openshift/sippy/pkg/api"
 	apijobrunscan "github.com/openshift/sippy/pkg/api/jobrunscan"
+	"github.com/openshift/sippy/pkg/db/infrafailure"
 	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
 	log "github.com/sirupsen/logrus"
 )
@@ -39,7 +42,7 @@ func (s *Server) jsonGetLabel(w http.ResponseWriter, req *http.Request) {
 }
 
 func (s *Server) jsonCreateLabel(w http.ResponseWriter, req *http.Request) {
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("label POST")
 	var label jobrunscan.Label
 	if err := json.NewDecoder(req.Body).Decode(&label); err != nil {
@@ -58,7 +61,7 @@ func (s *Server) jsonCreateLabel(w http.ResponseWriter, req *http.Request) {
 func (s *Server) jsonUpdateLabel(w http.ResponseWriter, req *http.Request) {
 	id := mux.Vars(req)["id"]
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("label PUT")
 	var label jobrunscan.Label
 	if err := json.NewDecoder(req.Body).Decode(&label); err != nil {
@@ -82,7 +85,7 @@ func (s *Server) jsonUpdateLabel(w http.ResponseWriter, req *http.Request) {
 func (s *Server) jsonDeleteLabel(w http.ResponseWriter, req *http.Request) {
 	id := mux.Vars(req)["id"]
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("label DELETE")
 	if err := apijobrunscan.DeleteLabel(s.db.DB, id, user); err != nil {
 		failureResponse(w, http.StatusInternalServerError, err.Error())
@@ -119,7 +122,7 @@ func (s *Server) jsonGetSymptom(w http.ResponseWriter, req *http.Request) {
 }
 
 func (s *Server) jsonCreateSymptom(w http.ResponseWriter, req *http.Request) {
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("symptom POST")
 	var symptom jobrunscan.Symptom
 	if err := json.NewDecoder(req.Body).Decode(&symptom); err != nil {
@@ -138,7 +141,7 @@ func (s *Server) jsonCreateSymptom(w http.ResponseWriter, req *http.Request) {
 func (s *Server) jsonUpdateSymptom(w http.ResponseWriter, req *http.Request) {
 	id := mux.Vars(req)["id"]
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("symptom PUT")
 	var symptom jobrunscan.Symptom
 	if err := json.NewDecoder(req.Body).Decode(&symptom); err != nil {
@@ -162,7 +165,7 @@ func (s *Server) jsonUpdateSymptom(w http.ResponseWriter, req *http.Request) {
 func (s *Server) jsonDeleteSymptom(w http.ResponseWriter, req *http.Request) {
 	id := mux.Vars(req)["id"]
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.WithField("user", user).Info("symptom DELETE")
 	if err := apijobrunscan.DeleteSymptom(s.db.DB, id, user); err != nil {
 		failureResponse(w, http.StatusInternalServerError, err.Error())
@@ -174,12 +177,9 @@ func (s *Server) jsonDeleteSymptom(w http.ResponseWriter, req *http.Request) {
 // Job run symptom re-evaluation handler
 
 func (s *Server) jsonReEvaluateJobRunSymptoms(w http.ResponseWriter, req *http.Request) {
-	log.WithField("user", getUserForRequest(req)).Info("symptom re-evaluation POST")
+	log.WithField("user", api.GetUserForRequest(req)).Info("symptom re-evaluation POST")
 
-	var body struct {
-		ProwJobBuildIDs []string `json:"prow_job_build_ids"`
-		DryRun          bool     `json:"dry_run"`
-	}
+	var body apijobrunscan.ReEvaluationRequest
 	req.Body = http.MaxBytesReader(w, req.Body, 1<<20) // 1 MiB limit to prevent DoS
 	dec := json.NewDecoder(req.Body)
 	dec.DisallowUnknownFields() // catch client errors faster
@@ -208,3 +208,115 @@ func (s *Server) jsonReEvaluateJobRunSymptoms(w http.ResponseWriter, req *http.R
 	apijobrunscan.InjectReEvalHATEOASLinks(&resp, api.GetBaseURL(req))
 	api.RespondWithJSON(http.StatusOK, w, resp)
 }
+
+// InfraFailure handlers
+
+func (s *Server) jsonGetInfraFailure(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid job run ID: "+mux.Vars(req)["id"])
+		return
+	}
+	status, err := infrafailure.GetStatus(req.Context(), s.db.DB, id)
+	if errors.Is(err, infrafailure.ErrRunNotFound) {
+		failureResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, status)
+}
+
+// jsonSetInfraFailure marks (PUT) or unmarks (DELETE) a job run as an infrastructure failure,
+// removing it from or adding it back to test pass rates.
+func (s *Server) jsonSetInfraFailure(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid job run ID: "+mux.Vars(req)["id"])
+		return
+	}
+	user := api.GetUserForRequest(req)
+	infraFailure := req.Method == http.MethodPut
+	log.WithFields(log.Fields{"user": user, "prowJobRunID": id, "infraFailure": infraFailure}).Info("infra failure " + req.Method)
+
+	change, err := infrafailure.SetInfraFailure(req.Context(), s.db.DB, id, infraFailure, user, req.URL.Query().Get("reason"))
+	if errors.Is(err, infrafailure.ErrRunNotFound) {
+		failureResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		log.WithError(err).WithField("prowJobRunID", id).Error("error setting InfraFailure")
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, change)
+}
+
+// Annotation campaign handlers
+
+func (s *Server) jsonListAnnotationCampaigns(w http.ResponseWriter, req *http.Request) {
+	campaigns, err := apijobrunscan.ListCampaigns(s.db, req)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, campaigns)
+}
+
+func (s *Server) jsonGetAnnotationCampaign(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(req)["id"])
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid annotation campaign ID: "+mux.Vars(req)["id"])
+		return
+	}
+
+	campaign, err := apijobrunscan.GetCampaign(s.db, id, req)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if campaign == nil {
+		failureResponse(w, http.StatusNotFound, "annotation campaign not found")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, campaign)
+}
+
+func (s *Server) jsonCreateAnnotationCampaign(w http.ResponseWriter, req *http.Request) {
+	user := api.GetUserForRequest(req)
+	log.WithField("user", user).Info("annotation campaign POST")
+	var campaign jobrunscan.AnnotationCampaign
+	if err := json.NewDecoder(req.Body).Decode(&campaign); err != nil {
+		log.WithError(err).Error("error parsing new annotation campaign")
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	campaign, err := apijobrunscan.CreateCampaign(s.db.DB, campaign, user, req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusCreated, w, campaign)
+}
+
+// jsonSetAnnotationCampaignPaused pauses (PUT) or resumes (DELETE) an annotation campaign.
+func (s *Server) jsonSetAnnotationCampaignPaused(w http.ResponseWriter, req *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(req)["id"])
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid annotation campaign ID: "+mux.Vars(req)["id"])
+		return
+	}
+	user := api.GetUserForRequest(req)
+	log.WithField("user", user).Info("annotation campaign paused " + req.Method)
+
+	campaign, err := apijobrunscan.SetCampaignPaused(s.db.DB, id, req.Method == http.MethodPut, user, req)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if campaign == nil {
+		failureResponse(w, http.StatusNotFound, "annotation campaign not found")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, campaign)
+}
@@ -0,0 +1,466 @@
+package sippyserver
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/invopop/jsonschema"
+
+	"github.com/openshift/sippy/pkg/version"
+)
+
+const openAPIVersion = "3.1.0"
+
+// apiParam documents a query or path parameter of an endpoint. Path parameters are taken from
+// the endpoint path, so only query parameters need to be listed in the endpoint table.
+type apiParam struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	// Type is string, integer, boolean, or array for a string parameter that may be repeated.
+	Type string `json:"type"`
+}
+
+func queryParam(name, description string) apiParam {
+	return apiParam{Name: name, In: "query", Description: description, Type: "string"}
+}
+
+func (p apiParam) required() apiParam {
+	p.Required = true
+	return p
+}
+
+func (p apiParam) integer() apiParam {
+	p.Type = "integer"
+	return p
+}
+
+func (p apiParam) boolean() apiParam {
+	p.Type = "boolean"
+	return p
+}
+
+// repeated marks a parameter that may be given more than once, e.g. includeVariant=a&includeVariant=b.
+func (p apiParam) repeated() apiParam {
+	p.Type = "array"
+	return p
+}
+
+// paramList concatenates groups of parameters, so the common groups below can be combined
+// with endpoint specific ones.
+func paramList(groups ...[]apiParam) []apiParam {
+	var params []apiParam
+	for _, g := range groups {
+		params = append(params, g...)
+	}
+	return params
+}
+
+var (
+	releaseParams = []apiParam{
+		queryParam("release", "Release to report on, e.g. 4.20").required(),
+	}
+	optionalReleaseParams = []apiParam{
+		queryParam("release", "Release to report on, e.g. 4.20"),
+	}
+	// filterParams are read by filter.FilterOptionsFromRequest and filter.ExtractFilters.
+	filterParams = []apiParam{
+		queryParam("filter", `JSON encoded filter, e.g. {"items":[{"columnField":"name","operatorValue":"contains","value":"aws"}],"linkOperator":"and"}`),
+		queryParam("sortField", "Field to sort by"),
+		queryParam("sort", "Sort direction, asc or desc"),
+		queryParam("limit", "Maximum number of results").integer(),
+	}
+	paginationParams = []apiParam{
+		queryParam("perPage", "Results per page").integer(),
+		queryParam("page", "Page number, starting at 0").integer(),
+	}
+	// listPaginationParams page endpoints that return a plain list when they are not set.
+	listPaginationParams = []apiParam{
+		queryParam("perPage", "Results per page. When set the response is a page of results: {rows, page_size, page, total_rows}").integer(),
+		queryParam("page", "Page number, starting at 0").integer(),
+	}
+	// periodParams are read by getPeriodDates. start, boundary and end are only used when all three are set.
+	periodParams = []apiParam{
+		queryParam("period", "Reporting period, e.g. default or twoDay"),
+		queryParam("start", "Start date of the reporting period, YYYY-MM-DD"),
+		queryParam("boundary", "Date separating the previous and current periods, YYYY-MM-DD"),
+		queryParam("end", "End date of the reporting period, YYYY-MM-DD"),
+	}
+	iso8601RangeParams = []apiParam{
+		queryParam("start", "Start time, e.g. 2024-01-02T15:04:05Z"),
+		queryParam("end", "End time, e.g. 2024-01-02T15:04:05Z"),
+	}
+	// testReportParams are read by makeTestsResultsSpec.
+	testReportParams = []apiParam{
+		queryParam("period", "Reporting period: default, current or twoDay"),
+		queryParam("collapse", "Aggregate the results of all variants, defaults to true").boolean(),
+		queryParam("overall", "Include an overall result when not collapsed").boolean(),
+	}
+	testParams = []apiParam{
+		queryParam("test", "Test name").required(),
+	}
+	jobRunIDParams = []apiParam{
+		queryParam("prow_job_run_id", "Prow job run ID").required(),
+	}
+	// jobRunParams are read by handlers that fall back to reading a job run's artifacts directly
+	jobRunParams = append([]apiParam{
+		queryParam("job_name", "Prow job name, used to find the run's artifacts when it is not in the database"),
+		queryParam("repo_info", "org_repo of a presubmit job run, used with job_name"),
+		queryParam("pull_number", "Pull request number of a presubmit job run, used with job_name"),
+	}, jobRunIDParams...)
+	// exportParams select a download of the regressed tests instead of JSON, see exportFormat.
+	exportParams = []apiParam{
+		queryParam("format", "Export regressed tests as csv, md or xlsx rather than JSON"),
+	}
+	// componentReportParams are read by utils.ParseComponentReportRequest. A view provides defaults
+	// for everything else.
+	componentReportParams = []apiParam{
+		queryParam("view", "Component readiness view to base the request on"),
+		queryParam("baseRelease", "Basis release"),
+		queryParam("sampleRelease", "Sample release"),
+		queryParam("baseStartTime", "Start of the basis, RFC 3339"),
+		queryParam("baseEndTime", "End of the basis, RFC 3339"),
+		queryParam("sampleStartTime", "Start of the sample, RFC 3339"),
+		queryParam("sampleEndTime", "End of the sample, RFC 3339"),
+		queryParam("samplePROrg", "Org of a pull request to use as the sample"),
+		queryParam("samplePRRepo", "Repo of a pull request to use as the sample"),
+		queryParam("samplePRNumber", "Number of a pull request to use as the sample"),
+		queryParam("samplePayloadTag", "Payload to use as the sample").repeated(),
+		queryParam("testBasisRelease", "Release to use as the basis for tests with no basis data"),
+		queryParam("columnGroupBy", "Comma separated variants to group report columns by"),
+		queryParam("dbGroupBy", "Comma separated variants to group test results by"),
+		queryParam("includeVariant", "Variant to include, as name:value").repeated(),
+		queryParam("compareVariant", "Variant to compare against when cross comparing, as name:value").repeated(),
+		queryParam("variantCrossCompare", "Variant name to cross compare").repeated(),
+		queryParam("confidence", "Confidence level, 0-100").integer(),
+		queryParam("pity", "Pass rate difference to tolerate, 0-100").integer(),
+		queryParam("minFail", "Minimum failures before reporting a regression").integer(),
+		queryParam("passRateNewTests", "Required pass rate for tests with no basis, 0-100").integer(),
+		queryParam("passRateAllTests", "Required pass rate for all tests, 0-100").integer(),
+		queryParam("ignoreMissing", "Ignore tests missing from the sample").boolean(),
+		queryParam("ignoreDisruption", "Ignore disruption tests").boolean(),
+		queryParam("flakeAsFailure", "Count flakes as failures").boolean(),
+		queryParam("includeMultiReleaseAnalysis", "Fall back to earlier releases for the basis").boolean(),
+		queryParam("component", "Component to report on"),
+		queryParam("capability", "Capability to report on"),
+		queryParam("testId", "Test to report on"),
+		queryParam("testCapabilities", "Only include tests with this capability").repeated(),
+		queryParam("testLifecycles", "Only include tests with this lifecycle").repeated(),
+		queryParam("keyTestName", "Test whose failure excludes a job run from the report").repeated(),
+		queryParam("analyzer", "Analyzer to use for test details"),
+		queryParam("dataSource", "Data source to query, when several are configured"),
+	}
+	cacheEntryParams = []apiParam{
+		queryParam("prefix", "Only entries whose key starts with this prefix, e.g. ComponentReport~"),
+		queryParam("view", "Only component readiness and test details reports for this view"),
+		queryParam("key", "The entry with exactly this key"),
+	}
+)
+
+// noContent is the Response of endpoints that do not return a body.
+type noContent struct{}
+
+// externalResponse is the Response of endpoints whose responses are defined elsewhere, such as the
+// chat proxy, which forwards to the sippy-chat service, and MCP.
+type externalResponse struct{}
+
+// markdownResponse is the Response of endpoints that return a markdown document.
+type markdownResponse struct{}
+
+// paginated documents the Rows of an apitype.PaginationResult, which are otherwise untyped.
+type paginated[T any] struct {
+	Rows      []T   `json:"rows"`
+	PageSize  int   `json:"page_size"`
+	Page      int   `json:"page"`
+	TotalRows int64 `json:"total_rows"`
+}
+
+// apiError is the body written by failureResponse and typedFailureResponse.
+type apiError struct {
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	ErrorType  string `json:"errorType,omitempty"`
+	ErrorParam string `json:"errorParam,omitempty"`
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas   map[string]json.RawMessage `json:"schemas"`
+	Responses map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	// Capabilities are the server capabilities the endpoint requires, see hasCapabilities.
+	Capabilities []string `json:"x-required-capabilities,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name        string          `json:"name"`
+	In          string          `json:"in"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref         string                      `json:"$ref,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema json.RawMessage `json:"schema"`
+}
+
+var (
+	pathParamRegexp   = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+	schemaNameRegexp  = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	typeArgPathRegexp = regexp.MustCompile(`[A-Za-z0-9._-]+/`)
+	civilDateType     = reflect.TypeOf(civil.Date{})
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// openAPIGenerator reflects the request and response types of endpoints into JSON schemas,
+// collecting the named types they use as shared component schemas.
+type openAPIGenerator struct {
+	reflector *jsonschema.Reflector
+	names     map[reflect.Type]string
+	types     map[string]reflect.Type
+	schemas   map[string]json.RawMessage
+}
+
+func newOpenAPIGenerator() *openAPIGenerator {
+	g := &openAPIGenerator{
+		names:   map[reflect.Type]string{},
+		types:   map[string]reflect.Type{},
+		schemas: map[string]json.RawMessage{},
+	}
+	g.reflector = &jsonschema.Reflector{
+		Anonymous:                 true,
+		AllowAdditionalProperties: true,
+		Namer:                     g.schemaName,
+		Mapper:                    mapOpenAPIType,
+	}
+	return g
+}
+
+// schemaName names the component schema of a type after its package and type name, using more of
+// the package path when two packages share a name. Type arguments of generic types are named by
+// their package and type name too.
+func (g *openAPIGenerator) schemaName(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return ""
+	}
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	typeName := strings.TrimSuffix(schemaNameRegexp.ReplaceAllString(typeArgPathRegexp.ReplaceAllString(t.Name(), ""), "_"), "_")
+	parts := strings.Split(t.PkgPath(), "/")
+	name := ""
+	for i := len(parts) - 1; i >= 0; i-- {
+		name = strings.Join(parts[i:], "_") + "." + typeName
+		if _, taken := g.types[name]; !taken {
+			break
+		}
+	}
+	g.names[t] = name
+	g.types[name] = t
+	return name
+}
+
+// mapOpenAPIType describes types whose JSON encoding differs from their Go structure.
+func mapOpenAPIType(t reflect.Type) *jsonschema.Schema {
+	implements := func(iface reflect.Type) bool {
+		return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+	}
+	switch {
+	case t == timeType:
+		return &jsonschema.Schema{Type: "string", Format: "date-time"}
+	case t == civilDateType:
+		return &jsonschema.Schema{Type: "string", Format: "date"}
+	case implements(jsonMarshalerType):
+		// custom JSON encodings can't be reflected, so allow any value
+		return jsonschema.TrueSchema
+	case implements(textMarshalerType):
+		return &jsonschema.Schema{Type: "string"}
+	}
+	return nil
+}
+
+// schema returns the JSON schema of v, adding the named types it uses to the component schemas.
+func (g *openAPIGenerator) schema(v any) (json.RawMessage, error) {
+	s := g.reflector.Reflect(v)
+	for name, def := range s.Definitions {
+		if _, ok := g.schemas[name]; ok {
+			continue
+		}
+		raw, err := marshalSchema(def)
+		if err != nil {
+			return nil, err
+		}
+		g.schemas[name] = raw
+	}
+	s.Definitions = nil
+	s.Version = ""
+	return marshalSchema(s)
+}
+
+func marshalSchema(s *jsonschema.Schema) (json.RawMessage, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(strings.ReplaceAll(string(raw), `"#/$defs/`, `"#/components/schemas/`)), nil
+}
+
+func (g *openAPIGenerator) operation(ep apiEndpoints) (openAPIOperation, error) {
+	op := openAPIOperation{
+		Summary:      ep.Description,
+		Capabilities: ep.Capabilities,
+		Responses: map[string]openAPIResponse{
+			"default": {Ref: "#/components/responses/Error"},
+		},
+	}
+
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(ep.EndpointPath, -1) {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   json.RawMessage(`{"type":"string"}`),
+		})
+	}
+	for _, p := range ep.Params {
+		schema := `{"type":"` + p.Type + `"}`
+		if p.Type == "array" {
+			schema = `{"type":"array","items":{"type":"string"}}`
+		}
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      json.RawMessage(schema),
+		})
+	}
+
+	if ep.RequestBody != nil {
+		schema, err := g.schema(ep.RequestBody)
+		if err != nil {
+			return op, err
+		}
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: schema}},
+		}
+	}
+
+	status := ep.ResponseStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	switch ep.Response.(type) {
+	case noContent:
+		op.Responses[strconv.Itoa(status)] = openAPIResponse{Description: "Success, with no content"}
+	case externalResponse:
+		op.Responses[strconv.Itoa(status)] = openAPIResponse{
+			Description: "Response of the service the request is forwarded to",
+			Content:     map[string]openAPIMediaType{"*/*": {Schema: json.RawMessage("true")}},
+		}
+	case markdownResponse:
+		op.Responses[strconv.Itoa(status)] = openAPIResponse{
+			Description: http.StatusText(status),
+			Content:     map[string]openAPIMediaType{"text/markdown": {Schema: json.RawMessage(`{"type":"string"}`)}},
+		}
+	default:
+		schema, err := g.schema(ep.Response)
+		if err != nil {
+			return op, err
+		}
+		op.Responses[strconv.Itoa(status)] = openAPIResponse{
+			Description: http.StatusText(status),
+			Content:     map[string]openAPIMediaType{"application/json": {Schema: schema}},
+		}
+	}
+	return op, nil
+}
+
+// generateOpenAPI builds an OpenAPI document for the endpoints. Endpoints that don't restrict their
+// methods are documented as GET, or POST when they take a request body.
+func generateOpenAPI(endpoints []apiEndpoints) (*openAPIDocument, error) {
+	g := newOpenAPIGenerator()
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: "Sippy API", Version: version.Get().GitCommit},
+		Paths:   map[string]map[string]openAPIOperation{},
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "dev"
+	}
+
+	for _, ep := range endpoints {
+		op, err := g.operation(ep)
+		if err != nil {
+			return nil, err
+		}
+		methods := ep.Methods
+		if len(methods) == 0 {
+			methods = []string{http.MethodGet}
+			if ep.RequestBody != nil {
+				methods = []string{http.MethodPost}
+			}
+		}
+		path := pathParamRegexp.ReplaceAllString(ep.EndpointPath, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]openAPIOperation{}
+		}
+		for _, m := range methods {
+			doc.Paths[path][strings.ToLower(m)] = op
+		}
+	}
+
+	errorSchema, err := g.schema(apiError{})
+	if err != nil {
+		return nil, err
+	}
+	doc.Components = openAPIComponents{
+		Schemas: g.schemas,
+		Responses: map[string]openAPIResponse{
+			"Error": {
+				Description: "Error",
+				Content:     map[string]openAPIMediaType{"application/json": {Schema: errorSchema}},
+			},
+		},
+	}
+	return doc, nil
+}
@@ -0,0 +1,73 @@
+package sippyserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsHaveSchemas(t *testing.T) {
+	s := &Server{}
+	for _, ep := range s.apiEndpointTable(http.NotFound) {
+		assert.NotNil(t, ep.Response, "%s %s has no Response type; set one so it is documented in the OpenAPI spec",
+			strings.Join(ep.Methods, ","), ep.EndpointPath)
+		for _, p := range ep.Params {
+			assert.Equal(t, "query", p.In, "%s parameter %s", ep.EndpointPath, p.Name)
+		}
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	s := &Server{}
+	endpoints := s.apiEndpointTable(http.NotFound)
+	doc, err := generateOpenAPI(endpoints)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	spec := map[string]any{}
+	require.NoError(t, json.Unmarshal(raw, &spec))
+	assert.Equal(t, openAPIVersion, spec["openapi"])
+
+	labels := doc.Paths["/api/jobs/labels/{id}"]
+	require.Contains(t, labels, "get")
+	require.Contains(t, labels, "put")
+	require.Contains(t, labels, "delete")
+	assert.Equal(t, "id", labels["get"].Parameters[0].Name)
+	assert.Equal(t, "path", labels["get"].Parameters[0].In)
+	assert.NotNil(t, labels["put"].RequestBody)
+	assert.Contains(t, labels["delete"].Responses, "204")
+
+	jobs := doc.Paths["/api/jobs"]["get"]
+	assert.Contains(t, jobs.Responses, "200")
+	var release *openAPIParameter
+	for i := range jobs.Parameters {
+		if jobs.Parameters[i].Name == "release" {
+			release = &jobs.Parameters[i]
+		}
+	}
+	require.NotNil(t, release)
+	assert.True(t, release.Required)
+
+	// every reference must resolve to a component schema
+	for _, ref := range strings.Split(string(raw), `"$ref":"`)[1:] {
+		ref = ref[:strings.Index(ref, `"`)]
+		if name, ok := strings.CutPrefix(ref, "#/components/schemas/"); ok {
+			assert.Contains(t, doc.Components.Schemas, name)
+		} else {
+			assert.Equal(t, "#/components/responses/Error", ref)
+		}
+	}
+}
+
+func TestSchemaNames(t *testing.T) {
+	g := newOpenAPIGenerator()
+	_, err := g.schema(paginated[apiError]{})
+	require.NoError(t, err)
+	assert.Contains(t, g.schemas, "sippyserver.apiError")
+	assert.Contains(t, g.schemas, "sippyserver.paginated_sippyserver.apiError")
+}
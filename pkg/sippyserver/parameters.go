@@ -101,6 +101,24 @@ func getPaginationParams(req *http.Request) (*apitype.Pagination, error) {
 	return nil, nil
 }
 
+// paginate returns the requested page of rows, for endpoints that build their full result in memory.
+// With no pagination the rows are returned as is, so existing clients still receive a plain list.
+func paginate[T any](rows []T, pagination *apitype.Pagination) any {
+	if pagination == nil || pagination.PerPage <= 0 {
+		return rows
+	}
+	page := make([]T, 0, pagination.PerPage)
+	if start := pagination.Page * pagination.PerPage; start >= 0 && start < len(rows) {
+		page = append(page, rows[start:min(start+pagination.PerPage, len(rows))]...)
+	}
+	return &apitype.PaginationResult{
+		Rows:      page,
+		PageSize:  pagination.PerPage,
+		Page:      pagination.Page,
+		TotalRows: int64(len(rows)),
+	}
+}
+
 func getSortParams(req *http.Request) (string, apitype.Sort) {
 	sortField := param.SafeRead(req, "sortField")
 	sort := apitype.Sort(param.SafeRead(req, "sort"))
@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/sippy/pkg/apis/prow"
 	"github.com/openshift/sippy/pkg/bigquery"
 	"github.com/openshift/sippy/pkg/dataloader/prowloader/gcs"
+	"github.com/openshift/sippy/pkg/dataloader/prowloader/github"
 	"github.com/openshift/sippy/pkg/db"
 	"github.com/openshift/sippy/pkg/db/models"
 	"github.com/openshift/sippy/pkg/github/commenter"
@@ -104,6 +105,7 @@ type WorkProcessor struct {
 // PreparedComment is a comment that is ready to be posted on a github PR
 type PreparedComment struct {
 	comment     string
+	riskLevel   api.RiskLevel // the highest risk in the comment, for the conclusion of a check run
 	commentType int
 	org         string
 	repo        string
@@ -330,8 +332,11 @@ func (cw *CommentWorker) writeComment(ghCommenter *commenter.GitHubCommenter, pr
 		writeCommentMetric.WithLabelValues(preparedComment.org, preparedComment.repo).Observe(float64(end.UnixMilli() - start.UnixMilli()))
 	}()
 
-	// if there is no comment then just delete the record
-	if preparedComment.comment == "" {
+	outputMode := ghCommenter.OutputMode(preparedComment.org, preparedComment.repo)
+
+	// if there is no comment then just delete the record,
+	// unless publishing a check run, which should pass when there is nothing to report
+	if preparedComment.comment == "" && outputMode != commenter.OutputModeCheckRun {
 		return nil
 	}
 
@@ -386,6 +391,10 @@ func (cw *CommentWorker) writeComment(ghCommenter *commenter.GitHubCommenter, pr
 		return nil
 	}
 
+	if outputMode == commenter.OutputModeCheckRun {
+		return cw.writeCheckRun(ghCommenter, logger, preparedComment)
+	}
+
 	// create a constant for the key
 	// determine the commentType and build the id off of that and the sha
 	// generate the comment
@@ -430,6 +439,61 @@ func (cw *CommentWorker) writeComment(ghCommenter *commenter.GitHubCommenter, pr
 	return ghCommenter.AddComment(preparedComment.org, preparedComment.repo, preparedComment.number, ghcomment)
 }
 
+// riskAnalysisCheckRunName is the name of the check run risk analysis is published as
+const riskAnalysisCheckRunName = "Sippy Risk Analysis"
+
+// writeCheckRun publishes the prepared comment as a check run on the PR head commit, with a
+// conclusion reviewers can gate on.
+func (cw *CommentWorker) writeCheckRun(ghCommenter *commenter.GitHubCommenter, logger log.FieldLogger, preparedComment PreparedComment) error {
+	checkRun := github.CheckRun{
+		Name:       riskAnalysisCheckRunName,
+		Conclusion: checkRunConclusion(preparedComment.riskLevel),
+		Title:      fmt.Sprintf("Risk level: %s", preparedComment.riskLevel.Name),
+		Summary:    preparedComment.comment,
+	}
+	if checkRun.Summary == "" {
+		checkRun.Summary = fmt.Sprintf("Risk analysis found no risks for sha: %s", preparedComment.sha)
+	}
+
+	if cw.dryRunOnly {
+		logger.Infof("Dry run check run %s (%s) for: %s\n%s", checkRun.Name, checkRun.Conclusion, preparedComment.sha, checkRun.Summary)
+		return nil
+	}
+
+	logger.Infof("Publishing check run %s (%s) for: %s", checkRun.Name, checkRun.Conclusion, preparedComment.sha)
+	return ghCommenter.UpsertCheckRun(preparedComment.org, preparedComment.repo, preparedComment.sha, checkRun)
+}
+
+// checkRunConclusion fails the check for high risks, and leaves it neutral for risks that need a
+// look but are not clearly caused by the PR.
+func checkRunConclusion(level api.RiskLevel) string {
+	switch {
+	case level.Level >= api.FailureRiskLevelHigh.Level:
+		return "failure"
+	case level.Level >= api.FailureRiskLevelMedium.Level:
+		return "neutral"
+	}
+	return "success"
+}
+
+// overallRiskLevel is the highest risk level of any job or new test.
+func overallRiskLevel(riskAnalyses []RiskAnalysisSummary, newTestRisks []*JobNewTestRisks) api.RiskLevel {
+	overall := api.FailureRiskLevelNone
+	for _, ra := range riskAnalyses {
+		if ra.RiskLevel.Level > overall.Level {
+			overall = ra.RiskLevel
+		}
+	}
+	for _, jr := range newTestRisks {
+		for _, risk := range jr.NewTestRisks {
+			if risk.Level.Level > overall.Level {
+				overall = risk.Level
+			}
+		}
+	}
+	return overall
+}
+
 func (aw *AnalysisWorker) Run(ctx context.Context) {
 
 	// wait for the next item to be available and process it
@@ -486,6 +550,7 @@ func (aw *AnalysisWorker) determinePrComment(ctx context.Context, prCommentProsp
 	newTestRisks := aw.newTestsWorker.analyzeRisks(logger, completedJobs)
 	preparedComment := PreparedComment{
 		comment:     buildCommentText(riskAnalyses, newTestRisks, prCommentProspect.SHA),
+		riskLevel:   overallRiskLevel(riskAnalyses, newTestRisks),
 		sha:         prCommentProspect.SHA,
 		org:         prCommentProspect.Org,
 		repo:        prCommentProspect.Repo,
@@ -181,3 +181,22 @@ func TestRASort(t *testing.T) {
 	SortByJobNameRA(foo) // really a test of whether slices.SortFunc sorts a param in place
 	assert.Equal(t, "bar", foo[0].Name)
 }
+
+func TestCheckRunConclusion(t *testing.T) {
+	riskAnalyses := []RiskAnalysisSummary{
+		{Name: "job1", RiskLevel: api.FailureRiskLevelLow},
+		{Name: "job2", RiskLevel: api.FailureRiskLevelMedium},
+	}
+	newTestRisks := []*JobNewTestRisks{
+		{JobName: "job1", NewTestRisks: map[string]*NewTestRisk{"test": {Level: api.FailureRiskLevelNone}}},
+	}
+	assert.Equal(t, api.FailureRiskLevelMedium, overallRiskLevel(riskAnalyses, newTestRisks))
+	assert.Equal(t, "neutral", checkRunConclusion(overallRiskLevel(riskAnalyses, newTestRisks)))
+
+	newTestRisks[0].NewTestRisks["test"].Level = api.FailureRiskLevelHigh
+	assert.Equal(t, "failure", checkRunConclusion(overallRiskLevel(riskAnalyses, newTestRisks)))
+
+	assert.Equal(t, "success", checkRunConclusion(overallRiskLevel(nil, nil)))
+	assert.Equal(t, "success", checkRunConclusion(api.FailureRiskLevelLow))
+	assert.Equal(t, "neutral", checkRunConclusion(api.FailureRiskLevelMissingData))
+}
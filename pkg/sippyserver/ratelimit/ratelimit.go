@@ -0,0 +1,179 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+)
+
+// maxWindows bounds the in-memory limiter; idle windows are pruned once there are more callers than this.
+const maxWindows = 10000
+
+// Quota allows Requests per Period.
+type Quota struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (q Quota) Enabled() bool {
+	return q.Requests > 0 && q.Period > 0
+}
+
+// ParseQuotas parses endpoint quotas given as <endpoint path>=<requests>/<period>,
+// e.g. /api/tests/v2/runs=50/1h.
+func ParseQuotas(specs []string) (map[string]Quota, error) {
+	quotas := map[string]Quota{}
+	for _, spec := range specs {
+		endpoint, limit, ok := strings.Cut(spec, "=")
+		if !ok || endpoint == "" {
+			return nil, fmt.Errorf("invalid rate limit %q, expected <endpoint path>=<requests>/<period>", spec)
+		}
+		requests, period, ok := strings.Cut(limit, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q, expected <endpoint path>=<requests>/<period>", spec)
+		}
+		q := Quota{}
+		var err error
+		if q.Requests, err = strconv.Atoi(requests); err != nil || q.Requests < 0 {
+			return nil, fmt.Errorf("invalid request count in rate limit %q", spec)
+		}
+		if q.Period, err = time.ParseDuration(period); err != nil || q.Period < 0 {
+			return nil, fmt.Errorf("invalid period in rate limit %q", spec)
+		}
+		quotas[endpoint] = q
+	}
+	return quotas, nil
+}
+
+// Limiter counts requests per endpoint and caller. Counts are kept in the cache when it supports
+// rate limiting, so that limits hold across replicas, and otherwise in memory.
+type Limiter struct {
+	shared    cache.RateLimiter
+	overrides map[string]Quota
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+// NewLimiter returns a limiter using c if it is a cache.RateLimiter. Overrides replace the
+// default quota of the endpoints they name.
+func NewLimiter(c cache.Cache, overrides map[string]Quota) *Limiter {
+	l := &Limiter{overrides: overrides, windows: map[string]*window{}}
+	if shared, ok := c.(cache.RateLimiter); ok {
+		l.shared = shared
+	}
+	return l
+}
+
+// Quota returns the quota for the endpoint, which is def unless overridden.
+func (l *Limiter) Quota(endpoint string, def Quota) Quota {
+	if q, ok := l.overrides[endpoint]; ok {
+		return q
+	}
+	return def
+}
+
+// Allow records a request by identity to endpoint if the quota allows it. If the shared cache
+// fails, requests are counted in memory rather than failing open or closed across the board.
+func (l *Limiter) Allow(ctx context.Context, endpoint, identity string, q Quota) cache.RateLimitResult {
+	key := endpoint + ":" + identity
+	if l.shared != nil {
+		result, err := l.shared.RateLimit(ctx, key, q.Requests, q.Period)
+		if err == nil {
+			return result
+		}
+		log.WithError(err).Warn("error checking rate limit in cache, falling back to in-memory rate limiting")
+	}
+
+	l.mu.Lock()
+	if len(l.windows) > maxWindows {
+		l.prune()
+	}
+	w, ok := l.windows[key]
+	if !ok {
+		w = &window{}
+		l.windows[key] = w
+	}
+	l.mu.Unlock()
+	return w.allow(q, time.Now())
+}
+
+// prune drops windows with no requests left in them. The caller must hold l.mu.
+func (l *Limiter) prune() {
+	now := time.Now()
+	for key, w := range l.windows {
+		if w.idle(now) {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// window is a sliding window of the times of allowed requests.
+type window struct {
+	mu           sync.Mutex
+	requestTimes []time.Time
+	period       time.Duration
+}
+
+func (w *window) allow(q Quota, now time.Time) cache.RateLimitResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.period = q.Period
+
+	// Remove expired request times
+	cutoff := now.Add(-q.Period)
+	valid := w.requestTimes[:0]
+	for _, t := range w.requestTimes {
+		if t.After(cutoff) {
+			valid = append(valid, t)
+		}
+	}
+	w.requestTimes = valid
+
+	// Only allowed requests count toward the limit, so rejections do not extend the wait
+	result := cache.RateLimitResult{Allowed: len(w.requestTimes) < q.Requests}
+	if result.Allowed {
+		w.requestTimes = append(w.requestTimes, now)
+	}
+	result.Remaining = max(q.Requests-len(w.requestTimes), 0)
+	result.Reset = q.Period
+	if len(w.requestTimes) > 0 {
+		result.Reset = w.requestTimes[0].Add(q.Period).Sub(now)
+	}
+	log.Debugf("rate limit allowed=%t remaining=%d/%d reset=%s", result.Allowed, result.Remaining, q.Requests, result.Reset)
+	return result
+}
+
+func (w *window) idle(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.requestTimes) == 0 || !w.requestTimes[len(w.requestTimes)-1].After(now.Add(-w.period))
+}
+
+// Headers lists the response headers SetHeaders may set.
+var Headers = []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}
+
+// SetHeaders sets the RateLimit-* headers from the IETF rate limit headers draft, and Retry-After
+// on rejected requests. Times are in whole seconds, rounded up.
+func SetHeaders(h http.Header, q Quota, result cache.RateLimitResult) {
+	reset := strconv.Itoa(seconds(result.Reset))
+	h.Set("RateLimit-Limit", strconv.Itoa(q.Requests))
+	h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	h.Set("RateLimit-Reset", reset)
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", q.Requests, seconds(q.Period)))
+	if !result.Allowed {
+		h.Set("Retry-After", reset)
+	}
+}
+
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/sippy/pkg/apis/cache"
+)
+
+func TestParseQuotas(t *testing.T) {
+	quotas, err := ParseQuotas([]string{"/api/tests/v2/runs=50/1h", "/api/jobs/runs/disruption=0/1m"})
+	require.NoError(t, err)
+	assert.Equal(t, Quota{Requests: 50, Period: time.Hour}, quotas["/api/tests/v2/runs"])
+	assert.False(t, quotas["/api/jobs/runs/disruption"].Enabled())
+
+	for _, spec := range []string{"/api/x", "/api/x=5", "=5/1h", "/api/x=five/1h", "/api/x=5/hour"} {
+		_, err := ParseQuotas([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestInMemoryLimiter(t *testing.T) {
+	l := NewLimiter(nil, map[string]Quota{"/api/b": {Requests: 5, Period: time.Minute}})
+	q := l.Quota("/api/a", Quota{Requests: 2, Period: time.Hour})
+	assert.Equal(t, Quota{Requests: 2, Period: time.Hour}, q)
+	assert.Equal(t, 5, l.Quota("/api/b", q).Requests)
+
+	ctx := context.Background()
+	first := l.Allow(ctx, "/api/a", "user:alice", q)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.True(t, l.Allow(ctx, "/api/a", "user:alice", q).Allowed)
+
+	rejected := l.Allow(ctx, "/api/a", "user:alice", q)
+	assert.False(t, rejected.Allowed)
+	assert.Equal(t, 0, rejected.Remaining)
+	assert.Greater(t, rejected.Reset, 59*time.Minute)
+
+	// other callers and endpoints have their own windows
+	assert.True(t, l.Allow(ctx, "/api/a", "user:bob", q).Allowed)
+	assert.True(t, l.Allow(ctx, "/api/c", "user:alice", q).Allowed)
+}
+
+func TestWindowSlides(t *testing.T) {
+	w := &window{}
+	q := Quota{Requests: 1, Period: time.Minute}
+	start := time.Now()
+	assert.True(t, w.allow(q, start).Allowed)
+	assert.False(t, w.allow(q, start.Add(30*time.Second)).Allowed)
+	assert.True(t, w.allow(q, start.Add(61*time.Second)).Allowed)
+	assert.False(t, w.idle(start.Add(90*time.Second)))
+	assert.True(t, w.idle(start.Add(3*time.Minute)))
+}
+
+type fakeSharedCache struct {
+	err   error
+	calls int
+}
+
+func (f *fakeSharedCache) Get(context.Context, string, time.Duration) ([]byte, error) {
+	return nil, nil
+}
+func (f *fakeSharedCache) Set(context.Context, string, []byte, time.Duration) error { return nil }
+func (f *fakeSharedCache) RateLimit(_ context.Context, key string, limit int, _ time.Duration) (cache.RateLimitResult, error) {
+	f.calls++
+	return cache.RateLimitResult{Allowed: true, Remaining: limit - 1}, f.err
+}
+
+func TestSharedLimiterFallback(t *testing.T) {
+	shared := &fakeSharedCache{}
+	l := NewLimiter(shared, nil)
+	q := Quota{Requests: 1, Period: time.Hour}
+	ctx := context.Background()
+
+	assert.True(t, l.Allow(ctx, "/api/a", "ip:10.0.0.1", q).Allowed)
+	assert.True(t, l.Allow(ctx, "/api/a", "ip:10.0.0.1", q).Allowed)
+	assert.Equal(t, 2, shared.calls)
+
+	shared.err = errors.New("connection refused")
+	assert.True(t, l.Allow(ctx, "/api/a", "ip:10.0.0.1", q).Allowed)
+	assert.False(t, l.Allow(ctx, "/api/a", "ip:10.0.0.1", q).Allowed)
+}
+
+func TestSetHeaders(t *testing.T) {
+	h := http.Header{}
+	SetHeaders(h, Quota{Requests: 25, Period: time.Hour}, cache.RateLimitResult{Allowed: false, Reset: 1500 * time.Millisecond})
+	assert.Equal(t, "25", h.Get("RateLimit-Limit"))
+	assert.Equal(t, "0", h.Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", h.Get("RateLimit-Reset"))
+	assert.Equal(t, "25;w=3600", h.Get("RateLimit-Policy"))
+	assert.Equal(t, "2", h.Get("Retry-After"))
+}
@@ -2,6 +2,7 @@ package sippyserver
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"os"
 	"os/signal"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,11 +26,15 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/openshift/sippy/pkg/api/componentreadiness/dataprovider"
+	"github.com/openshift/sippy/pkg/api/componentreadiness/export"
 	"github.com/openshift/sippy/pkg/api/componentreadiness/utils"
 	"github.com/openshift/sippy/pkg/api/jobartifacts"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crsnapshot"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/crtest"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/crview"
 	"github.com/openshift/sippy/pkg/apis/api/componentreport/reqopts"
+	"github.com/openshift/sippy/pkg/apis/api/componentreport/testdetails"
 	"github.com/openshift/sippy/pkg/bigquery/bqlabel"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -41,6 +47,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/openshift/sippy/pkg/mcp"
+	"github.com/openshift/sippy/pkg/mcp/tools"
 
 	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
 
@@ -49,19 +56,27 @@ import (
 	"cloud.google.com/go/civil"
 	"github.com/openshift/sippy/pkg/api"
 	"github.com/openshift/sippy/pkg/api/componentreadiness"
+	"github.com/openshift/sippy/pkg/api/durations"
 	"github.com/openshift/sippy/pkg/api/featuregatepromotion"
+	"github.com/openshift/sippy/pkg/api/flakiness"
 	"github.com/openshift/sippy/pkg/api/jobrunevents"
 	"github.com/openshift/sippy/pkg/api/jobrunintervals"
+	apijobrunscan "github.com/openshift/sippy/pkg/api/jobrunscan"
 	apitype "github.com/openshift/sippy/pkg/apis/api"
 	"github.com/openshift/sippy/pkg/apis/cache"
 	sippyv1 "github.com/openshift/sippy/pkg/apis/sippy/v1"
 	sippybq "github.com/openshift/sippy/pkg/bigquery"
 	"github.com/openshift/sippy/pkg/db"
+	"github.com/openshift/sippy/pkg/db/chatconversation"
 	"github.com/openshift/sippy/pkg/db/cumulativesummary"
 	"github.com/openshift/sippy/pkg/db/dailysummary"
+	"github.com/openshift/sippy/pkg/db/infrafailure"
 	"github.com/openshift/sippy/pkg/db/models"
+	"github.com/openshift/sippy/pkg/db/models/jobrunscan"
 	"github.com/openshift/sippy/pkg/db/query"
 	"github.com/openshift/sippy/pkg/filter"
+	"github.com/openshift/sippy/pkg/sippyserver/authz"
+	"github.com/openshift/sippy/pkg/sippyserver/ratelimit"
 	"github.com/openshift/sippy/pkg/synthetictests"
 	"github.com/openshift/sippy/pkg/testidentification"
 	"github.com/openshift/sippy/pkg/util"
@@ -98,6 +113,8 @@ func NewServer(
 	enableWriteEndpoints bool,
 	chatAPIURL string,
 	jiraClient *jira.Client,
+	authorization *authz.Config,
+	rateLimits map[string]ratelimit.Quota,
 ) *Server {
 
 	server := &Server{
@@ -123,6 +140,8 @@ func NewServer(
 		enableWriteAPIs:      enableWriteEndpoints,
 		chatAPIURL:           chatAPIURL,
 		jiraClient:           jiraClient,
+		authorization:        authorization,
+		rateLimiter:          ratelimit.NewLimiter(cacheClient, rateLimits),
 	}
 
 	if crDataProvider != nil {
@@ -184,7 +203,8 @@ type Server struct {
 	enableWriteAPIs      bool
 	chatAPIURL           string
 	jiraClient           *jira.Client
-	rateLimiters         map[string]*rateLimiter
+	authorization        *authz.Config
+	rateLimiter          *ratelimit.Limiter
 }
 
 // getReleases returns release data via the configured data provider.
@@ -195,51 +215,6 @@ func (s *Server) getReleases(ctx context.Context) ([]sippyv1.Release, error) {
 	return nil, fmt.Errorf("no data source available for releases")
 }
 
-type rateLimiter struct {
-	mu           sync.Mutex
-	requestTimes []time.Time
-	maxRequests  int
-	period       time.Duration
-}
-
-func (rl *rateLimiter) allow() bool {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	now := time.Now()
-
-	// Remove expired request times
-	cutoff := now.Add(-rl.period)
-	var validTimes []time.Time
-	for _, t := range rl.requestTimes {
-		if t.After(cutoff) {
-			validTimes = append(validTimes, t)
-		}
-	}
-	rl.requestTimes = validTimes
-
-	log.Debugf("Rate limiter: now=%s, cutoff=%s, valid_requests=%d/%d, oldest=%v",
-		now.Format("15:04:05"), cutoff.Format("15:04:05"), len(rl.requestTimes), rl.maxRequests,
-		func() string {
-			if len(rl.requestTimes) > 0 {
-				return rl.requestTimes[0].Format("15:04:05")
-			}
-			return "none"
-		}())
-
-	// Check if we're at the limit
-	if len(rl.requestTimes) >= rl.maxRequests {
-		// Reject without recording - only successful requests count toward the limit
-		log.Debugf("Rate limit REJECTED: %d requests in window (max %d)", len(rl.requestTimes), rl.maxRequests)
-		return false
-	}
-
-	// Record this request as successful - it counts toward the rate limit
-	rl.requestTimes = append(rl.requestTimes, now)
-	log.Debugf("Rate limit ALLOWED: recorded request at %s", now.Format("15:04:05"))
-	return true
-}
-
 func (s *Server) GetReportEnd() time.Time {
 	return util.GetReportEnd(s.pinnedDateTime)
 }
@@ -497,6 +472,7 @@ func failureResponseWithError(w http.ResponseWriter, message string, err error)
 const APIConfigError = "APIConfigError"
 const ParameterMissing = "ParameterMissing"
 const ParameterInvalid = "ParameterInvalid"
+const AuthorizationDenied = "AuthorizationDenied"
 
 func typedFailureResponse(w http.ResponseWriter, code int, errorType, errorParam, message string) {
 	response := map[string]interface{}{
@@ -777,6 +753,71 @@ func (s *Server) jsonFeatureGateDetail(w http.ResponseWriter, req *http.Request)
 	api.RespondWithJSON(http.StatusOK, w, gates[0])
 }
 
+// jsonFeatureGatePromotionReport reports the promotion readiness of every unpromoted feature gate of
+// a release, compared with an earlier stored report. Without a date the report is generated from
+// the current data, which takes a promotion analysis per gate.
+func (s *Server) jsonFeatureGatePromotionReport(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+	format := req.URL.Query().Get("format")
+	if format != "" && format != "json" && format != export.FormatMarkdown {
+		failureResponse(w, http.StatusBadRequest, fmt.Sprintf("unsupported format %q, expected json or %s", format, export.FormatMarkdown))
+		return
+	}
+
+	date, err := param.ReadDate(req, "date")
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	compareTo, err := param.ReadDate(req, "compare_to")
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var report *featuregatepromotion.ReleaseReport
+	if date != "" {
+		report, err = featuregatepromotion.GetReleaseReport(s.db.DB, release, date)
+		if err != nil {
+			failureResponseWithError(w, "couldn't get feature gate promotion report", err)
+			return
+		}
+		if report == nil {
+			failureResponse(w, http.StatusNotFound, fmt.Sprintf("no feature gate promotion report of release %s on or before %s", release, date))
+			return
+		}
+	} else {
+		report, err = featuregatepromotion.GenerateReleaseReport(req.Context(), s.db, s.cache, release, time.Now())
+		if err != nil {
+			failureResponseWithError(w, "couldn't generate feature gate promotion report", err)
+			return
+		}
+	}
+	if err := featuregatepromotion.AddTrend(s.db.DB, report, compareTo); err != nil {
+		failureResponseWithError(w, "couldn't compare feature gate promotion reports", err)
+		return
+	}
+
+	if format == export.FormatMarkdown {
+		var buf bytes.Buffer
+		if err := report.WriteMarkdown(&buf); err != nil {
+			failureResponseWithError(w, "couldn't write feature gate promotion report", err)
+			return
+		}
+		w.Header().Set("Content-Type", export.ContentType(export.FormatMarkdown))
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			log.WithError(err).Warn("error writing feature gate promotion report")
+		}
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, report)
+}
+
 func injectFeatureGateListLinks(fg *apitype.FeatureGate, release, baseAPIURL, baseFrontendURL string) {
 	fg.Links = map[string]string{
 		"ui_detail": fmt.Sprintf(
@@ -827,6 +868,7 @@ func convertPromotionStatus(status *featuregatepromotion.PromotionStatus) *apity
 	for _, vr := range status.ResultsByVariant {
 		variant := apitype.FeatureGateVariantResult{
 			Variants:    vr.Variants,
+			JobTiers:    vr.JobTiers,
 			Optional:    vr.Optional,
 			Sufficient:  vr.Sufficient,
 			TestResults: []apitype.FeatureGateTestResult{},
@@ -1019,6 +1061,76 @@ func (s *Server) jsonGetRecentTestFailures(w http.ResponseWriter, req *http.Requ
 	api.RespondWithJSON(http.StatusOK, w, result)
 }
 
+func (s *Server) jsonFlakyTests(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	opts := flakiness.Options{Release: release, Variant: param.SafeRead(req, "variant")}
+	var err error
+	if opts.WindowDays, err = param.ReadUint(req, "window_days", 90); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.MinRuns, err = param.ReadUint(req, "min_runs", 0); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.Limit, err = param.ReadUint(req, "limit", 1000); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.All, err = param.ReadBool(req, "all", false); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := flakiness.GetFlakyTests(s.db, opts, s.GetReportEnd())
+	if err != nil {
+		failureResponseWithError(w, "error scoring flaky tests", err)
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, report)
+}
+
+func (s *Server) jsonTestDurationRegressions(w http.ResponseWriter, req *http.Request) {
+	release := s.getParamOrFail(w, req, "release")
+	if release == "" {
+		return
+	}
+
+	opts := durations.Options{
+		Release: release,
+		Variant: param.SafeRead(req, "variant"),
+		GroupBy: param.SafeRead(req, "group_by"),
+	}
+	var err error
+	if opts.BasisDays, err = param.ReadUint(req, "basis_days", 60); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.SampleDays, err = param.ReadUint(req, "sample_days", 30); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.MinSamples, err = param.ReadUint(req, "min_samples", 0); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.Limit, err = param.ReadUint(req, "limit", 1000); err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := durations.GetDurationRegressions(s.db, opts, s.GetReportEnd())
+	if err != nil {
+		failureResponseWithError(w, "error comparing test durations", err)
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, report)
+}
+
 func (s *Server) jsonBackendDisruptionByRun(w http.ResponseWriter, req *http.Request) {
 	if s.bigQueryClient == nil {
 		failureResponse(w, http.StatusBadRequest, "backend disruption API requires BigQuery configuration")
@@ -1263,12 +1375,22 @@ func (s *Server) getComponentReportFromRequest(req *http.Request) (componentrepo
 }
 
 func (s *Server) jsonComponentReport(w http.ResponseWriter, req *http.Request) {
+	format, err := exportFormat(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	outputs, err := s.getComponentReportFromRequest(req)
 	if err != nil {
 		failureResponseWithError(w, "error generating component report", err)
 		return
 	}
 
+	if format != "" {
+		name := exportName("component-readiness", param.SafeRead(req, "view"), req.URL.Query().Get("sampleRelease"))
+		respondWithExport(w, format, name, export.FromComponentReport(outputs))
+		return
+	}
 	api.RespondWithJSON(http.StatusOK, w, outputs)
 }
 
@@ -1865,12 +1987,17 @@ func (s *Server) jsonJobsAnalysisFromDB(w http.ResponseWriter, req *http.Request
 }
 
 func (s *Server) jsonGetTriages(w http.ResponseWriter, req *http.Request) {
+	pagination, err := getPaginationParams(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "could not parse pagination options: "+err.Error())
+		return
+	}
 	triages, err := componentreadiness.ListTriages(s.db, req)
 	if err != nil {
 		failureResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	api.RespondWithJSON(http.StatusOK, w, triages)
+	api.RespondWithJSON(http.StatusOK, w, paginate(triages, pagination))
 }
 
 // ExpandedTriage allows for additional information to be included in the triage response.
@@ -1954,7 +2081,7 @@ func (s *Server) jsonGetTriageByID(w http.ResponseWriter, req *http.Request) {
 }
 
 func (s *Server) jsonCreateTriage(w http.ResponseWriter, req *http.Request) {
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.Infof("triage POST made by user: %s", user)
 	var triage models.Triage
 	if err := json.NewDecoder(req.Body).Decode(&triage); err != nil {
@@ -1980,7 +2107,7 @@ func (s *Server) jsonUpdateTriage(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.Infof("triage PUT made by user: %s", user)
 	var triage models.Triage
 	if err := json.NewDecoder(req.Body).Decode(&triage); err != nil {
@@ -2011,7 +2138,7 @@ func (s *Server) jsonDeleteTriage(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	user := getUserForRequest(req)
+	user := api.GetUserForRequest(req)
 	log.Infof("triage DELETE made by user: %s", user)
 	ctx := context.WithValue(req.Context(), models.CurrentUserKey, user)
 	if err := componentreadiness.DeleteTriage(s.db.DB.WithContext(ctx), triageID); err != nil {
@@ -2095,6 +2222,179 @@ func (s *Server) jsonGetTriageAuditDetails(w http.ResponseWriter, req *http.Requ
 	api.RespondWithJSON(http.StatusOK, w, responseAuditLogs)
 }
 
+func (s *Server) jsonGetRegressionAllowances(w http.ResponseWriter, req *http.Request) {
+	allowances, err := componentreadiness.ListRegressionAllowances(s.db.DB, req)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error listing regression allowances: %v", err))
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, allowances)
+}
+
+func (s *Server) jsonListComponentReportSnapshots(w http.ResponseWriter, req *http.Request) {
+	snapshots, err := componentreadiness.ListReportSnapshots(s.db.DB, param.SafeRead(req, "view"))
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error listing component report snapshots: %v", err))
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, snapshots)
+}
+
+func (s *Server) jsonGetComponentReportSnapshot(w http.ResponseWriter, req *http.Request) {
+	view := param.SafeRead(req, "view")
+	if view == "" {
+		failureResponse(w, http.StatusBadRequest, "view is required")
+		return
+	}
+	date := mux.Vars(req)["date"]
+	if _, err := time.Parse(crsnapshot.DateFormat, date); err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid date, expected YYYY-MM-DD: "+date)
+		return
+	}
+	snapshot, err := componentreadiness.GetReportSnapshot(s.db.DB, view, date)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error getting component report snapshot: %v", err))
+		return
+	}
+	if snapshot == nil {
+		failureResponse(w, http.StatusNotFound, fmt.Sprintf("no snapshot of view %s on %s", view, date))
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, snapshot)
+}
+
+func (s *Server) jsonDiffComponentReportSnapshots(w http.ResponseWriter, req *http.Request) {
+	view := param.SafeRead(req, "view")
+	from, err := param.ReadDate(req, "from_date")
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if view == "" || from == "" {
+		failureResponse(w, http.StatusBadRequest, "view and from_date (YYYY-MM-DD) are required")
+		return
+	}
+	to, err := param.ReadDate(req, "to_date")
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	diff, err := componentreadiness.DiffReportSnapshots(s.db.DB, view, from, to)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error comparing component report snapshots: %v", err))
+		return
+	}
+	if diff == nil {
+		failureResponse(w, http.StatusNotFound, fmt.Sprintf("view %s does not have snapshots for both dates", view))
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, diff)
+}
+
+func (s *Server) jsonGetRegressionAllowanceByID(w http.ResponseWriter, req *http.Request) {
+	idStr := mux.Vars(req)["id"]
+	allowanceID, err := strconv.Atoi(idStr)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid ID format: "+idStr)
+		return
+	}
+
+	allowance, err := componentreadiness.GetRegressionAllowance(s.db.DB, allowanceID, req)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, fmt.Sprintf("error getting regression allowance: %v", err))
+		return
+	}
+	if allowance == nil {
+		failureResponse(w, http.StatusNotFound, "regression allowance not found")
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, allowance)
+}
+
+func (s *Server) jsonCreateRegressionAllowance(w http.ResponseWriter, req *http.Request) {
+	user := api.GetUserForRequest(req)
+	log.Infof("regression allowance POST made by user: %s", user)
+	var allowance models.RegressionAllowance
+	if err := json.NewDecoder(req.Body).Decode(&allowance); err != nil {
+		log.WithError(err).Error("error parsing new regression allowance")
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx := context.WithValue(req.Context(), models.CurrentUserKey, user)
+	allowance, err := componentreadiness.CreateRegressionAllowance(s.db.DB.WithContext(ctx), allowance, req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, allowance)
+}
+
+func (s *Server) jsonUpdateRegressionAllowance(w http.ResponseWriter, req *http.Request) {
+	idStr := mux.Vars(req)["id"]
+	allowanceID, err := strconv.Atoi(idStr)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid ID format: "+idStr)
+		return
+	}
+
+	user := api.GetUserForRequest(req)
+	log.Infof("regression allowance PUT made by user: %s", user)
+	var allowance models.RegressionAllowance
+	if err := json.NewDecoder(req.Body).Decode(&allowance); err != nil {
+		log.WithError(err).Error("error parsing regression allowance")
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if allowanceID != int(allowance.ID) { // nolint:gosec
+		failureResponse(w, http.StatusBadRequest, "resource regression allowance ID does not match URL")
+		return
+	}
+	ctx := context.WithValue(req.Context(), models.CurrentUserKey, user)
+	allowance, err = componentreadiness.UpdateRegressionAllowance(s.db.DB.WithContext(ctx), allowance, req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, allowance)
+}
+
+func (s *Server) jsonDeleteRegressionAllowance(w http.ResponseWriter, req *http.Request) {
+	idStr := mux.Vars(req)["id"]
+	allowanceID, err := strconv.Atoi(idStr)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid ID format: "+idStr)
+		return
+	}
+
+	user := api.GetUserForRequest(req)
+	log.Infof("regression allowance DELETE made by user: %s", user)
+	ctx := context.WithValue(req.Context(), models.CurrentUserKey, user)
+	if err := componentreadiness.DeleteRegressionAllowance(s.db.DB.WithContext(ctx), allowanceID); errors.Is(err, gorm.ErrRecordNotFound) {
+		failureResponse(w, http.StatusNotFound, fmt.Sprintf("regression allowance %d not found", allowanceID))
+		return
+	} else if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, nil)
+}
+
+func (s *Server) jsonGetRegressionAllowanceAuditLogs(w http.ResponseWriter, req *http.Request) {
+	idStr := mux.Vars(req)["id"]
+	allowanceID, err := strconv.Atoi(idStr)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "invalid ID format: "+idStr)
+		return
+	}
+
+	auditLogs, err := componentreadiness.GetRegressionAllowanceAuditLogs(s.db.DB, allowanceID)
+	if err != nil {
+		failureResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, auditLogs)
+}
+
 // jsonGetRegressions handles GET requests for listing component readiness regression records.
 func (s *Server) jsonGetRegressions(w http.ResponseWriter, req *http.Request) {
 	// Get releases for view processing
@@ -2108,6 +2408,16 @@ func (s *Server) jsonGetRegressions(w http.ResponseWriter, req *http.Request) {
 	view := param.SafeRead(req, "view")
 	release := param.SafeRead(req, "release")
 	testName := param.SafeRead(req, "test")
+	format, err := exportFormat(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pagination, err := getPaginationParams(req)
+	if err != nil {
+		failureResponse(w, http.StatusBadRequest, "could not parse pagination options: "+err.Error())
+		return
+	}
 
 	// Error if both view and release are specified
 	if view != "" && release != "" {
@@ -2141,7 +2451,12 @@ func (s *Server) jsonGetRegressions(w http.ResponseWriter, req *http.Request) {
 		failureResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
-	api.RespondWithJSON(http.StatusOK, w, regressions)
+	if format != "" {
+		// exports are for sharing, so hold every regression rather than a page
+		respondWithExport(w, format, exportName("regressions", view, release), export.FromRegressions(regressions, view))
+		return
+	}
+	api.RespondWithJSON(http.StatusOK, w, paginate(regressions, pagination))
 }
 
 // jsonGetRegressionByID handles GET requests for a specific component readiness regression record by ID.
@@ -2174,14 +2489,6 @@ func (s *Server) jsonGetRegressionByID(w http.ResponseWriter, req *http.Request)
 	api.RespondWithJSON(http.StatusOK, w, regression)
 }
 
-func getUserForRequest(req *http.Request) string {
-	user := req.Header.Get("X-Forwarded-User")
-	if user == "" && os.Getenv("DEV_MODE") == "1" {
-		user = "developer"
-	}
-	return user
-}
-
 // jsonRegressionPotentialMatchingTriages finds the triage entries that currently have regressions that match
 // the regression in question. These matches are based on test name and last failure time similarity.
 func (s *Server) jsonRegressionPotentialMatchingTriages(w http.ResponseWriter, req *http.Request) {
@@ -2224,7 +2531,7 @@ func (s *Server) jsonFileJiraBug(w http.ResponseWriter, req *http.Request) {
 			log.Warn("jira client not initialized, will not create jira bug, dry run only")
 		}
 
-		user := getUserForRequest(req)
+		user := api.GetUserForRequest(req)
 		if user == "" {
 			failureResponse(w, http.StatusUnauthorized, "User authentication required")
 			return
@@ -2316,6 +2623,8 @@ func logJiraError(response *jira.Response, err error) {
 // - pathGlob (required): a glob pattern to match against the GCS path (ref. https://cloud.google.com/storage/docs/json_api/v1/objects/list#list-object-glob)
 // - textContains: a string to search for in the contents of the artifacts, returning the containing line
 // - textRegex: a regex string to match in the contents of the artifacts, returning the matching line
+// - jq: a jq expression to run against JSON artifacts, returning each result that is not null or false
+// - xpath: an XPath expression to run against XML artifacts, returning the selected nodes or a truthy scalar result
 // - beforeContext: a positive integer indicating how many lines before the matched line to return
 // - afterContext: a positive integer indicating how many lines after the matched line to return
 // - maxFileMatches: a positive integer indicating maximum number of matches to return per file
@@ -2394,6 +2703,26 @@ func contentMatcherFromParams(w http.ResponseWriter, req *http.Request) (content
 			return nil, true
 		}
 		contentMatcher = jobartifacts.NewRegexMatcher(re, contextBefore, contextAfter, maxMatches)
+	} else if jqStr := param.SafeRead(req, "jq"); jqStr != "" {
+		_, _, maxMatches, errs := jobartifacts.ParseLineMatcherParams(req)
+		if contentMatcherParamFailure(w, errs) {
+			return nil, true
+		}
+		var err error
+		if contentMatcher, err = jobartifacts.NewJQMatcher(jqStr, maxMatches); err != nil {
+			typedFailureResponse(w, http.StatusBadRequest, ParameterInvalid, "jq", err.Error())
+			return nil, true
+		}
+	} else if xpathStr := param.SafeRead(req, "xpath"); xpathStr != "" {
+		_, _, maxMatches, errs := jobartifacts.ParseLineMatcherParams(req)
+		if contentMatcherParamFailure(w, errs) {
+			return nil, true
+		}
+		var err error
+		if contentMatcher, err = jobartifacts.NewXPathMatcher(xpathStr, maxMatches); err != nil {
+			typedFailureResponse(w, http.StatusBadRequest, ParameterInvalid, "xpath", err.Error())
+			return nil, true
+		}
 	}
 
 	return // nil matcher means don't bother reading the artifacts, just return metadata without any matching
@@ -2426,25 +2755,18 @@ func (s *Server) requireCapabilities(capabilities []string, implFn func(w http.R
 	}
 }
 
-func (s *Server) rateLimit(endpointPath string, maxRequests int, period time.Duration, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
-	// Initialize rate limiter map if needed
-	if s.rateLimiters == nil {
-		s.rateLimiters = make(map[string]*rateLimiter)
-	}
-
-	// Create or get rate limiter for this endpoint
-	if s.rateLimiters[endpointPath] == nil {
-		s.rateLimiters[endpointPath] = &rateLimiter{
-			maxRequests: maxRequests,
-			period:      period,
-		}
-	}
-
-	rl := s.rateLimiters[endpointPath]
-
+// rateLimit limits each caller of the endpoint to the quota, identifying callers by user if the auth
+// proxy identified them, and otherwise by IP.
+func (s *Server) rateLimit(endpointPath string, quota ratelimit.Quota, handler func(w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !rl.allow() {
-			failureResponse(w, http.StatusTooManyRequests, fmt.Sprintf("Rate limit exceeded. Maximum %d requests per %s. Please try again later.", maxRequests, period))
+		identity := "ip:" + getRequestorIP(r)
+		if user := api.GetUserForRequest(r); user != "" {
+			identity = "user:" + user
+		}
+		result := s.rateLimiter.Allow(r.Context(), endpointPath, identity, quota)
+		ratelimit.SetHeaders(w.Header(), quota, result)
+		if !result.Allowed {
+			failureResponse(w, http.StatusTooManyRequests, fmt.Sprintf("Rate limit exceeded. Maximum %d requests per %s. Please try again later.", quota.Requests, quota.Period))
 			return
 		}
 		handler(w, r)
@@ -2490,29 +2812,142 @@ func (s *Server) Serve() {
 	router.PathPrefix("/static/").Handler(http.FileServer(http.FS(s.static)))
 
 	// Setup MCP Server
-	mcpServer := mcp.NewMCPServer(context.Background(), s.httpServer, s.db, s.bigQueryClient, s.cache)
-
-	type apiEndpoints struct {
-		EndpointPath      string                                       `json:"path"`
-		Description       string                                       `json:"description"`
-		Capabilities      []string                                     `json:"required_capabilities"`
-		CacheTime         time.Duration                                `json:"cache_time"`
-		Methods           []string                                     `json:"methods,omitempty"`
-		HandlerFunc       func(w http.ResponseWriter, r *http.Request) `json:"-"`
-		RateLimitRequests int                                          `json:"-"` // Maximum number of requests
-		RateLimitPeriod   time.Duration                                `json:"-"` // Time period for rate limit
+	mcpDeps := &tools.ToolDependencies{
+		DBClient:             s.db,
+		BigQueryClient:       s.bigQueryClient,
+		CacheClient:          s.cache,
+		CRDataProvider:       s.crDataProvider,
+		CRTimeRoundingFactor: s.crTimeRoundingFactor,
+		CRTimeRoundingOffset: s.crTimeRoundingOffset,
+		JiraClient:           s.jiraClient,
+		EnableWriteAPIs:      s.db != nil && s.enableWriteAPIs,
+		AuthorizeWrite:       s.authorizeWrite,
 	}
+	if s.views != nil {
+		mcpDeps.Views = s.views.ComponentReadiness
+	}
+	mcpServer := mcp.NewMCPServer(context.Background(), s.httpServer, mcpDeps)
 
-	var endpoints []apiEndpoints
-	endpoints = []apiEndpoints{
-		{
-			EndpointPath: "/mcp/v1/",
-			Description:  "Handles MCP Requests",
-			Capabilities: []string{},
-			HandlerFunc:  http.StripPrefix("/mcp/v1", mcpServer.Handler()).ServeHTTP,
-		},
-		{
-			EndpointPath: "/api",
+	endpoints := s.apiEndpointTable(http.StripPrefix("/mcp/v1", mcpServer.Handler()).ServeHTTP)
+
+	for _, ep := range endpoints {
+		fn := ep.HandlerFunc
+		// Apply rate limiting first (innermost middleware)
+		// This ensures cached responses bypass rate limiting
+		if quota := s.rateLimiter.Quota(ep.EndpointPath, ratelimit.Quota{Requests: ep.RateLimitRequests, Period: ep.RateLimitPeriod}); quota.Enabled() {
+			fn = s.rateLimit(ep.EndpointPath, quota, fn)
+		}
+		// Apply caching second - wraps rate-limited handler
+		// Cache hits return early without calling the rate-limited handler
+		if ep.CacheTime > 0 {
+			fn = s.cached(ep.CacheTime, fn)
+		}
+		// Check the user may call write endpoints before any other work is done for them
+		if s.authorization != nil && slices.Contains(ep.Capabilities, WriteEndpointsCapability) {
+			fn = s.authorize(ep.EndpointPath, fn)
+		}
+		// Apply capability checks last (outermost middleware)
+		if len(ep.Capabilities) > 0 {
+			fn = s.requireCapabilities(ep.Capabilities, fn)
+		}
+
+		// Register endpoint with proper HTTP methods
+		route := router.HandleFunc(ep.EndpointPath, fn)
+		if len(ep.Methods) > 0 {
+			route.Methods(ep.Methods...)
+		}
+	}
+
+	// Catch-all fallback: serve static files for any unmatched routes, or redirect to sippy-ng
+	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Try to open the file from static filesystem (embedded FS keeps directory structure)
+		filePath := "static" + r.URL.Path
+		if _, err := s.static.Open(filePath); err != nil {
+			// File doesn't exist in static, redirect to sippy-ng
+			if r.URL.Path == "/" {
+				http.Redirect(w, r, "/sippy-ng/", http.StatusMovedPermanently)
+			} else {
+				http.NotFound(w, r)
+			}
+			return
+		}
+		// File exists, rewrite path to include /static prefix and serve
+		r.URL.Path = "/static" + r.URL.Path
+		http.FileServer(http.FS(s.static)).ServeHTTP(w, r)
+	})
+
+	var handler http.Handler = router
+	handler = logRequestHandler(handler)
+
+	// Middleware for http metrics
+	metricsMiddleware := middleware.New(middleware.Config{
+		Recorder: metrics.NewRecorder(metrics.Config{
+			DurationBuckets: []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		}),
+	})
+	handler = middlewarestd.Handler("", metricsMiddleware, handler)
+	cors := handlers.CORS(
+		handlers.AllowedOrigins([]string{s.corsAllowedOrigin}),
+		handlers.AllowedMethods([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}),
+		handlers.AllowedHeaders([]string{"Content-Type", "X-Forwarded-User", "X-Forwarded-Groups", "X-Forwarded-For", "X-Real-IP", "Authorization"}))
+
+	// Store a pointer to the HTTP server for later retrieval.
+	s.httpServer = &http.Server{
+		Addr:              s.listenAddr,
+		Handler:           cors(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Infof("Serving reports on %s ", s.listenAddr)
+
+	// Handle graceful shutdown on SIGINT/SIGTERM so coverage data is flushed
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigCh
+		log.Infof("Received %s, shutting down server...", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			log.WithError(err).Error("Error during server shutdown")
+		}
+	}()
+
+	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.WithError(err).Error("Server exited")
+	}
+}
+
+// apiEndpoints describes a route served by sippy. Params and Response document the endpoint in the
+// OpenAPI spec served at /api/openapi.json, and every endpoint must set a Response.
+type apiEndpoints struct {
+	EndpointPath      string                                       `json:"path"`
+	Description       string                                       `json:"description"`
+	Capabilities      []string                                     `json:"required_capabilities"`
+	CacheTime         time.Duration                                `json:"cache_time"`
+	Methods           []string                                     `json:"methods,omitempty"`
+	HandlerFunc       func(w http.ResponseWriter, r *http.Request) `json:"-"`
+	RateLimitRequests int                                          `json:"-"` // Maximum number of requests
+	RateLimitPeriod   time.Duration                                `json:"-"` // Time period for rate limit
+	Params            []apiParam                                   `json:"params,omitempty"`
+	RequestBody       any                                          `json:"-"` // Value of the type decoded from the request body
+	Response          any                                          `json:"-"` // Value of the type written to the response body
+	ResponseStatus    int                                          `json:"-"` // Status of a successful response, if not 200
+}
+
+// apiEndpointTable returns every API route sippy serves. mcpHandler serves the MCP requests.
+func (s *Server) apiEndpointTable(mcpHandler http.HandlerFunc) []apiEndpoints {
+	var endpoints []apiEndpoints
+	endpoints = []apiEndpoints{
+		{
+			EndpointPath: "/mcp/v1/",
+			Description:  "Handles MCP Requests",
+			Capabilities: []string{},
+			HandlerFunc:  mcpHandler,
+			Response:     externalResponse{},
+		},
+		{
+			EndpointPath: "/api",
 			Description:  "API docs",
 			Methods:      []string{http.MethodGet},
 			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
@@ -2524,12 +2959,35 @@ func (s *Server) Serve() {
 				}
 				api.RespondWithJSON(http.StatusOK, w, availableEndpoints)
 			},
+			Response: []apiEndpoints{},
+		},
+		{
+			EndpointPath: "/api/openapi.json",
+			Description:  "OpenAPI spec of the API",
+			Methods:      []string{http.MethodGet},
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				var availableEndpoints []apiEndpoints
+				for _, ep := range endpoints {
+					if s.hasCapabilities(ep.Capabilities) {
+						availableEndpoints = append(availableEndpoints, ep)
+					}
+				}
+				doc, err := generateOpenAPI(availableEndpoints)
+				if err != nil {
+					failureResponseWithError(w, "error generating OpenAPI spec", err)
+					return
+				}
+				api.RespondWithJSON(http.StatusOK, w, doc)
+			},
+			Response: map[string]any{},
 		},
 		{
 			EndpointPath: "/api/job/run/summary",
 			Description:  "Returns raw job run summary data including test failures and cluster operators",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobRunSummary,
+			Params:       jobRunIDParams,
+			Response:     api.JobRunData{},
 		},
 		{
 			EndpointPath: "/api/job/run/payload",
@@ -2537,30 +2995,42 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobRunPayload,
 			CacheTime:    4 * time.Hour,
+			Params:       jobRunIDParams,
+			Response:     []apitype.JobPayload{},
 		},
 		{
 			EndpointPath: "/api/autocomplete/{field}",
 			Description:  "Autocompletes queries",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonAutocompleteFromDB,
+			Params:       paramList(optionalReleaseParams, []apiParam{queryParam("search", "Substring to match")}),
+			Response:     []string{},
 		},
 		{
 			EndpointPath: "/api/jobs",
 			Description:  "Returns a list of jobs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobsReportFromDB,
+			Params:       paramList(releaseParams, filterParams, periodParams),
+			Response:     []apitype.Job{},
 		},
 		{
 			EndpointPath: "/api/jobs/runs",
 			Description:  "Returns a report of job runs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobRunsReportFromDB,
+			Params: paramList(optionalReleaseParams, []apiParam{
+				queryParam("useCurrentRelease", "Report on the current release when release is not set").boolean(),
+			}, filterParams, paginationParams),
+			Response: paginated[apitype.JobRun]{},
 		},
 		{
 			EndpointPath: "/api/jobs/runs/risk_analysis",
 			Description:  "Analyzes risks of job runs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobRunRiskAnalysis,
+			Params:       []apiParam{queryParam("prow_job_run_id", "Prow job run ID, if a partial ProwJobRun is not POSTed instead")},
+			Response:     apitype.ProwJobRunRiskAnalysis{},
 		},
 		{
 			EndpointPath: "/api/jobs/runs/intervals",
@@ -2568,6 +3038,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    4 * time.Hour,
 			HandlerFunc:  s.jsonJobRunIntervals,
+			Params:       paramList(jobRunParams, []apiParam{queryParam("file", "Interval file to read, defaults to all of them")}),
+			Response:     apitype.EventIntervalList{},
 		},
 		{
 			EndpointPath: "/api/jobs/runs/events",
@@ -2575,30 +3047,53 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    4 * time.Hour,
 			HandlerFunc:  s.jsonJobRunEvents,
+			Params:       jobRunParams,
+			Response:     jobrunevents.EventListResponse{},
 		},
 		{
 			EndpointPath: "/api/jobs/analysis",
 			Description:  "Analyzes jobs from the database",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobsAnalysisFromDB,
+			Params:       paramList(optionalReleaseParams, filterParams, periodParams),
+			Response:     apitype.JobAnalysisResult{},
 		},
 		{
 			EndpointPath: "/api/jobs/details",
 			Description:  "Reports details of jobs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobsDetailsReportFromDB,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("job", "Substring of the job names").required(),
+				queryParam("limit", "Maximum number of jobs").integer(),
+			}),
+			Response: api.JobDetailAPIResult{},
 		},
 		{
 			EndpointPath: "/api/jobs/bugs",
 			Description:  "Reports bugs related to jobs",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonJobBugsFromDB,
+			Params:       paramList(optionalReleaseParams, filterParams, periodParams),
+			Response:     []models.Bug{},
 		},
 		{
 			EndpointPath: "/api/jobs/artifacts",
 			Description:  "Queries job artifacts and their contents",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.queryJobArtifacts,
+			Params: []apiParam{
+				queryParam("prowJobRuns", "Comma separated prow job run IDs").required(),
+				queryParam("pathGlob", "Glob of the artifact paths to search"),
+				queryParam("textContains", "Text to search the artifacts for"),
+				queryParam("textRegex", "Regular expression to search the artifacts for"),
+				queryParam("jq", "jq expression to apply to JSON artifacts"),
+				queryParam("xpath", "XPath expression to apply to XML artifacts"),
+				queryParam("beforeContext", "Lines of context before each match").integer(),
+				queryParam("afterContext", "Lines of context after each match").integer(),
+				queryParam("maxFileMatches", "Maximum matches per file").integer(),
+			},
+			Response: jobartifacts.QueryResponse{},
 		},
 		{
 			EndpointPath: "/api/jobs/labels",
@@ -2606,13 +3101,17 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonListLabels,
+			Response:     []jobrunscan.Label{},
 		},
 		{
-			EndpointPath: "/api/jobs/labels",
-			Description:  "Create a new job run label definition",
-			Methods:      []string{http.MethodPost},
-			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonCreateLabel,
+			EndpointPath:   "/api/jobs/labels",
+			Description:    "Create a new job run label definition",
+			Methods:        []string{http.MethodPost},
+			Capabilities:   []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonCreateLabel,
+			RequestBody:    jobrunscan.Label{},
+			Response:       jobrunscan.Label{},
+			ResponseStatus: http.StatusCreated,
 		},
 		{
 			EndpointPath: "/api/jobs/labels/{id}",
@@ -2620,6 +3119,7 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonGetLabel,
+			Response:     jobrunscan.Label{},
 		},
 		{
 			EndpointPath: "/api/jobs/labels/{id}",
@@ -2627,13 +3127,17 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPut},
 			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonUpdateLabel,
+			RequestBody:  jobrunscan.Label{},
+			Response:     jobrunscan.Label{},
 		},
 		{
-			EndpointPath: "/api/jobs/labels/{id}",
-			Description:  "Delete a job run label definition",
-			Methods:      []string{http.MethodDelete},
-			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonDeleteLabel,
+			EndpointPath:   "/api/jobs/labels/{id}",
+			Description:    "Delete a job run label definition",
+			Methods:        []string{http.MethodDelete},
+			Capabilities:   []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonDeleteLabel,
+			Response:       noContent{},
+			ResponseStatus: http.StatusNoContent,
 		},
 		{
 			EndpointPath: "/api/jobs/symptoms",
@@ -2641,13 +3145,17 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonListSymptoms,
+			Response:     []jobrunscan.Symptom{},
 		},
 		{
-			EndpointPath: "/api/jobs/symptoms",
-			Description:  "Create a new job run symptom definition",
-			Methods:      []string{http.MethodPost},
-			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonCreateSymptom,
+			EndpointPath:   "/api/jobs/symptoms",
+			Description:    "Create a new job run symptom definition",
+			Methods:        []string{http.MethodPost},
+			Capabilities:   []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonCreateSymptom,
+			RequestBody:    jobrunscan.Symptom{},
+			Response:       jobrunscan.Symptom{},
+			ResponseStatus: http.StatusCreated,
 		},
 		{
 			EndpointPath: "/api/jobs/symptoms/{id}",
@@ -2655,6 +3163,7 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonGetSymptom,
+			Response:     jobrunscan.Symptom{},
 		},
 		{
 			EndpointPath: "/api/jobs/symptoms/{id}",
@@ -2662,13 +3171,59 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPut},
 			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonUpdateSymptom,
+			RequestBody:  jobrunscan.Symptom{},
+			Response:     jobrunscan.Symptom{},
 		},
 		{
-			EndpointPath: "/api/jobs/symptoms/{id}",
-			Description:  "Delete a job run symptom definition",
+			EndpointPath:   "/api/jobs/symptoms/{id}",
+			Description:    "Delete a job run symptom definition",
+			Methods:        []string{http.MethodDelete},
+			Capabilities:   []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonDeleteSymptom,
+			Response:       noContent{},
+			ResponseStatus: http.StatusNoContent,
+		},
+		{
+			EndpointPath: "/api/jobs/annotation_campaigns",
+			Description:  "List job run annotation campaigns, with their progress",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonListAnnotationCampaigns,
+			Response:     []jobrunscan.AnnotationCampaign{},
+		},
+		{
+			EndpointPath:   "/api/jobs/annotation_campaigns",
+			Description:    "Create a job run annotation campaign, which the daemon runs periodically over new job runs",
+			Methods:        []string{http.MethodPost},
+			Capabilities:   []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonCreateAnnotationCampaign,
+			RequestBody:    jobrunscan.AnnotationCampaign{},
+			Response:       jobrunscan.AnnotationCampaign{},
+			ResponseStatus: http.StatusCreated,
+		},
+		{
+			EndpointPath: "/api/jobs/annotation_campaigns/{id}",
+			Description:  "Get a job run annotation campaign, with its progress",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonGetAnnotationCampaign,
+			Response:     jobrunscan.AnnotationCampaign{},
+		},
+		{
+			EndpointPath: "/api/jobs/annotation_campaigns/{id}/paused",
+			Description:  "Pause a job run annotation campaign",
+			Methods:      []string{http.MethodPut},
+			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonSetAnnotationCampaignPaused,
+			Response:     jobrunscan.AnnotationCampaign{},
+		},
+		{
+			EndpointPath: "/api/jobs/annotation_campaigns/{id}/paused",
+			Description:  "Resume a paused job run annotation campaign",
 			Methods:      []string{http.MethodDelete},
 			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonDeleteSymptom,
+			HandlerFunc:  s.jsonSetAnnotationCampaignPaused,
+			Response:     jobrunscan.AnnotationCampaign{},
 		},
 		{
 			EndpointPath: "/api/jobs/runs/reevaluate",
@@ -2676,12 +3231,42 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPost},
 			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonReEvaluateJobRunSymptoms,
+			RequestBody:  apijobrunscan.ReEvaluationRequest{},
+			Response:     apijobrunscan.ReEvaluationResponse{},
+		},
+		{
+			EndpointPath: "/api/jobs/runs/{id}/infra_failure",
+			Description:  "Whether a job run is recorded as an infrastructure failure, with the audit log of manual changes",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability},
+			HandlerFunc:  s.jsonGetInfraFailure,
+			Response:     infrafailure.Status{},
+		},
+		{
+			EndpointPath: "/api/jobs/runs/{id}/infra_failure",
+			Description:  "Record a job run as an infrastructure failure, removing its results from test pass rates",
+			Methods:      []string{http.MethodPut},
+			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonSetInfraFailure,
+			Params:       []apiParam{queryParam("reason", "Why the run is being marked, recorded in the audit log")},
+			Response:     infrafailure.Change{},
+		},
+		{
+			EndpointPath: "/api/jobs/runs/{id}/infra_failure",
+			Description:  "Unrecord a job run mistakenly labeled as an infrastructure failure, adding its results back to test pass rates",
+			Methods:      []string{http.MethodDelete},
+			Capabilities: []string{LocalDBCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonSetInfraFailure,
+			Params:       []apiParam{queryParam("reason", "Why the run is being unmarked, recorded in the audit log")},
+			Response:     infrafailure.Change{},
 		},
 		{
 			EndpointPath: "/api/job_variants",
 			Description:  "Reports all job variants",
 			Capabilities: []string{ComponentReadinessCapability},
 			HandlerFunc:  s.jsonJobVariants,
+			Params:       []apiParam{queryParam("dataSource", "Data source to query, when several are configured")},
+			Response:     crtest.JobVariants{},
 		},
 		{
 			EndpointPath: "/api/pull_requests",
@@ -2689,6 +3274,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonPullRequestsReportFromDB,
+			Params:       paramList(releaseParams, filterParams),
+			Response:     []apitype.PullRequest{},
 		},
 		{
 			EndpointPath: "/api/pull_requests/test_results",
@@ -2696,24 +3283,41 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonPullRequestTestResults,
 			CacheTime:    1 * time.Minute,
+			Params: []apiParam{
+				queryParam("org", "Pull request org").required(),
+				queryParam("repo", "Pull request repo").required(),
+				queryParam("pr_number", "Pull request number").required(),
+				queryParam("start_date", "Start date, YYYY-MM-DD"),
+				queryParam("end_date", "End date, YYYY-MM-DD"),
+				queryParam("latest_sha_only", "Only report the latest commit of the pull request").boolean(),
+				queryParam("include_successes", "Test name substring for which to include passing results").repeated(),
+				queryParam("limit", "Maximum number of results").integer(),
+			},
+			Response: []api.PRTestResult{},
 		},
 		{
 			EndpointPath: "/api/repositories",
 			Description:  "Reports on repositories",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonRepositoriesReportFromDB,
+			Params:       paramList(releaseParams, filterParams),
+			Response:     []apitype.Repository{},
 		},
 		{
 			EndpointPath: "/api/tests",
 			Description:  "Reports on tests",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonTestsReportFromDB,
+			Params:       paramList(releaseParams, filterParams, testReportParams),
+			Response:     api.TestsAPIResult{},
 		},
 		{
 			EndpointPath: "/api/tests/v2",
 			Description:  "Reports on tests",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonTestsReportFromBigQuery,
+			Params:       paramList(releaseParams, filterParams, testReportParams),
+			Response:     api.TestsAPIResultBQ{},
 		},
 		{
 			EndpointPath: "/api/tests/details",
@@ -2721,6 +3325,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestDetailsReportFromDB,
+			Params:       paramList(releaseParams, []apiParam{queryParam("test", "Substring of the test names").repeated()}),
+			Response:     "",
 		},
 		{
 			EndpointPath: "/api/tests/analysis/overall",
@@ -2728,6 +3334,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestAnalysisOverallFromDB,
+			Params:       paramList(testParams, releaseParams, filterParams),
+			Response:     map[string][]api.CountByDate{},
 		},
 		{
 			EndpointPath: "/api/tests/analysis/variants",
@@ -2735,6 +3343,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestAnalysisByVariantFromDB,
+			Params:       paramList(testParams, releaseParams, filterParams),
+			Response:     map[string][]api.CountByDate{},
 		},
 		{
 			EndpointPath: "/api/tests/analysis/jobs",
@@ -2742,6 +3352,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestAnalysisByJobFromDB,
+			Params:       paramList(testParams, releaseParams, filterParams),
+			Response:     map[string][]api.CountByDate{},
 		},
 		{
 			EndpointPath: "/api/tests/bugs",
@@ -2749,6 +3361,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestBugsFromDB,
+			Params:       testParams,
+			Response:     []models.Bug{},
 		},
 		{
 			EndpointPath: "/api/tests/outputs",
@@ -2756,6 +3370,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestOutputsFromDB,
+			Params:       paramList(releaseParams, testParams, filterParams),
+			Response:     []apitype.TestOutput{},
 		},
 		{
 			EndpointPath: "/api/tests/recent_failures",
@@ -2763,6 +3379,43 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonGetRecentTestFailures,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("period", "How far back to look for failures, e.g. 7d").required(),
+				queryParam("previousPeriod", "Period before that to compare against, defaults to the same length"),
+				queryParam("includeOutputs", "Include test outputs").boolean(),
+			}, filterParams, paginationParams),
+			Response: paginated[apitype.RecentTestFailure]{},
+		},
+		{
+			EndpointPath: "/api/tests/flaky",
+			Description:  "Scores tests by flake rate relative to the release and lists quarantine candidates",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    1 * time.Hour,
+			HandlerFunc:  s.jsonFlakyTests,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("variant", "Only jobs with this variant, e.g. Platform:aws"),
+				queryParam("window_days", "Days in the current window, compared to the window before it, defaults to 14").integer(),
+				queryParam("min_runs", "Fewest runs in the window for a test to be scored, defaults to 20").integer(),
+				queryParam("all", "List every test that flaked, not only quarantine candidates").boolean(),
+				queryParam("limit", "Maximum number of tests, defaults to 100").integer(),
+			}),
+			Response: flakiness.Report{},
+		},
+		{
+			EndpointPath: "/api/tests/duration_regressions",
+			Description:  "Compares test durations between a basis and a recent sample window and lists tests that became significantly slower",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    4 * time.Hour,
+			HandlerFunc:  s.jsonTestDurationRegressions,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("variant", "Only jobs with this variant, e.g. Platform:aws"),
+				queryParam("group_by", "Compare each test separately per value of this variant, e.g. Platform"),
+				queryParam("basis_days", "Days in the basis window, before the sample window, defaults to 14").integer(),
+				queryParam("sample_days", "Days in the sample window, ending at the report end, defaults to 7").integer(),
+				queryParam("min_samples", "Fewest passing runs in each window for a test to be compared, defaults to 30").integer(),
+				queryParam("limit", "Maximum number of tests, defaults to 100").integer(),
+			}),
+			Response: durations.Report{},
 		},
 		{
 			EndpointPath:      "/api/tests/v2/runs",
@@ -2772,6 +3425,15 @@ func (s *Server) Serve() {
 			HandlerFunc:       s.jsonTestRunsAndOutputsFromBigQuery,
 			RateLimitRequests: 25,
 			RateLimitPeriod:   1 * time.Hour,
+			Params: []apiParam{
+				queryParam("test_id", "Test ID").required(),
+				queryParam("prow_job_run_ids", "Comma separated prow job run IDs"),
+				queryParam("prowjob_name", "Prow job name").repeated(),
+				queryParam("include_success", "Include passing runs").boolean(),
+				queryParam("start_date", "Start date, YYYY-MM-DD"),
+				queryParam("end_date", "End date, YYYY-MM-DD"),
+			},
+			Response: []apitype.TestOutputBigQuery{},
 		},
 		{
 			EndpointPath:      "/api/jobs/runs/disruption",
@@ -2781,6 +3443,11 @@ func (s *Server) Serve() {
 			HandlerFunc:       s.jsonBackendDisruptionByRun,
 			RateLimitRequests: 25,
 			RateLimitPeriod:   1 * time.Hour,
+			Params: []apiParam{
+				queryParam("job_run_names", "Comma separated prow job run IDs").required(),
+				queryParam("backend_name", "Only report this disruption backend"),
+			},
+			Response: apitype.BackendDisruptionRunsResult{},
 		},
 		{
 			EndpointPath: "/api/tests/durations",
@@ -2788,6 +3455,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestDurationsFromDB,
+			Params:       paramList(releaseParams, testParams, filterParams),
+			Response:     map[civil.Date]float64{},
 		},
 		{
 			EndpointPath: "/api/tests/capabilities",
@@ -2795,6 +3464,7 @@ func (s *Server) Serve() {
 			Capabilities: []string{ComponentReadinessCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestCapabilitiesFromDB,
+			Response:     []string{},
 		},
 		{
 			EndpointPath: "/api/tests/lifecycles",
@@ -2802,6 +3472,7 @@ func (s *Server) Serve() {
 			Capabilities: []string{ComponentReadinessCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonTestLifecyclesFromDB,
+			Response:     []string{},
 		},
 		{
 			EndpointPath: "/api/install",
@@ -2809,6 +3480,8 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonInstallReportFromDB,
+			Params:       optionalReleaseParams,
+			Response:     "", // a JSON document encoded as a string
 		},
 		{
 			EndpointPath: "/api/upgrade",
@@ -2816,24 +3489,31 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonUpgradeReportFromDB,
+			Params:       optionalReleaseParams,
+			Response:     "", // a JSON document encoded as a string
 		},
 		{
 			EndpointPath: "/api/releases",
 			Description:  "Reports on releases",
 			Capabilities: []string{},
 			HandlerFunc:  s.jsonReleasesReportFromDB,
+			Response:     apitype.Releases{},
 		},
 		{
 			EndpointPath: "/api/health/build_cluster/analysis",
 			Description:  "Analyzes build cluster health",
 			Capabilities: []string{LocalDBCapability, BuildClusterCapability},
 			HandlerFunc:  s.jsonBuildClusterHealthAnalysis,
+			Params:       paramList(optionalReleaseParams, []apiParam{queryParam("period", "Group by day or week")}),
+			Response:     map[string]apitype.BuildClusterHealthAnalysis{},
 		},
 		{
 			EndpointPath: "/api/health/build_cluster",
 			Description:  "Reports health of build cluster",
 			Capabilities: []string{LocalDBCapability, BuildClusterCapability},
 			HandlerFunc:  s.jsonBuildClusterHealth,
+			Params:       paramList(optionalReleaseParams, periodParams),
+			Response:     []apitype.BuildClusterHealth{},
 		},
 		{
 			EndpointPath: "/api/health",
@@ -2841,41 +3521,52 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    1 * time.Hour,
 			HandlerFunc:  s.jsonHealthReportFromDB,
+			Params:       releaseParams,
+			Response:     apitype.Health{},
 		},
 		{
 			EndpointPath: "/api/variants",
 			Description:  "Reports on variants",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonVariantsReportFromDB,
+			Params:       paramList(releaseParams, periodParams),
+			Response:     []apitype.Variant{},
 		},
 		{
 			EndpointPath: "/api/report_date",
 			Description:  "Displays report date",
 			HandlerFunc:  s.printReportDate,
+			Response:     map[string]string{},
 		},
 		{
 			EndpointPath: "/api/component_readiness",
 			Description:  "Reports component readiness",
 			Capabilities: []string{ComponentReadinessCapability},
 			HandlerFunc:  s.jsonComponentReport,
+			Params:       paramList(componentReportParams, exportParams),
+			Response:     componentreport.ComponentReport{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/test_details",
 			Description:  "Reports test details for component readiness",
 			Capabilities: []string{ComponentReadinessCapability},
 			HandlerFunc:  s.jsonComponentReportTestDetails,
+			Params:       componentReportParams,
+			Response:     testdetails.Report{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/variants",
 			Description:  "Reports test variants for component readiness from BigQuery",
 			Capabilities: []string{ComponentReadinessCapability},
 			HandlerFunc:  s.jsonComponentTestVariantsFromBigQuery,
+			Response:     componentreadiness.CacheVariants{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/views",
 			Description:  "Lists all predefined server-side views over ComponentReadiness data",
 			Capabilities: []string{ComponentReadinessCapability},
 			HandlerFunc:  s.jsonComponentReadinessViews,
+			Response:     []crview.View{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages",
@@ -2883,6 +3574,8 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonGetTriages,
+			Params:       paramList([]apiParam{queryParam("view", "View to link regressions to")}, listPaginationParams),
+			Response:     []models.Triage{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages",
@@ -2890,6 +3583,8 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPost},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonCreateTriage,
+			RequestBody:  models.Triage{},
+			Response:     models.Triage{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages/{id}",
@@ -2897,6 +3592,8 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonGetTriageByID,
+			Params:       paramList([]apiParam{queryParam("expand", "Comma separated fields to expand: regressions, symptoms")}, componentReportParams),
+			Response:     ExpandedTriage{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages/{id}",
@@ -2904,6 +3601,8 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPut},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonUpdateTriage,
+			RequestBody:  models.Triage{},
+			Response:     models.Triage{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages/{id}",
@@ -2911,6 +3610,7 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodDelete},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
 			HandlerFunc:  s.jsonDeleteTriage,
+			Response:     noContent{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages/{id}/matches",
@@ -2918,6 +3618,8 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonTriagePotentialMatchingRegressions,
+			Params:       componentReportParams,
+			Response:     []componentreadiness.PotentialMatchingRegression{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/triages/{id}/audit",
@@ -2925,12 +3627,73 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonGetTriageAuditDetails,
+			Response:     []componentreadiness.TriageAuditLog{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances",
+			Description:  "List intentional regression allowances, optionally filtered by release and test_id",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonGetRegressionAllowances,
+			Params: []apiParam{
+				queryParam("release", "Only list allowances for this release"),
+				queryParam("test_id", "Only list allowances for this test"),
+			},
+			Response: []models.RegressionAllowance{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances",
+			Description:  "Create intentional regression allowance",
+			Methods:      []string{http.MethodPost},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonCreateRegressionAllowance,
+			RequestBody:  models.RegressionAllowance{},
+			Response:     models.RegressionAllowance{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances/{id}",
+			Description:  "Get specific intentional regression allowance",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonGetRegressionAllowanceByID,
+			Response:     models.RegressionAllowance{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances/{id}",
+			Description:  "Update intentional regression allowance",
+			Methods:      []string{http.MethodPut},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonUpdateRegressionAllowance,
+			RequestBody:  models.RegressionAllowance{},
+			Response:     models.RegressionAllowance{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances/{id}",
+			Description:  "Delete intentional regression allowance",
+			Methods:      []string{http.MethodDelete},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonDeleteRegressionAllowance,
+			Response:     noContent{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/allowances/{id}/audit",
+			Description:  "Get audit logs for a given intentional regression allowance.",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonGetRegressionAllowanceAuditLogs,
+			Response:     []componentreadiness.RegressionAllowanceAuditLog{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/regressions",
 			Description:  "List component readiness test regressions. Supports view OR release query parameters (not both). Optional test parameter filters by exact test name.",
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonGetRegressions,
+			Params: paramList([]apiParam{
+				queryParam("view", "Only list regressions in this view"),
+				queryParam("release", "Only list regressions in this release"),
+				queryParam("test", "Only list regressions of this test"),
+			}, listPaginationParams, exportParams),
+			Response: []models.TestRegression{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/regressions/{id}",
@@ -2938,6 +3701,7 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonGetRegressionByID,
+			Response:     models.TestRegression{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/regressions/{id}/matches",
@@ -2945,72 +3709,131 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonRegressionPotentialMatchingTriages,
+			Response:     []componentreadiness.PotentialMatchingTriage{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/snapshots",
+			Description:  "List daily component report snapshots, optionally filtered by view",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonListComponentReportSnapshots,
+			Params:       []apiParam{queryParam("view", "Only list snapshots of this view")},
+			Response:     []componentreadiness.ReportSnapshotInfo{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/snapshots/diff",
+			Description:  "Compare a view's component report snapshots between from_date and to_date (defaults to the latest)",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonDiffComponentReportSnapshots,
+			Params: []apiParam{
+				queryParam("view", "View of the snapshots").required(),
+				queryParam("from_date", "Date of the earlier snapshot, YYYY-MM-DD").required(),
+				queryParam("to_date", "Date of the later snapshot, YYYY-MM-DD, defaults to the latest"),
+			},
+			Response: crsnapshot.Diff{},
+		},
+		{
+			EndpointPath: "/api/component_readiness/snapshots/{date}",
+			Description:  "Get a view's component report snapshot for a date (YYYY-MM-DD)",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{LocalDBCapability, ComponentReadinessCapability},
+			HandlerFunc:  s.jsonGetComponentReportSnapshot,
+			Params:       []apiParam{queryParam("view", "View of the snapshot").required()},
+			Response:     crsnapshot.Snapshot{},
 		},
 		{
 			EndpointPath: "/api/component_readiness/bugs",
 			Description:  "Create Jira Bugs from component readiness",
 			Capabilities: []string{WriteEndpointsCapability, ComponentReadinessCapability},
 			HandlerFunc:  s.jsonFileJiraBug,
+			RequestBody:  util.FileBugRequest{},
+			Response:     util.FileBugResponse{},
 		},
 		{
 			EndpointPath: "/api/capabilities",
 			Description:  "Lists available API capabilities",
 			Capabilities: []string{},
 			HandlerFunc:  s.jsonCapabilitiesReport,
+			Response:     []string{},
 		},
 		{
 			EndpointPath: "/api/releases/health",
 			Description:  "Reports health of releases",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonReleaseHealthReport,
+			Params:       releaseParams,
+			Response:     []apitype.ReleaseHealthReport{},
 		},
 		{
 			EndpointPath: "/api/releases/tags/events",
 			Description:  "Lists events for release tags",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonReleaseTagsEvent,
+			Params:       paramList(releaseParams, filterParams, iso8601RangeParams),
+			Response:     []apitype.CalendarEvent{},
 		},
 		{
 			EndpointPath: "/api/releases/tags",
 			Description:  "Lists release tags",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonReleaseTagsReport,
+			Params:       paramList(optionalReleaseParams, filterParams),
+			Response:     []api.APIReleaseTag{},
 		},
 		{
 			EndpointPath: "/api/releases/pull_requests",
 			Description:  "Reports pull requests for releases",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonReleasePullRequestsReport,
+			Params:       paramList(optionalReleaseParams, filterParams),
+			Response:     []models.ReleasePullRequest{},
 		},
 		{
 			EndpointPath: "/api/releases/job_runs",
 			Description:  "Lists job runs for releases",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonListPayloadJobRuns,
+			Params:       paramList(optionalReleaseParams, filterParams),
+			Response:     []models.ReleaseJobRun{},
 		},
 		{
 			EndpointPath: "/api/incidents",
 			Description:  "Reports incident events",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonIncidentEvent,
+			Params:       iso8601RangeParams,
+			Response:     []apitype.CalendarEvent{},
 		},
 		{
 			EndpointPath: "/api/releases/test_failures",
 			Description:  "Analysis of test failures for releases",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonGetPayloadAnalysis,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("stream", "Payload stream, e.g. nightly").required(),
+				queryParam("arch", "Payload architecture, e.g. amd64").required(),
+			}, filterParams),
+			Response: []apitype.TestFailureAnalysis{},
 		},
 		{
 			EndpointPath: "/api/payloads/test_failures",
 			Description:  "Analysis of test failures in payloads",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonGetPayloadTestFailures,
+			Params:       []apiParam{queryParam("payload", "Payload tag").required()},
+			Response:     []apitype.TestFailureAnalysis{},
 		},
 		{
 			EndpointPath: "/api/payloads/diff",
 			Description:  "Reports pull requests that differ between payloads",
 			Capabilities: []string{LocalDBCapability},
 			HandlerFunc:  s.jsonPayloadDiff,
+			Params: []apiParam{
+				queryParam("fromPayload", "Earlier payload tag").required(),
+				queryParam("toPayload", "Later payload tag").required(),
+			},
+			Response: []models.ReleasePullRequest{},
 		},
 		{
 			EndpointPath: "/api/feature_gates",
@@ -3018,6 +3841,22 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    4 * time.Hour,
 			HandlerFunc:  s.jsonFeatureGates,
+			Params:       paramList(releaseParams, filterParams),
+			Response:     []apitype.FeatureGate{},
+		},
+		{
+			// registered before /api/feature_gates/{feature_gate}, which would otherwise match it
+			EndpointPath: "/api/feature_gates/promotion_report",
+			Description:  "Reports the promotion readiness of every TechPreview and DevPreview feature gate of a release, with the variants blocking each and the changes since a week before",
+			Capabilities: []string{LocalDBCapability},
+			CacheTime:    4 * time.Hour,
+			HandlerFunc:  s.jsonFeatureGatePromotionReport,
+			Params: paramList(releaseParams, []apiParam{
+				queryParam("date", "Return the report stored by sippy feature-gates report on this day (YYYY-MM-DD), or the latest before it, instead of generating one"),
+				queryParam("compare_to", "Compare with the stored report of this day (YYYY-MM-DD), or the latest before it, defaults to a week before the report"),
+				queryParam("format", "Response format: json (default) or md"),
+			}),
+			Response: featuregatepromotion.ReleaseReport{},
 		},
 		{
 			EndpointPath: "/api/feature_gates/{feature_gate}",
@@ -3025,36 +3864,43 @@ func (s *Server) Serve() {
 			Capabilities: []string{LocalDBCapability},
 			CacheTime:    4 * time.Hour,
 			HandlerFunc:  s.jsonFeatureGateDetail,
+			Params:       releaseParams,
+			Response:     apitype.FeatureGate{},
 		},
 		{
 			EndpointPath: "/api/chat",
 			Description:  "HTTP proxy for REST API requests to sippy-chat service",
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
 			EndpointPath: "/api/chat/stream",
 			Description:  "Websocket proxy for chat API requests to sippy-chat service (supports HTTP and WebSocket)",
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
 			EndpointPath: "/api/chat/personas",
 			Description:  "Proxy for listing personas from sippy-chat service.",
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
 			EndpointPath: "/api/chat/models",
 			Description:  "Proxy for listing available models from sippy-chat service.",
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
 			EndpointPath: "/api/chat/prompts",
 			Description:  "Proxy for listing available prompt templates from sippy-chat service.",
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
 			EndpointPath: "/api/chat/prompts/render",
@@ -3062,20 +3908,41 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodPost},
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.handleChatProxy,
+			Response:     externalResponse{},
 		},
 		{
-			EndpointPath: "/api/chat/ratings",
-			Description:  "Create a chat rating record",
-			Methods:      []string{http.MethodPost},
-			Capabilities: []string{LocalDBCapability, ChatCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonCreateChatRating,
+			EndpointPath:   "/api/chat/ratings",
+			Description:    "Create a chat rating record",
+			Methods:        []string{http.MethodPost},
+			Capabilities:   []string{LocalDBCapability, ChatCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonCreateChatRating,
+			RequestBody:    models.ChatRating{},
+			Response:       models.ChatRating{},
+			ResponseStatus: http.StatusCreated,
 		},
 		{
 			EndpointPath: "/api/chat/conversations",
-			Description:  "Create a new chat conversation",
-			Methods:      []string{http.MethodPost},
-			Capabilities: []string{ChatCapability, WriteEndpointsCapability},
-			HandlerFunc:  s.jsonCreateChatConversation,
+			Description:  "List chat conversations, newest first, or search their messages with text search, most relevant first",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{ChatCapability},
+			HandlerFunc:  s.jsonListChatConversations,
+			Params: paramList([]apiParam{
+				queryParam("user", "Only conversations shared by this user, defaults to the requesting user"),
+				queryParam("all_users", "List conversations shared by any user").boolean(),
+				queryParam("tag", "Only conversations with this tag"),
+				queryParam("q", "Web search style text query, e.g. \"etcd leader\" -metal, matched against message content"),
+			}, paginationParams),
+			Response: paginated[chatconversation.Summary]{},
+		},
+		{
+			EndpointPath:   "/api/chat/conversations",
+			Description:    "Create a new chat conversation",
+			Methods:        []string{http.MethodPost},
+			Capabilities:   []string{ChatCapability, WriteEndpointsCapability},
+			HandlerFunc:    s.jsonCreateChatConversation,
+			RequestBody:    CreateChatConversationRequest{},
+			Response:       ChatConversationResponse{},
+			ResponseStatus: http.StatusCreated,
 		},
 		{
 			EndpointPath: "/api/chat/conversations/{id}",
@@ -3083,91 +3950,48 @@ func (s *Server) Serve() {
 			Methods:      []string{http.MethodGet},
 			Capabilities: []string{ChatCapability},
 			HandlerFunc:  s.jsonGetChatConversation,
+			Response:     models.ChatConversation{},
+		},
+		{
+			EndpointPath: "/api/chat/conversations/{id}/tags",
+			Description:  "Replace the tags of a chat conversation, which only the user who shared it can do",
+			Methods:      []string{http.MethodPut},
+			Capabilities: []string{ChatCapability, WriteEndpointsCapability},
+			HandlerFunc:  s.jsonSetChatConversationTags,
+			RequestBody:  ChatConversationTags{},
+			Response:     ChatConversationTags{},
+		},
+		{
+			EndpointPath: "/api/chat/conversations/{id}/export",
+			Description:  "Download a chat conversation as markdown",
+			Methods:      []string{http.MethodGet},
+			Capabilities: []string{ChatCapability},
+			HandlerFunc:  s.jsonExportChatConversation,
+			Response:     markdownResponse{},
+		},
+		{
+			EndpointPath:      "/api/cache/entries",
+			Description:       "Lists cached entries for a component readiness view or key prefix, with their remaining TTL",
+			Methods:           []string{http.MethodGet},
+			Capabilities:      []string{},
+			HandlerFunc:       s.jsonListCacheEntries,
+			RateLimitRequests: 25,
+			RateLimitPeriod:   1 * time.Hour,
+			Params:            paramList(cacheEntryParams, []apiParam{queryParam("limit", "Maximum number of entries, defaults to 100").integer()}),
+			Response:          []cacheEntry{},
+		},
+		{
+			EndpointPath: "/api/cache/entries",
+			Description:  "Purges cached entries for a component readiness view, key prefix or key",
+			Methods:      []string{http.MethodDelete},
+			Capabilities: []string{WriteEndpointsCapability},
+			HandlerFunc:  s.jsonDeleteCacheEntries,
+			Params:       cacheEntryParams,
+			Response:     cachePurgeResult{},
 		},
 	}
 
-	for _, ep := range endpoints {
-		fn := ep.HandlerFunc
-		// Apply rate limiting first (innermost middleware)
-		// This ensures cached responses bypass rate limiting
-		if ep.RateLimitRequests > 0 && ep.RateLimitPeriod > 0 {
-			fn = s.rateLimit(ep.EndpointPath, ep.RateLimitRequests, ep.RateLimitPeriod, fn)
-		}
-		// Apply caching second - wraps rate-limited handler
-		// Cache hits return early without calling the rate-limited handler
-		if ep.CacheTime > 0 {
-			fn = s.cached(ep.CacheTime, fn)
-		}
-		// Apply capability checks last (outermost middleware)
-		if len(ep.Capabilities) > 0 {
-			fn = s.requireCapabilities(ep.Capabilities, fn)
-		}
-
-		// Register endpoint with proper HTTP methods
-		route := router.HandleFunc(ep.EndpointPath, fn)
-		if len(ep.Methods) > 0 {
-			route.Methods(ep.Methods...)
-		}
-	}
-
-	// Catch-all fallback: serve static files for any unmatched routes, or redirect to sippy-ng
-	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Try to open the file from static filesystem (embedded FS keeps directory structure)
-		filePath := "static" + r.URL.Path
-		if _, err := s.static.Open(filePath); err != nil {
-			// File doesn't exist in static, redirect to sippy-ng
-			if r.URL.Path == "/" {
-				http.Redirect(w, r, "/sippy-ng/", http.StatusMovedPermanently)
-			} else {
-				http.NotFound(w, r)
-			}
-			return
-		}
-		// File exists, rewrite path to include /static prefix and serve
-		r.URL.Path = "/static" + r.URL.Path
-		http.FileServer(http.FS(s.static)).ServeHTTP(w, r)
-	})
-
-	var handler http.Handler = router
-	handler = logRequestHandler(handler)
-
-	// Middleware for http metrics
-	metricsMiddleware := middleware.New(middleware.Config{
-		Recorder: metrics.NewRecorder(metrics.Config{
-			DurationBuckets: []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
-		}),
-	})
-	handler = middlewarestd.Handler("", metricsMiddleware, handler)
-	cors := handlers.CORS(
-		handlers.AllowedOrigins([]string{s.corsAllowedOrigin}),
-		handlers.AllowedMethods([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}),
-		handlers.AllowedHeaders([]string{"Content-Type", "X-Forwarded-User", "X-Forwarded-For", "X-Real-IP", "Authorization"}))
-
-	// Store a pointer to the HTTP server for later retrieval.
-	s.httpServer = &http.Server{
-		Addr:              s.listenAddr,
-		Handler:           cors(handler),
-		ReadHeaderTimeout: 10 * time.Second,
-	}
-
-	log.Infof("Serving reports on %s ", s.listenAddr)
-
-	// Handle graceful shutdown on SIGINT/SIGTERM so coverage data is flushed
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		sig := <-sigCh
-		log.Infof("Received %s, shutting down server...", sig)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := s.httpServer.Shutdown(ctx); err != nil {
-			log.WithError(err).Error("Error during server shutdown")
-		}
-	}()
-
-	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
-		log.WithError(err).Error("Server exited")
-	}
+	return endpoints
 }
 
 type statusCapturingResponseWriter struct {
@@ -3192,7 +4016,7 @@ func logRequestHandler(h http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		// add request context for any BQ queries that may be made
 		bqCtx := bqlabel.RequestContext{
-			User:    getUserForRequest(r),
+			User:    api.GetUserForRequest(r),
 			IP:      getRequestorIP(r),
 			URIPath: r.URL.Path,
 		}
@@ -3264,6 +4088,10 @@ func respondFromCache(content []byte, w http.ResponseWriter, r *http.Request) er
 	for k, v := range apiResponse.Headers {
 		w.Header()[k] = v
 	}
+	// rate limits were reported to the request that populated the cache, and do not apply to this one
+	for _, k := range ratelimit.Headers {
+		w.Header().Del(k)
+	}
 	w.Header().Set("X-Sippy-Cached", "true")
 	w.WriteHeader(http.StatusOK)
 
@@ -147,3 +147,62 @@ func TestEncodeDefaultHighRisk(t *testing.T) {
 		t.Fatal("Invalid overall risk analysis after decoding")
 	}
 }
+
+func TestPaginate(t *testing.T) {
+	rows := []int{1, 2, 3, 4, 5}
+
+	if got, ok := paginate(rows, nil).([]int); !ok || len(got) != len(rows) {
+		t.Fatalf("expected unpaginated rows, got %v", got)
+	}
+
+	result, ok := paginate(rows, &apitype.Pagination{PerPage: 2, Page: 2}).(*apitype.PaginationResult)
+	if !ok {
+		t.Fatal("expected a pagination result")
+	}
+	if page := result.Rows.([]int); len(page) != 1 || page[0] != 5 || result.TotalRows != 5 {
+		t.Fatalf("unexpected last page: %+v", result)
+	}
+
+	result = paginate(rows, &apitype.Pagination{PerPage: 2, Page: 3}).(*apitype.PaginationResult)
+	if page := result.Rows.([]int); len(page) != 0 {
+		t.Fatalf("expected an empty page past the end, got %v", page)
+	}
+}
+
+func TestDiffComponentReportSnapshotsValidatesDates(t *testing.T) {
+	s := &Server{}
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing from_date", query: "view=4.20-main"},
+		{name: "malformed from_date", query: "view=4.20-main&from_date=yesterday"},
+		{name: "impossible from_date", query: "view=4.20-main&from_date=2026-13-45"},
+		{name: "malformed to_date", query: "view=4.20-main&from_date=2026-03-01&to_date=yesterday"},
+		{name: "impossible to_date", query: "view=4.20-main&from_date=2026-03-01&to_date=2026-02-30"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/component_readiness/snapshots/diff?"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			s.jsonDiffComponentReportSnapshots(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestFeatureGatePromotionReportValidatesDates(t *testing.T) {
+	s := &Server{}
+	for _, query := range []string{"date=yesterday", "date=2026-13-45", "compare_to=last-week", "compare_to=2026-02-30"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/feature_gates/promotion_report?release=4.20&"+query, nil)
+			rec := httptest.NewRecorder()
+			s.jsonFeatureGatePromotionReport(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
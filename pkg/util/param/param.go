@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -67,12 +68,16 @@ var paramRegexp = map[string]*regexp.Regexp{
 	"samplePayloadTag": nameRegexp,
 	"view":             nameRegexp,                                  // component readiness view name
 	"dataSource":       regexp.MustCompile(`^(bigquery|postgres)$`), // data source for CR queries
+	"from_date":        dateRegexp,                                  // YYYY-MM-DD format, for report snapshot diffs
+	"to_date":          dateRegexp,                                  // YYYY-MM-DD format, for report snapshot diffs
 	// jobartifacts params
 	"prowJobRuns":        regexp.MustCompile(`^\d+(,\d+)*$`), // comma-separated integers
 	"pathGlob":           nonEmptyRegex,                      // a glob can be anything
 	"maxJobFilesToScan ": uintRegexp,
 	"textContains":       nonEmptyRegex, // text search can look for anything
 	"textRegex":          nonEmptyRegex, // regex could be just about anything
+	"jq":                 nonEmptyRegex, // jq expression to run against JSON artifacts
+	"xpath":              nonEmptyRegex, // xpath expression to run against XML artifacts
 	"maxFileMatches":     uintRegexp,
 	"beforeContext":      uintRegexp,
 	"afterContext":       uintRegexp,
@@ -81,9 +86,20 @@ var paramRegexp = map[string]*regexp.Regexp{
 	"includeOutputs": boolRegexp,
 	// pull request test results params
 	"limit": uintRegexp,
+	// flaky test params
+	"variant": regexp.MustCompile(`^[\w.:-]+$`),
+	// duration regression params
+	"group_by": wordRegexp,
 	// disruption params
 	"job_run_names": regexp.MustCompile(`^\d+(,\d+)*$`),
 	"backend_name":  regexp.MustCompile(`^[\w-]+$`),
+	// feature gate promotion report params
+	"date":       dateRegexp, // YYYY-MM-DD format
+	"compare_to": dateRegexp, // YYYY-MM-DD format
+	// chat conversation params
+	"user": regexp.MustCompile(`^[\w.@+-]+$`),
+	"tag":  nonEmptyRegex, // tags can be anything, so always parameterize in sql
+	"q":    nonEmptyRegex, // text search query, parsed by websearch_to_tsquery
 }
 
 // SafeRead returns the value of a query parameter only if it matches the given regexp.
@@ -150,3 +166,23 @@ func ReadBool(req *http.Request, name string, defaultValue bool) (bool, error) {
 
 	return value == "true", nil
 }
+
+const dateLayout = "2006-01-02"
+
+// ReadDate returns the value of a query parameter only if it is a valid YYYY-MM-DD date.
+// If the param is not present or empty, it returns "" and nil.
+// If the value is invalid, including dates that do not exist such as 2026-02-30, it returns "" and an error.
+func ReadDate(req *http.Request, name string) (string, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return "", nil
+	}
+
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		err := fmt.Errorf("invalid value for %q param: %q (expected YYYY-MM-DD)", name, value)
+		log.Warn(err)
+		return "", err
+	}
+
+	return value, nil
+}
@@ -2734,7 +2734,7 @@ func TestVariantsSnapshot(t *testing.T) {
 
 	log := logrus.WithField("test", "TestVariantsSnapshot")
 
-	snapshot := NewVariantSnapshot(cfg, views.ComponentReadiness, syntheticReleaseJobOverrides, log)
+	snapshot := NewVariantSnapshot(cfg, views.ComponentReadiness, syntheticReleaseJobOverrides, nil, log)
 
 	newVariants, err := snapshot.Identify()
 	assert.NoError(t, err)
@@ -3091,6 +3091,8 @@ func TestSetOS(t *testing.T) {
 		},
 	}
 
+	rules, err := DefaultClassificationRules()
+	require.NoError(t, err)
 	jLog := logrus.WithField("test", "TestSetOS")
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3098,7 +3100,7 @@ func TestSetOS(t *testing.T) {
 			for k, v := range tt.variants {
 				variants[k] = v
 			}
-			setOS(jLog, variants, tt.jobName)
+			rules.classify(jLog, variants, tt.jobName)
 			assert.Equal(t, tt.expectedOS, variants[VariantOS])
 		})
 	}
@@ -0,0 +1,313 @@
+package variantregistry
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultRulesYAML holds the rules used to classify OCP jobs when no rules file is given.
+//
+//go:embed ocp_rules.yaml
+var defaultRulesYAML []byte
+
+var (
+	defaultRulesOnce sync.Once
+	defaultRules     *ClassificationRules
+	defaultRulesErr  error
+)
+
+// ClassificationRules determine job variants from job names, and how they are reconciled with the
+// variants reported in a job run's cluster-data.json. Release variants are always derived from the job
+// name and sippy config before any rules are applied, so rules may match on them.
+type ClassificationRules struct {
+	// Classifiers are applied in order, so later classifiers may match on variants set by earlier ones.
+	Classifiers []Classifier     `yaml:"classifiers"`
+	ClusterData ClusterDataRules `yaml:"clusterData"`
+}
+
+// Classifier sets variants from the first of its rules that matches a job, or its default if none do.
+type Classifier struct {
+	Name string `yaml:"name"`
+	// Ignore lists substrings replaced with a single dash before matching, for uses of a term that do not
+	// mean what the rules look for (e.g. "-multi-network-" is not a multi-arch job).
+	Ignore []string `yaml:"ignore,omitempty"`
+	Rules  []Rule   `yaml:"rules"`
+	// Default variants are set when no rule matches.
+	Default map[string]string `yaml:"default,omitempty"`
+	// WarnUnmatched logs a warning when no rule matches and there is no default.
+	WarnUnmatched bool `yaml:"warnUnmatched,omitempty"`
+}
+
+// Rule sets variants when all of its conditions hold. Job name conditions are matched against the
+// lowercased job name.
+type Rule struct {
+	// Contains requires the job name to contain every one of these substrings.
+	Contains []string `yaml:"contains,omitempty"`
+	// Regex requires the job name to match this regular expression.
+	Regex string `yaml:"regex,omitempty"`
+	// Variants requires previously set variants to have these values, where a missing variant is empty.
+	Variants map[string]string `yaml:"variants,omitempty"`
+	// MinRelease and BeforeRelease require the job's release to be known, and at least or before these versions.
+	MinRelease    string `yaml:"minRelease,omitempty"`
+	BeforeRelease string `yaml:"beforeRelease,omitempty"`
+	// Set holds the variants to set when the rule matches.
+	Set map[string]string `yaml:"set"`
+
+	regex         *regexp.Regexp
+	minRelease    *version.Version
+	beforeRelease *version.Version
+}
+
+// ClusterDataRules control how variants read from cluster-data.json are merged with those from the job name.
+// On a mismatch the cluster data wins, unless the variant is listed in PreferJobName.
+type ClusterDataRules struct {
+	// Ignore lists cluster data values that vary by job run rather than by job, and are unsuited for variants.
+	Ignore []string `yaml:"ignore,omitempty"`
+	// Lowercase lists cluster data values to lowercase before comparing.
+	Lowercase     []string            `yaml:"lowercase,omitempty"`
+	PreferJobName []JobNamePreference `yaml:"preferJobName,omitempty"`
+}
+
+// JobNamePreference keeps the job name value of a variant when the cluster data disagrees.
+type JobNamePreference struct {
+	Variant string `yaml:"variant"`
+	// Values limits the preference to these job name values, when set.
+	Values []string `yaml:"values,omitempty"`
+	// FileFromRelease trusts the cluster data from this release on, when set. The job name is still
+	// preferred for older releases and when the release is unknown.
+	FileFromRelease string `yaml:"fileFromRelease,omitempty"`
+
+	fileFromRelease *version.Version
+}
+
+// DefaultClassificationRules returns the built-in OCP classification rules.
+func DefaultClassificationRules() (*ClassificationRules, error) {
+	defaultRulesOnce.Do(func() {
+		defaultRules, defaultRulesErr = ParseClassificationRules(defaultRulesYAML)
+	})
+	return defaultRules, defaultRulesErr
+}
+
+// LoadClassificationRules reads classification rules from a YAML file, or returns the built-in
+// rules if path is empty.
+func LoadClassificationRules(path string) (*ClassificationRules, error) {
+	if path == "" {
+		return DefaultClassificationRules()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read variant rules from %s: %w", path, err)
+	}
+	rules, err := ParseClassificationRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid variant rules in %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseClassificationRules parses and validates YAML classification rules.
+func ParseClassificationRules(data []byte) (*ClassificationRules, error) {
+	rules := &ClassificationRules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *ClassificationRules) compile() error {
+	names := map[string]bool{}
+	for i := range r.Classifiers {
+		c := &r.Classifiers[i]
+		if c.Name == "" {
+			return fmt.Errorf("classifier %d has no name", i)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("duplicate classifier %s", c.Name)
+		}
+		names[c.Name] = true
+		for j := range c.Rules {
+			if err := c.Rules[j].compile(); err != nil {
+				return fmt.Errorf("classifier %s rule %d: %w", c.Name, j, err)
+			}
+		}
+	}
+	for i := range r.ClusterData.PreferJobName {
+		p := &r.ClusterData.PreferJobName[i]
+		if p.Variant == "" {
+			return fmt.Errorf("cluster data preference %d has no variant", i)
+		}
+		if p.FileFromRelease != "" {
+			v, err := version.NewVersion(p.FileFromRelease)
+			if err != nil {
+				return fmt.Errorf("cluster data preference for %s: invalid fileFromRelease: %w", p.Variant, err)
+			}
+			p.fileFromRelease = v
+		}
+	}
+	return nil
+}
+
+func (rule *Rule) compile() error {
+	if len(rule.Set) == 0 {
+		return fmt.Errorf("rule sets no variants")
+	}
+	if len(rule.Contains) == 0 && rule.Regex == "" && len(rule.Variants) == 0 &&
+		rule.MinRelease == "" && rule.BeforeRelease == "" {
+		return fmt.Errorf("rule has no conditions, use the classifier default instead")
+	}
+	for _, s := range rule.Contains {
+		if s != strings.ToLower(s) {
+			return fmt.Errorf("contains %q must be lowercase, job names are lowercased before matching", s)
+		}
+	}
+	var err error
+	if rule.Regex != "" {
+		if rule.regex, err = regexp.Compile(rule.Regex); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	if rule.MinRelease != "" {
+		if rule.minRelease, err = version.NewVersion(rule.MinRelease); err != nil {
+			return fmt.Errorf("invalid minRelease: %w", err)
+		}
+	}
+	if rule.BeforeRelease != "" {
+		if rule.beforeRelease, err = version.NewVersion(rule.BeforeRelease); err != nil {
+			return fmt.Errorf("invalid beforeRelease: %w", err)
+		}
+	}
+	return nil
+}
+
+// classify applies each classifier in order to the job's variants.
+func (r *ClassificationRules) classify(jLog logrus.FieldLogger, variants map[string]string, jobName string) {
+	for i := range r.Classifiers {
+		r.Classifiers[i].apply(jLog, variants, jobName)
+	}
+}
+
+func (c *Classifier) apply(jLog logrus.FieldLogger, variants map[string]string, jobName string) {
+	jobNameLower := strings.ToLower(jobName)
+	for _, ignore := range c.Ignore {
+		jobNameLower = strings.ReplaceAll(jobNameLower, ignore, "-")
+	}
+
+	// the release version is only looked up if a rule needs it, as doing so warns when it is unknown
+	var release *version.Version
+	releaseLookedUp := false
+	releaseVersion := func() *version.Version {
+		if !releaseLookedUp {
+			release = releaseVersionFromVariants(jLog, variants)
+			releaseLookedUp = true
+		}
+		return release
+	}
+
+	for i := range c.Rules {
+		if c.Rules[i].matches(jobNameLower, variants, releaseVersion) {
+			setVariants(variants, c.Rules[i].Set)
+			return
+		}
+	}
+	if len(c.Default) > 0 {
+		setVariants(variants, c.Default)
+		return
+	}
+	if c.WarnUnmatched {
+		jLog.WithField("jobName", jobName).Warnf("unable to determine %s from job name", c.Name)
+	}
+}
+
+func (rule *Rule) matches(jobNameLower string, variants map[string]string, releaseVersion func() *version.Version) bool {
+	for _, s := range rule.Contains {
+		if !strings.Contains(jobNameLower, s) {
+			return false
+		}
+	}
+	if rule.regex != nil && !rule.regex.MatchString(jobNameLower) {
+		return false
+	}
+	for k, v := range rule.Variants {
+		if variants[k] != v {
+			return false
+		}
+	}
+	if rule.minRelease != nil || rule.beforeRelease != nil {
+		release := releaseVersion()
+		if release == nil {
+			return false
+		}
+		if rule.minRelease != nil && release.LessThan(rule.minRelease) {
+			return false
+		}
+		if rule.beforeRelease != nil && !release.LessThan(rule.beforeRelease) {
+			return false
+		}
+	}
+	return true
+}
+
+func setVariants(variants, values map[string]string) {
+	for k, v := range values {
+		variants[k] = v
+	}
+}
+
+func (d *ClusterDataRules) ignored(variant string) bool {
+	for _, v := range d.Ignore {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *ClusterDataRules) lowercased(variant string) bool {
+	for _, v := range d.Lowercase {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+// preferJobName reports whether the job name value of a variant should be kept over a mismatching
+// value from the cluster data.
+func (d *ClusterDataRules) preferJobName(jLog logrus.FieldLogger, variant, jobNameValue string, variants map[string]string) bool {
+	for _, p := range d.PreferJobName {
+		if p.Variant != variant {
+			continue
+		}
+		if len(p.Values) > 0 {
+			matched := false
+			for _, v := range p.Values {
+				if v == jobNameValue {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		if p.fileFromRelease != nil {
+			release := releaseVersionFromVariants(jLog, variants)
+			if release != nil && release.GreaterThanOrEqual(p.fileFromRelease) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
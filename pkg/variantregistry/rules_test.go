@@ -0,0 +1,201 @@
+package variantregistry
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/openshift/sippy/pkg/apis/config/v1"
+)
+
+func TestDefaultClassificationRules(t *testing.T) {
+	rules, err := DefaultClassificationRules()
+	require.NoError(t, err)
+	assert.NotEmpty(t, rules.Classifiers)
+}
+
+func TestParseClassificationRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		yaml        string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			yaml: `
+classifiers:
+  - name: Platform
+    rules:
+      - contains: ["-aws"]
+        set: {Platform: aws}
+      - regex: "-(gcp|gce)-"
+        set: {Platform: gcp}
+    default: {Platform: unknown}
+`,
+		},
+		{
+			name: "missing name",
+			yaml: `
+classifiers:
+  - rules:
+      - contains: ["-aws"]
+        set: {Platform: aws}
+`,
+			expectedErr: "classifier 0 has no name",
+		},
+		{
+			name: "rule without conditions",
+			yaml: `
+classifiers:
+  - name: Platform
+    rules:
+      - set: {Platform: aws}
+`,
+			expectedErr: "rule has no conditions",
+		},
+		{
+			name: "uppercase substring never matches",
+			yaml: `
+classifiers:
+  - name: Platform
+    rules:
+      - contains: ["-AWS"]
+        set: {Platform: aws}
+`,
+			expectedErr: "must be lowercase",
+		},
+		{
+			name: "invalid regex",
+			yaml: `
+classifiers:
+  - name: Platform
+    rules:
+      - regex: "-(aws"
+        set: {Platform: aws}
+`,
+			expectedErr: "invalid regex",
+		},
+		{
+			name: "invalid release",
+			yaml: `
+classifiers:
+  - name: Network
+    rules:
+      - minRelease: "four"
+        set: {Network: ovn}
+`,
+			expectedErr: "invalid minRelease",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseClassificationRules([]byte(tt.yaml))
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestCustomClassificationRules(t *testing.T) {
+	rules, err := ParseClassificationRules([]byte(`
+classifiers:
+  - name: Platform
+    ignore: ["-awsome-"]
+    rules:
+      - contains: ["-aws"]
+        set: {Platform: aws}
+      - regex: "-(gcp|gce)-"
+        set: {Platform: gcp}
+    default: {Platform: other}
+  - name: Network
+    rules:
+      - contains: ["-ovn"]
+        set: {Network: ovn}
+      - variants: {Platform: gcp}
+        minRelease: "4.12"
+        set: {Network: ovn}
+      - beforeRelease: "4.12"
+        set: {Network: sdn}
+  - name: JobTier
+    rules:
+      - contains: ["-hidden"]
+        set: {JobTier: hidden}
+clusterData:
+  preferJobName:
+    - variant: Network
+      fileFromRelease: "4.20"
+`))
+	require.NoError(t, err)
+	loader := OCPVariantLoader{config: &v1.SippyConfig{}, rules: rules}
+	jLog := logrus.WithField("test", "TestCustomClassificationRules")
+
+	tests := []struct {
+		job          string
+		variantsFile map[string]string
+		expected     map[string]string
+	}{
+		{
+			job:      "periodic-ci-openshift-release-master-nightly-4.18-e2e-aws-ovn",
+			expected: map[string]string{VariantPlatform: "aws", VariantNetwork: "ovn", VariantJobTier: "candidate"},
+		},
+		{
+			job:      "periodic-ci-openshift-release-master-nightly-4.18-e2e-gce-hidden",
+			expected: map[string]string{VariantPlatform: "gcp", VariantNetwork: "ovn", VariantJobTier: "hidden"},
+		},
+		{
+			job:      "periodic-ci-openshift-release-master-nightly-4.11-e2e-awsome-upgrade",
+			expected: map[string]string{VariantPlatform: "other", VariantNetwork: "sdn", VariantJobTier: "candidate"},
+		},
+		{
+			// the job name is preferred for network before 4.20
+			job:          "periodic-ci-openshift-release-master-nightly-4.18-e2e-aws-ovn",
+			variantsFile: map[string]string{VariantNetwork: "sdn"},
+			expected:     map[string]string{VariantPlatform: "aws", VariantNetwork: "ovn", VariantJobTier: "candidate"},
+		},
+		{
+			job:          "periodic-ci-openshift-release-master-nightly-4.20-e2e-aws-ovn",
+			variantsFile: map[string]string{VariantNetwork: "sdn"},
+			expected:     map[string]string{VariantPlatform: "aws", VariantNetwork: "sdn", VariantJobTier: "candidate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.job, func(t *testing.T) {
+			variants := loader.CalculateVariantsForJob(jLog, tt.job, tt.variantsFile)
+			for k, v := range tt.expected {
+				assert.Equal(t, v, variants[k], "variant %s", k)
+			}
+		})
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	oldVariants := JobVariants{
+		"job1": {"Platform": "aws", "Network": "ovn"},
+		"job2": {"Platform": "gcp"},
+	}
+	newVariants := JobVariants{
+		"job1": {"Platform": "gcp", "Topology": "ha"},
+		"job3": {"Platform": "metal"},
+	}
+
+	changes := DiffSnapshots(oldVariants, newVariants)
+	descriptions := make([]string, 0, len(changes))
+	for _, c := range changes {
+		descriptions = append(descriptions, c.Job+": "+c.Description())
+	}
+	assert.Equal(t, []string{
+		"job1: removed Network:ovn",
+		"job1: changed Platform:aws to Platform:gcp",
+		"job1: added Topology:ha",
+		"job2: removed Platform:gcp",
+		"job3: added Platform:metal",
+	}, descriptions)
+
+	assert.Empty(t, DiffSnapshots(oldVariants, oldVariants))
+}
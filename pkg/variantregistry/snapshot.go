@@ -1,7 +1,9 @@
 package variantregistry
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"sort"
 	"strings"
@@ -21,21 +23,25 @@ type VariantSnapshot struct {
 	config                       *v1.SippyConfig
 	views                        []crview.View
 	syntheticReleaseJobOverrides *releaseoverride.SyntheticReleaseOverrides
+	rules                        *ClassificationRules
 	log                          logrus.FieldLogger
 }
 
-func NewVariantSnapshot(config *v1.SippyConfig, views []crview.View, syntheticReleaseJobOverrides *releaseoverride.SyntheticReleaseOverrides, log logrus.FieldLogger) *VariantSnapshot {
+// NewVariantSnapshot creates a snapshot of the variants for all configured jobs. A nil rules uses the
+// built-in classification rules.
+func NewVariantSnapshot(config *v1.SippyConfig, views []crview.View, syntheticReleaseJobOverrides *releaseoverride.SyntheticReleaseOverrides, rules *ClassificationRules, log logrus.FieldLogger) *VariantSnapshot {
 	return &VariantSnapshot{
 		config:                       config,
 		views:                        views,
 		syntheticReleaseJobOverrides: syntheticReleaseJobOverrides,
+		rules:                        rules,
 		log:                          log,
 	}
 }
 
 func (s *VariantSnapshot) Identify() (JobVariants, error) {
 	newVariants := map[string]map[string]string{}
-	variantSyncer := OCPVariantLoader{config: s.config, views: s.views, syntheticReleaseJobOverrides: s.syntheticReleaseJobOverrides}
+	variantSyncer := OCPVariantLoader{config: s.config, views: s.views, syntheticReleaseJobOverrides: s.syntheticReleaseJobOverrides, rules: s.rules}
 	var errs []string
 	for _, releaseCfg := range s.config.Releases {
 		for job := range releaseCfg.Jobs {
@@ -74,16 +80,83 @@ func (s *VariantSnapshot) Load(path string) (JobVariants, error) {
 	return oldVariants, nil
 }
 
-func (s *VariantSnapshot) Save(path string) error {
+// Save writes the current variants to path, returning how they differ from the snapshot previously
+// saved there, if any.
+func (s *VariantSnapshot) Save(path string) ([]VariantChange, error) {
 	newVariants, err := s.Identify()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var changes []VariantChange
+	oldVariants, err := s.Load(path)
+	switch {
+	case err == nil:
+		changes = DiffSnapshots(oldVariants, newVariants)
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
 	}
+
 	y, err := yaml.Marshal(newVariants)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return changes, os.WriteFile(path, y, 0o600)
+}
+
+// VariantChange describes a difference in a job's variants between two snapshots. An empty Old value means
+// the variant was added, and an empty New value that it was removed.
+type VariantChange struct {
+	Job     string
+	Variant string
+	Old     string
+	New     string
+}
+
+// Description describes the change without the job, so identical changes across jobs can be grouped.
+func (c VariantChange) Description() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("added %s:%s", c.Variant, c.New)
+	case c.New == "":
+		return fmt.Sprintf("removed %s:%s", c.Variant, c.Old)
+	default:
+		return fmt.Sprintf("changed %s:%s to %s:%s", c.Variant, c.Old, c.Variant, c.New)
 	}
-	return os.WriteFile(path, y, 0o600)
+}
+
+// DiffSnapshots returns the classification changes between two snapshots, sorted by job and variant.
+// Jobs only present in one of the snapshots have all of their variants reported as added or removed.
+func DiffSnapshots(oldVariants, newVariants JobVariants) []VariantChange {
+	var changes []VariantChange
+	for job, oldVars := range oldVariants {
+		newVars := newVariants[job]
+		for variant, oldValue := range oldVars {
+			if newValue := newVars[variant]; newValue != oldValue {
+				changes = append(changes, VariantChange{Job: job, Variant: variant, Old: oldValue, New: newValue})
+			}
+		}
+		for variant, newValue := range newVars {
+			if _, ok := oldVars[variant]; !ok {
+				changes = append(changes, VariantChange{Job: job, Variant: variant, New: newValue})
+			}
+		}
+	}
+	for job, newVars := range newVariants {
+		if _, ok := oldVariants[job]; ok {
+			continue
+		}
+		for variant, newValue := range newVars {
+			changes = append(changes, VariantChange{Job: job, Variant: variant, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Job != changes[j].Job {
+			return changes[i].Job < changes[j].Job
+		}
+		return changes[i].Variant < changes[j].Variant
+	})
+	return changes
 }
 
 func isIgnoredJob(jobName string) bool {
@@ -73,7 +73,7 @@ func TestRegressionCacheLoader(t *testing.T) {
 	require.NoError(t, err, "error getting releases from postgres")
 
 	// Build a regression store
-	regressionStore := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	regressionStore := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 
 	// Build the data provider using the default provider selection, which
 	// cascades to whichever backend is configured (BigQuery, Postgres, or both).
@@ -82,7 +82,7 @@ func TestRegressionCacheLoader(t *testing.T) {
 
 	// Create and run the loader
 	loader, err := regressioncacheloader.New(
-		dbc, dataProvider,
+		context.Background(), dbc, dataProvider,
 		&configv1.SippyConfig{},
 		sippyViews.ComponentReadiness,
 		releaseConfigs,
@@ -38,7 +38,7 @@ func cleanupAllRegressions(dbc *db.DB) {
 func Test_RegressionTracker(t *testing.T) {
 	dbc := util.CreateE2EPostgresConnection(t)
 	// Pass nil jiraClient as we don't want to comment on jiras in the e2e test
-	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	newRegression := componentreport.ReportTestSummary{
 		TestComparison: testdetails.TestComparison{
 			BaseStats: &testdetails.ReleaseStats{
@@ -282,7 +282,7 @@ func cleanupJobRuns(dbc *db.DB) {
 
 func Test_RegressionJobRuns(t *testing.T) {
 	dbc := util.CreateE2EPostgresConnection(t)
-	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	view := crview.View{
 		Name: "4.19-main",
 		SampleRelease: reqopts.RelativeRelease{
@@ -523,7 +523,7 @@ func cleanupTriages(dbc *db.DB) {
 
 func Test_SyncTriageSymptoms(t *testing.T) {
 	dbc := util.CreateE2EPostgresConnection(t)
-	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	view := crview.View{
 		Name: "4.19-main",
 		SampleRelease: reqopts.RelativeRelease{
@@ -836,7 +836,7 @@ func cleanupRegressionViews(dbc *db.DB) {
 
 func Test_RegressionViews(t *testing.T) {
 	dbc := util.CreateE2EPostgresConnection(t)
-	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	view := crview.View{
 		Name: "4.19-main",
 		SampleRelease: reqopts.RelativeRelease{
@@ -950,6 +950,35 @@ func Test_RegressionViews(t *testing.T) {
 		assert.Len(t, views, 2, "regression should be associated with two views")
 	})
 
+	t.Run("failed fix is combined across views", func(t *testing.T) {
+		defer cleanupAllRegressions(dbc)
+		defer cleanupRegressionViews(dbc)
+
+		reg, err := tracker.OpenRegression(view, newRegSummary("failed-fix-views"))
+		require.NoError(t, err)
+		mainView := crview.View{Name: "4.19-main"}
+		armView := crview.View{Name: "4.19-arm64"}
+
+		require.NoError(t, tracker.UpdateFailedFix(mainView, reg, true))
+		require.True(t, reg.FailedFixDetected.Valid, "a failed fix in one view marks the regression")
+		detected := reg.FailedFixDetected.Time
+
+		// another view that does not see the failed fix must not clear it, on this or later syncs
+		for i := 0; i < 2; i++ {
+			require.NoError(t, tracker.UpdateFailedFix(armView, reg, false))
+			require.NoError(t, tracker.UpdateFailedFix(mainView, reg, true))
+			assert.True(t, reg.FailedFixDetected.Valid, "disagreeing views should not clear the failed fix")
+			assert.True(t, detected.Equal(reg.FailedFixDetected.Time), "the failed fix should keep its first detection time")
+		}
+
+		require.NoError(t, tracker.UpdateFailedFix(mainView, reg, false))
+		assert.False(t, reg.FailedFixDetected.Valid, "the failed fix is cleared once no view sees it")
+
+		var stored models.TestRegression
+		require.NoError(t, dbc.DB.First(&stored, reg.ID).Error)
+		assert.False(t, stored.FailedFixDetected.Valid)
+	})
+
 	t.Run("deactivate rolled-off views removes views not in active map", func(t *testing.T) {
 		defer cleanupAllRegressions(dbc)
 		defer cleanupRegressionViews(dbc)
@@ -1108,7 +1137,7 @@ func Test_RegressionViews(t *testing.T) {
 
 func Test_CrossCompareIsolation(t *testing.T) {
 	dbc := util.CreateE2EPostgresConnection(t)
-	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil)
+	tracker := componentreadiness.NewPostgresRegressionStore(dbc, nil, nil)
 	rLog := log.WithField("test", "cross-compare-isolation")
 
 	standardView := crview.View{
@@ -145,6 +145,142 @@ func TestRecordInfraFailureIsIdempotent(t *testing.T) {
 	assert.Equal(t, 1, labelCount, "InfraFailure label should be applied exactly once")
 }
 
+// TestUnrecordInfraFailureRestoresSummaries records a run as an infrastructure
+// failure and then unrecords it, verifying the label is removed and the run's
+// contribution is added back to both summary tables exactly once.
+func TestUnrecordInfraFailureRestoresSummaries(t *testing.T) {
+	dbc := intutil.NewTestDB(t, pgContainer)
+	jobID := seedProwJob(t, dbc, "periodic-e2e-aws", "4.18")
+	today := testDate
+	ts := time.Date(today.Year, today.Month, today.Day, 10, 0, 0, 0, time.UTC)
+
+	const runID = 41501
+
+	writeBatch(t, dbc, testDate, []pgwriter.JobRunResult{{
+		Run: pgwriter.RunRow{ID: runID, ProwJobID: jobID, ProwJobRelease: "4.18", Timestamp: ts},
+		Tests: []pgwriter.TestRow{
+			{ProwJobRunID: runID, ProwJobID: jobID, ProwJobRunTimestamp: ts, ProwJobRunRelease: "4.18", TestName: "infra-undo-test", SuiteName: "junit_e2e", Status: statusSuccess, Duration: 1.0},
+		},
+	}})
+
+	var test models.Test
+	require.NoError(t, dbc.DB.Where("name = ?", "infra-undo-test").First(&test).Error)
+
+	require.NoError(t, infrafailure.RecordInfraFailure(context.Background(), dbc.DB, runID))
+	// Unrecording twice must add the run back only once.
+	require.NoError(t, infrafailure.UnrecordInfraFailure(context.Background(), dbc.DB, runID))
+	require.NoError(t, infrafailure.UnrecordInfraFailure(context.Background(), dbc.DB, runID))
+
+	var run models.ProwJobRun
+	require.NoError(t, dbc.DB.First(&run, runID).Error)
+	assert.NotContains(t, []string(run.Labels), infrafailure.LabelInfraFailure)
+
+	var dt models.TestDailyTotal
+	require.NoError(t, dbc.DB.Where("test_id = ? AND prow_job_id = ? AND release = ? AND date = ?", test.ID, jobID, "4.18", today).First(&dt).Error)
+	assert.Equal(t, int32(1), dt.Successes)
+	assert.Equal(t, int32(1), dt.Runs)
+
+	for _, d := range []civil.Date{today, today.AddDays(1)} {
+		var cs models.TestCumulativeSummary
+		require.NoError(t, dbc.DB.Where("test_id = ? AND prow_job_id = ? AND release = ? AND date = ?", test.ID, jobID, "4.18", d).First(&cs).Error, "date %s", d)
+		assert.Equal(t, int64(1), cs.PrefixSumSuccesses, "date %s", d)
+		assert.Equal(t, int64(1), cs.PrefixSumRuns, "date %s", d)
+	}
+}
+
+// TestUnrecordInfraFailureCreatesMissingSummaryRows unrecords a run that was
+// already labeled when its batch was written, so it never had summary rows,
+// and verifies the rows are created with its results.
+func TestUnrecordInfraFailureCreatesMissingSummaryRows(t *testing.T) {
+	dbc := intutil.NewTestDB(t, pgContainer)
+	jobID := seedProwJob(t, dbc, "periodic-e2e-aws", "4.18")
+	today := testDate
+	ts := time.Date(today.Year, today.Month, today.Day, 10, 0, 0, 0, time.UTC)
+
+	const labeledRunID = 41601
+	const otherRunID = 41602
+
+	writeBatch(t, dbc, testDate, []pgwriter.JobRunResult{
+		{
+			Run: pgwriter.RunRow{ID: labeledRunID, ProwJobID: jobID, ProwJobRelease: "4.18", Timestamp: ts, Labels: []string{infrafailure.LabelInfraFailure}},
+			Tests: []pgwriter.TestRow{
+				{ProwJobRunID: labeledRunID, ProwJobID: jobID, ProwJobRunTimestamp: ts, ProwJobRunRelease: "4.18", TestName: "infra-undo-missing-test", SuiteName: "junit_e2e", Status: statusFailure, Duration: 1.0},
+			},
+		},
+		{
+			// keeps cumulative summaries for the release through tomorrow
+			Run: pgwriter.RunRow{ID: otherRunID, ProwJobID: jobID, ProwJobRelease: "4.18", Timestamp: ts},
+			Tests: []pgwriter.TestRow{
+				{ProwJobRunID: otherRunID, ProwJobID: jobID, ProwJobRunTimestamp: ts, ProwJobRunRelease: "4.18", TestName: "infra-undo-other-test", SuiteName: "junit_e2e", Status: statusSuccess, Duration: 1.0},
+			},
+		},
+	})
+
+	var test models.Test
+	require.NoError(t, dbc.DB.Where("name = ?", "infra-undo-missing-test").First(&test).Error)
+	var count int64
+	require.NoError(t, dbc.DB.Model(&models.TestDailyTotal{}).Where("test_id = ?", test.ID).Count(&count).Error)
+	require.Equal(t, int64(0), count, "precondition: the labeled run has no daily totals")
+
+	require.NoError(t, infrafailure.UnrecordInfraFailure(context.Background(), dbc.DB, labeledRunID))
+
+	var dt models.TestDailyTotal
+	require.NoError(t, dbc.DB.Where("test_id = ? AND prow_job_id = ? AND release = ? AND date = ?", test.ID, jobID, "4.18", today).First(&dt).Error)
+	assert.Equal(t, int32(1), dt.Failures)
+	assert.Equal(t, int32(1), dt.Runs)
+	require.NotNil(t, dt.LastFailureTimestamp)
+
+	for _, d := range []civil.Date{today, today.AddDays(1)} {
+		var cs models.TestCumulativeSummary
+		require.NoError(t, dbc.DB.Where("test_id = ? AND prow_job_id = ? AND release = ? AND date = ?", test.ID, jobID, "4.18", d).First(&cs).Error, "date %s", d)
+		assert.Equal(t, int64(1), cs.PrefixSumFailures, "date %s", d)
+		assert.Equal(t, int64(1), cs.PrefixSumRuns, "date %s", d)
+	}
+}
+
+// TestSetInfraFailureAudits verifies manual changes are audited, and that
+// requests which change nothing are not.
+func TestSetInfraFailureAudits(t *testing.T) {
+	dbc := intutil.NewTestDB(t, pgContainer)
+	jobID := seedProwJob(t, dbc, "periodic-e2e-aws", "4.18")
+	ts := time.Date(testDate.Year, testDate.Month, testDate.Day, 10, 0, 0, 0, time.UTC)
+
+	const runID = 41701
+
+	writeBatch(t, dbc, testDate, []pgwriter.JobRunResult{{
+		Run: pgwriter.RunRow{ID: runID, ProwJobID: jobID, ProwJobRelease: "4.18", Timestamp: ts},
+		Tests: []pgwriter.TestRow{
+			{ProwJobRunID: runID, ProwJobID: jobID, ProwJobRunTimestamp: ts, ProwJobRunRelease: "4.18", TestName: "infra-audit-test", SuiteName: "junit_e2e", Status: statusSuccess, Duration: 1.0},
+		},
+	}})
+
+	ctx := context.Background()
+	change, err := infrafailure.SetInfraFailure(ctx, dbc.DB, runID, true, "triager", "cluster install failed")
+	require.NoError(t, err)
+	assert.True(t, change.Changed)
+	assert.Contains(t, change.Labels, infrafailure.LabelInfraFailure)
+
+	change, err = infrafailure.SetInfraFailure(ctx, dbc.DB, runID, true, "triager", "")
+	require.NoError(t, err)
+	assert.False(t, change.Changed, "already marked")
+
+	change, err = infrafailure.SetInfraFailure(ctx, dbc.DB, runID, false, "other-triager", "symptom too broad")
+	require.NoError(t, err)
+	assert.True(t, change.Changed)
+	assert.NotContains(t, change.Labels, infrafailure.LabelInfraFailure)
+
+	status, err := infrafailure.GetStatus(ctx, dbc.DB, runID)
+	require.NoError(t, err)
+	assert.False(t, status.InfraFailure)
+	require.Len(t, status.AuditLogs, 2)
+	assert.Equal(t, "other-triager", status.AuditLogs[0].User)
+	assert.Contains(t, string(status.AuditLogs[0].NewData), "symptom too broad")
+	assert.Equal(t, "triager", status.AuditLogs[1].User)
+
+	_, err = infrafailure.SetInfraFailure(ctx, dbc.DB, 41799, true, "triager", "")
+	assert.ErrorIs(t, err, infrafailure.ErrRunNotFound)
+}
+
 // TestCreateBatchDeltasExcludesInfraFailureRuns verifies the write-time
 // exclusion: a run that already carries the InfraFailure label when the batch
 // is written never contributes to the summary tables.